@@ -0,0 +1,112 @@
+// Package banners extracts the text that Recog databases match from raw service
+// greetings and HTTP-style messages, such as the software after "SSH-2.0-" or the
+// text after an FTP reply code. It is shared by the scan output readers and the
+// packet capture tools so that every source feeds the databases the same input.
+package banners
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// FirstLine returns the first line of a value without the line terminator or
+// surrounding whitespace
+func FirstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+// SSHLine returns the SSH identification line, skipping any lines the server sends
+// before it, or an empty string if there is none
+func SSHLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "SSH-") {
+			return line
+		}
+	}
+	return ""
+}
+
+// SSHSoftware returns the software and comment following "SSH-x.x-" in the
+// identification line, the form ssh_banners.xml matches
+func SSHSoftware(s string) string {
+	parts := strings.SplitN(SSHLine(s), "-", 3)
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[2]
+}
+
+var replyCodePat = regexp.MustCompile(`^([1-5][0-9][0-9])[ -]`)
+
+// ReplyCode returns the three digit reply code at the start of an FTP, SMTP or
+// NNTP greeting, if any
+func ReplyCode(s string) string {
+	m := replyCodePat.FindStringSubmatch(s)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// ReplyText returns the text after the reply code of the first line of an FTP,
+// SMTP or NNTP greeting, or the whole line if it has no reply code
+func ReplyText(s string) string {
+	line := FirstLine(s)
+	if ReplyCode(line) == "" {
+		return line
+	}
+	return strings.TrimSpace(line[4:])
+}
+
+var (
+	titlePat = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	spacePat = regexp.MustCompile(`\s+`)
+)
+
+// HTMLTitle extracts the title from an HTML document
+func HTMLTitle(s string) string {
+	m := titlePat.FindStringSubmatch(s)
+	if m == nil {
+		return ""
+	}
+	return CleanTitle(html.UnescapeString(m[1]))
+}
+
+// CleanTitle collapses whitespace in a page title
+func CleanTitle(s string) string {
+	return strings.TrimSpace(spacePat.ReplaceAllString(s, " "))
+}
+
+// SplitHeaders separates the header block of an HTTP-style message from its body
+func SplitHeaders(s string) (string, string) {
+	if i := strings.Index(s, "\r\n\r\n"); i >= 0 {
+		return s[:i], s[i+4:]
+	}
+	if i := strings.Index(s, "\n\n"); i >= 0 {
+		return s[:i], s[i+2:]
+	}
+	return s, ""
+}
+
+// Headers returns the non-empty values of each header of an HTTP-style message,
+// keyed by the lower case header name
+func Headers(s string) map[string][]string {
+	res := make(map[string][]string)
+	headers, _ := SplitHeaders(s)
+	for _, line := range strings.Split(headers, "\n") {
+		line = strings.TrimRight(line, "\r")
+		i := strings.IndexByte(line, ':')
+		if i <= 0 {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(line[:i]))
+		if v := strings.TrimSpace(line[i+1:]); v != "" {
+			res[name] = append(res[name], v)
+		}
+	}
+	return res
+}
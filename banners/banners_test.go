@@ -0,0 +1,43 @@
+package banners
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	cases := []struct {
+		name   string
+		fn     func(string) string
+		input  string
+		output string
+	}{
+		{"FirstLine", FirstLine, " 220 ready\r\n220 more\r\n", "220 ready"},
+		{"SSHSoftware", SSHSoftware, "SSH-2.0-OpenSSH_8.9p1 Ubuntu-3ubuntu0.1\r\n", "OpenSSH_8.9p1 Ubuntu-3ubuntu0.1"},
+		{"SSHSoftware after a preamble", SSHSoftware, "Welcome\r\nSSH-1.99-Cisco-1.25\r\n", "Cisco-1.25"},
+		{"SSHSoftware without an identification", SSHSoftware, "OpenSSH_8.9p1", ""},
+		{"ReplyText", ReplyText, "220-ProFTPD Server ready.\r\n220 more\r\n", "ProFTPD Server ready."},
+		{"ReplyText without a code", ReplyText, "ProFTPD", "ProFTPD"},
+		{"HTMLTitle", HTMLTitle, "<html><TITLE>\n  Tom &amp; Jerry\n</TITLE></html>", "Tom & Jerry"},
+		{"HTMLTitle without a title", HTMLTitle, "<html></html>", ""},
+	}
+	for _, c := range cases {
+		if res := c.fn(c.input); res != c.output {
+			t.Errorf("%s(%q) returned %q, expected %q", c.name, c.input, res, c.output)
+		}
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	msg := "HTTP/1.1 401 Unauthorized\r\nServer: nginx\r\nSet-Cookie: a=1\r\nset-cookie: b=2\r\nX-Empty:\r\n\r\nServer: body"
+	want := map[string][]string{
+		"server":     {"nginx"},
+		"set-cookie": {"a=1", "b=2"},
+	}
+	if res := Headers(msg); !reflect.DeepEqual(res, want) {
+		t.Errorf("Headers() returned %v", res)
+	}
+	if _, body := SplitHeaders("Server: x\n\nbody"); body != "body" {
+		t.Errorf("SplitHeaders() returned body %q", body)
+	}
+}
@@ -0,0 +1,250 @@
+package recog
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BatchItem is a single input for batch matching
+type BatchItem struct {
+	// ID is an opaque value returned with the result, such as a record number
+	ID       interface{}
+	MatchKey string
+	Input    string
+}
+
+// BatchResult is the outcome of matching one BatchItem
+type BatchResult struct {
+	Item    BatchItem
+	Matches []*FingerprintMatch
+	// Nodes, Edges and Truncated are set instead of Matches when traversing
+	Nodes     []*MatchNode
+	Edges     []*MatchEdge
+	Truncated []Truncation
+	Err       error
+	seq       int
+}
+
+// Matched reports whether the item produced any matches
+func (r *BatchResult) Matched() bool {
+	return len(r.Matches) > 0 || len(r.Nodes) > 0
+}
+
+// BatchOptions controls how a batch is matched
+type BatchOptions struct {
+	// Workers is the number of concurrent matchers, defaulting to the CPU count
+	Workers int
+	// Unordered returns results as they complete instead of in input order
+	Unordered bool
+	// First uses MatchFirst instead of MatchAll for each item
+	First bool
+	// Traverse uses TraverseMatchGraph for each item and fills in Nodes, Edges
+	// and Truncated
+	Traverse        bool
+	TraverseOptions TraverseOptions
+}
+
+// BatchStats summarizes the progress of a batch
+type BatchStats struct {
+	Items   int64
+	Matched int64
+	Errors  int64
+	Elapsed time.Duration
+}
+
+// ItemsPerSecond returns the throughput of the batch
+func (s BatchStats) ItemsPerSecond() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Items) / s.Elapsed.Seconds()
+}
+
+// Batch is a running batch match
+type Batch struct {
+	results chan *BatchResult
+	start   time.Time
+	items   int64
+	matched int64
+	errors  int64
+	// elapsed is set once every result has been delivered
+	elapsed int64
+}
+
+// Results returns the channel of results, which is closed once every item has
+// been matched. The channel must be drained for the batch to make progress.
+func (b *Batch) Results() <-chan *BatchResult {
+	return b.results
+}
+
+// Stats returns the progress so far, or the final totals once Results is closed
+func (b *Batch) Stats() BatchStats {
+	s := BatchStats{
+		Items:   atomic.LoadInt64(&b.items),
+		Matched: atomic.LoadInt64(&b.matched),
+		Errors:  atomic.LoadInt64(&b.errors),
+		Elapsed: time.Duration(atomic.LoadInt64(&b.elapsed)),
+	}
+	if s.Elapsed == 0 {
+		s.Elapsed = time.Since(b.start)
+	}
+	return s
+}
+
+// record counts a result before it is delivered
+func (b *Batch) record(r *BatchResult) {
+	atomic.AddInt64(&b.items, 1)
+	if r.Matched() {
+		atomic.AddInt64(&b.matched, 1)
+	}
+	if r.Err != nil {
+		atomic.AddInt64(&b.errors, 1)
+	}
+}
+
+// reorderWindow is the number of items per worker that an ordered batch lets be
+// in flight or waiting on an earlier, slower item
+const reorderWindow = 4
+
+// MatchBatch matches items read from a channel on a pool of workers until the
+// channel is closed or the context is done. Results are delivered in input order
+// unless Unordered is set, and each item is subject to the set's size and time
+// limits.
+func (fs *FingerprintSet) MatchBatch(ctx context.Context, items <-chan BatchItem, opts BatchOptions) *Batch {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	b := &Batch{results: make(chan *BatchResult, workers), start: time.Now()}
+	jobs := make(chan *BatchResult, workers)
+	done := make(chan *BatchResult, workers)
+	// Ordered results wait for the items before them, so bound how far ahead the
+	// feeder may get rather than letting the reorder buffer grow without limit
+	var window chan struct{}
+	if !opts.Unordered {
+		window = make(chan struct{}, workers*reorderWindow)
+	}
+
+	// Number the items so ordered results can be put back in sequence
+	go func() {
+		defer close(jobs)
+		for seq := 0; ; seq++ {
+			var item BatchItem
+			var ok bool
+			select {
+			case item, ok = <-items:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+			if window != nil {
+				select {
+				case window <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case jobs <- &BatchResult{Item: item, seq: seq}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for r := range jobs {
+				fs.matchBatchItem(ctx, r, opts)
+				done <- r
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	go func() {
+		defer close(b.results)
+		defer func() { atomic.StoreInt64(&b.elapsed, int64(time.Since(b.start))) }()
+
+		if opts.Unordered {
+			for r := range done {
+				b.record(r)
+				b.results <- r
+			}
+			return
+		}
+
+		pending := make(map[int]*BatchResult)
+		next := 0
+		for r := range done {
+			pending[r.seq] = r
+			for {
+				r, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				b.record(r)
+				b.results <- r
+				<-window
+				next++
+			}
+		}
+	}()
+
+	return b
+}
+
+// matchBatchItem matches a single item in place
+func (fs *FingerprintSet) matchBatchItem(ctx context.Context, r *BatchResult, opts BatchOptions) {
+	switch {
+	case opts.Traverse:
+		var graph *MatchGraph
+		graph, r.Err = TraverseMatchGraph(ctx, fs, r.Item.MatchKey, r.Item.Input, opts.TraverseOptions)
+		r.Nodes, r.Edges, r.Truncated = graph.Nodes, graph.Edges, graph.Truncated
+	case opts.First:
+		var m *FingerprintMatch
+		m, r.Err = fs.MatchFirstContext(ctx, r.Item.MatchKey, r.Item.Input)
+		if m != nil {
+			r.Matches = []*FingerprintMatch{m}
+		}
+	default:
+		r.Matches, r.Err = fs.MatchAllContext(ctx, r.Item.MatchKey, r.Item.Input)
+	}
+}
+
+// MatchBatchSlice matches a slice of items and returns the results in input order
+// along with the final statistics
+func (fs *FingerprintSet) MatchBatchSlice(ctx context.Context, items []BatchItem, opts BatchOptions) ([]*BatchResult, BatchStats) {
+	ch := make(chan BatchItem)
+	go func() {
+		defer close(ch)
+		for _, item := range items {
+			select {
+			case ch <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	opts.Unordered = false
+	b := fs.MatchBatch(ctx, ch, opts)
+	results := make([]*BatchResult, 0, len(items))
+	for r := range b.Results() {
+		results = append(results, r)
+	}
+	return results, b.Stats()
+}
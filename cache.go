@@ -0,0 +1,153 @@
+package recog
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+)
+
+// MatchCache is a bounded least recently used cache of match results, keyed by
+// match key and a hash of the input as matched. It is safe for concurrent use.
+type MatchCache struct {
+	mu        sync.Mutex
+	size      int
+	order     *list.List
+	entries   map[cacheKey]*list.Element
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// CacheStats reports the effectiveness of a MatchCache
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+}
+
+// HitRate returns the fraction of lookups served from the cache
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// cacheKey identifies a match call by its match key, mode and matched input
+type cacheKey struct {
+	name   string
+	first  bool
+	binary bool
+	sum    [sha256.Size]byte
+}
+
+// cacheEntry is a cached result, which is never handed out directly
+type cacheEntry struct {
+	key     cacheKey
+	matches []*FingerprintMatch
+}
+
+// NewMatchCache returns a cache holding up to size results
+func NewMatchCache(size int) *MatchCache {
+	if size < 1 {
+		size = 1
+	}
+	return &MatchCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[cacheKey]*list.Element),
+	}
+}
+
+// newCacheKey hashes the input of a match call after limits and normalization
+func newCacheKey(name string, data []byte, first bool, binary bool) cacheKey {
+	return cacheKey{name: name, first: first, binary: binary, sum: sha256.Sum256(data)}
+}
+
+// get returns a copy of the cached result for a key
+func (c *MatchCache) get(key cacheKey) ([]*FingerprintMatch, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(el)
+	return copyMatches(el.Value.(*cacheEntry).matches), true
+}
+
+// put stores a copy of a result, evicting the least recently used entry if needed
+func (c *MatchCache) put(key cacheKey, matches []*FingerprintMatch) {
+	if c == nil {
+		return
+	}
+	matches = copyMatches(matches)
+	// each call records its own input, which may be a caller's buffer
+	for _, m := range matches {
+		m.Input, m.Normalized, m.InputBytes, m.NormalizedBytes = "", "", nil, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*cacheEntry).matches = matches
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, matches: matches})
+	for c.order.Len() > c.size {
+		el := c.order.Back()
+		c.order.Remove(el)
+		delete(c.entries, el.Value.(*cacheEntry).key)
+		c.evictions++
+	}
+}
+
+// Purge removes every cached result, keeping the counters
+func (c *MatchCache) Purge() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = make(map[cacheKey]*list.Element)
+}
+
+// Stats returns the cache counters
+func (c *MatchCache) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Entries: c.order.Len()}
+}
+
+// copyMatches deep copies match results so callers can modify them freely. The
+// fingerprints themselves are shared.
+func copyMatches(matches []*FingerprintMatch) []*FingerprintMatch {
+	if matches == nil {
+		return nil
+	}
+	res := make([]*FingerprintMatch, len(matches))
+	for i, m := range matches {
+		c := *m
+		if m.Errors != nil {
+			c.Errors = append([]error(nil), m.Errors...)
+		}
+		if m.Values != nil {
+			c.Values = make(map[string]string, len(m.Values))
+			for k, v := range m.Values {
+				c.Values[k] = v
+			}
+		}
+		res[i] = &c
+	}
+	return res
+}
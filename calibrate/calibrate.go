@@ -0,0 +1,273 @@
+// Package calibrate measures the precision of Recog fingerprints against a labeled
+// corpus and proposes certainty values to replace the hand-assigned ones. The
+// proposals are written as recog.CertaintyOverrides, which a FingerprintSet loads
+// alongside the XML databases.
+package calibrate
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+// Sample is a labeled input, stored in a corpus as a JSON line:
+//
+//	{"match_key": "ssh.banner", "input": "OpenSSH_8.9p1 Ubuntu-3ubuntu0.1", "expected": {"os.product": "Linux", "service.product": "OpenSSH"}}
+type Sample struct {
+	MatchKey string `json:"match_key"`
+	Input    string `json:"input"`
+	// Expected holds the values a correct match asserts, such as os.product
+	Expected map[string]string `json:"expected"`
+}
+
+// ReadCorpus parses a corpus of JSON lines, skipping blank lines and lines that
+// start with #
+func ReadCorpus(r io.Reader) ([]Sample, error) {
+	var res []Sample
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var s Sample
+		if err := json.Unmarshal([]byte(text), &s); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		if s.MatchKey == "" || len(s.Expected) == 0 {
+			return nil, fmt.Errorf("line %d: sample needs a match key and expected values", line)
+		}
+		res = append(res, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read corpus: %s", err)
+	}
+	return res, nil
+}
+
+// LoadCorpus reads a corpus file
+func LoadCorpus(path string) ([]Sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open corpus: %s", err)
+	}
+	defer f.Close()
+	return ReadCorpus(f)
+}
+
+// Stats counts the outcomes of matches. A match is correct when it agrees with
+// every expected value it asserts, incorrect when it contradicts one, and
+// uninformative when it asserts none of them.
+type Stats struct {
+	Matches       int
+	Correct       int
+	Incorrect     int
+	Uninformative int
+}
+
+// Judged returns the number of matches that were correct or incorrect
+func (s Stats) Judged() int {
+	return s.Correct + s.Incorrect
+}
+
+// Precision returns the share of judged matches that were correct
+func (s Stats) Precision() float64 {
+	if s.Judged() == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Judged())
+}
+
+func (s *Stats) add(o Stats) {
+	s.Matches += o.Matches
+	s.Correct += o.Correct
+	s.Incorrect += o.Incorrect
+	s.Uninformative += o.Uninformative
+}
+
+// FingerprintStats measures one fingerprint
+type FingerprintStats struct {
+	Stats
+	Fingerprint *recog.Fingerprint
+	// Certainty is the certainty the fingerprint had during calibration
+	Certainty float64
+	// Proposed is the calibrated certainty, see Options.PriorWeight
+	Proposed float64
+	// Errors holds samples the fingerprint matched incorrectly
+	Errors []Sample
+}
+
+// DatabaseStats measures one database
+type DatabaseStats struct {
+	Stats
+	Database string
+	// Samples is the number of samples matched against the database, and Missed
+	// the number of those no fingerprint matched
+	Samples int
+	Missed  int
+}
+
+// Report is the outcome of a calibration run
+type Report struct {
+	// Fingerprints holds each fingerprint that matched a sample, ordered by
+	// database and position
+	Fingerprints []*FingerprintStats
+	Databases    []*DatabaseStats
+	// Unmatched holds samples that no database matched
+	Unmatched []Sample
+	Errors    []error
+}
+
+// Options controls how certainty is calibrated
+type Options struct {
+	// PriorWeight is the number of pseudo-samples at the current certainty that are
+	// blended with the measured precision, so that few samples move a certainty
+	// little. Zero uses DefaultPriorWeight.
+	PriorWeight float64
+	// MaxErrors limits the incorrect samples kept per fingerprint
+	MaxErrors int
+}
+
+// DefaultPriorWeight is the PriorWeight used when Options does not set one
+const DefaultPriorWeight = 4
+
+// Calibrate matches every sample and measures each fingerprint. Samples with a
+// missing match key are reported in Errors, while a context error stops
+// calibration and is returned.
+func Calibrate(ctx context.Context, fs *recog.FingerprintSet, samples []Sample, opts Options) (*Report, error) {
+	if opts.PriorWeight <= 0 {
+		opts.PriorWeight = DefaultPriorWeight
+	}
+
+	res := &Report{}
+	fingerprints := make(map[*recog.Fingerprint]*FingerprintStats)
+	databases := make(map[string]*DatabaseStats)
+
+	for _, sample := range samples {
+		matches, err := fs.MatchAllContext(ctx, sample.MatchKey, sample.Input)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			res.Errors = append(res.Errors, fmt.Errorf("%s %q: %s", sample.MatchKey, sample.Input, err))
+			continue
+		}
+		if len(matches) == 0 {
+			res.Unmatched = append(res.Unmatched, sample)
+		}
+
+		matched := make(map[string]bool)
+		for _, m := range matches {
+			fp := m.Fingerprint
+			// a suppressed match recognizes the input without attributing it
+			if m.Suppressed {
+				matched[fp.DB.Name] = true
+				continue
+			}
+			stats, ok := fingerprints[fp]
+			if !ok {
+				c, _ := strconv.ParseFloat(fp.Certainty, 64)
+				stats = &FingerprintStats{Fingerprint: fp, Certainty: c}
+				fingerprints[fp] = stats
+				res.Fingerprints = append(res.Fingerprints, stats)
+			}
+			outcome := judge(m, sample.Expected)
+			stats.add(outcome)
+			if outcome.Incorrect > 0 && (opts.MaxErrors <= 0 || len(stats.Errors) < opts.MaxErrors) {
+				stats.Errors = append(stats.Errors, sample)
+			}
+			matched[fp.DB.Name] = true
+		}
+
+		for _, fdb := range fs.DatabasesByMatchKey[sample.MatchKey] {
+			stats, ok := databases[fdb.Name]
+			if !ok {
+				stats = &DatabaseStats{Database: fdb.Name}
+				databases[fdb.Name] = stats
+				res.Databases = append(res.Databases, stats)
+			}
+			stats.Samples++
+			if !matched[fdb.Name] {
+				stats.Missed++
+			}
+		}
+	}
+
+	for _, stats := range res.Fingerprints {
+		stats.Proposed = propose(stats.Stats, stats.Certainty, opts.PriorWeight)
+		if db, ok := databases[stats.Fingerprint.DB.Name]; ok {
+			db.add(stats.Stats)
+		}
+	}
+
+	sort.Slice(res.Fingerprints, func(i, j int) bool {
+		a, b := res.Fingerprints[i].Fingerprint, res.Fingerprints[j].Fingerprint
+		if a.DB.Name != b.DB.Name {
+			return a.DB.Name < b.DB.Name
+		}
+		return a.Index < b.Index
+	})
+	sort.Slice(res.Databases, func(i, j int) bool {
+		return res.Databases[i].Database < res.Databases[j].Database
+	})
+	return res, nil
+}
+
+// judge compares a match with the expected values of its sample
+func judge(m *recog.FingerprintMatch, expected map[string]string) Stats {
+	res := Stats{Matches: 1}
+	asserted := false
+	for key, want := range expected {
+		got, ok := m.Values[key]
+		if !ok {
+			continue
+		}
+		asserted = true
+		if !strings.EqualFold(strings.TrimSpace(got), strings.TrimSpace(want)) {
+			res.Incorrect = 1
+			return res
+		}
+	}
+	if asserted {
+		res.Correct = 1
+	} else {
+		res.Uninformative = 1
+	}
+	return res
+}
+
+// propose blends the measured precision with the current certainty, weighting the
+// current certainty as prior pseudo-samples, and rounds to two decimals
+func propose(s Stats, current float64, prior float64) float64 {
+	p := (float64(s.Correct) + prior*current) / (float64(s.Judged()) + prior)
+	return math.Round(p*100) / 100
+}
+
+// Overrides returns the proposed certainty of each fingerprint judged on at least
+// minSamples matches, where it differs from the current certainty
+func (r *Report) Overrides(minSamples int) *recog.CertaintyOverrides {
+	res := &recog.CertaintyOverrides{Overrides: []recog.CertaintyOverride{}}
+	for _, stats := range r.Fingerprints {
+		if stats.Judged() == 0 || stats.Judged() < minSamples || stats.Proposed == stats.Certainty {
+			continue
+		}
+		res.Overrides = append(res.Overrides, recog.CertaintyOverride{
+			Database:  stats.Fingerprint.DB.Name,
+			Pattern:   stats.Fingerprint.Pattern,
+			Certainty: stats.Proposed,
+			Samples:   stats.Judged(),
+			Precision: math.Round(stats.Precision()*1000) / 1000,
+		})
+	}
+	return res
+}
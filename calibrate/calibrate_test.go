@@ -0,0 +1,98 @@
+package calibrate
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+func TestCalibrate(t *testing.T) {
+	samples, err := LoadCorpus("testdata/corpus.jsonl")
+	if err != nil {
+		t.Fatalf("failed to load corpus: %s", err)
+	}
+	if len(samples) != 6 {
+		t.Fatalf("expected 6 samples, got %d", len(samples))
+	}
+	if _, err := ReadCorpus(strings.NewReader(`{"match_key": "ssh.banner", "input": "x"}`)); err == nil {
+		t.Errorf("expected an error for a sample without expected values")
+	}
+
+	fs, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("failed to load fingerprints: %s", err)
+	}
+	report, err := Calibrate(context.Background(), fs, samples, Options{})
+	if err != nil {
+		t.Fatalf("calibrate failed: %s", err)
+	}
+	if len(report.Errors) != 1 || len(report.Unmatched) != 1 {
+		t.Errorf("expected 1 error and 1 unmatched sample, got %v %v", report.Errors, report.Unmatched)
+	}
+
+	var ssh *DatabaseStats
+	for _, db := range report.Databases {
+		if db.Database == "ssh_banners.xml" {
+			ssh = db
+		}
+	}
+	if ssh == nil || ssh.Samples != 3 || ssh.Missed != 0 || ssh.Incorrect != 1 || ssh.Correct != ssh.Matches-1 {
+		t.Fatalf("unexpected ssh database stats: %+v", ssh)
+	}
+
+	// The mislabeled banner lowers the certainty of the fingerprint that matched it
+	var generic *FingerprintStats
+	for _, fp := range report.Fingerprints {
+		if fp.Incorrect > 0 {
+			generic = fp
+		}
+	}
+	if generic == nil || generic.Judged() != 3 || len(generic.Errors) != 1 {
+		t.Fatalf("unexpected fingerprint stats: %+v", generic)
+	}
+	want := (2 + DefaultPriorWeight*generic.Certainty) / (3 + DefaultPriorWeight)
+	if generic.Proposed >= generic.Certainty || generic.Proposed-want > 0.005 || want-generic.Proposed > 0.005 {
+		t.Errorf("expected a proposed certainty near %f, got %f", want, generic.Proposed)
+	}
+
+	overrides := report.Overrides(3)
+	if len(overrides.Overrides) != 1 || overrides.Overrides[0].Pattern != generic.Fingerprint.Pattern {
+		t.Fatalf("unexpected overrides: %+v", overrides)
+	}
+	if err := fs.ApplyCertaintyOverrides(overrides); err != nil {
+		t.Fatalf("failed to apply overrides: %s", err)
+	}
+	m, err := fs.MatchFirst("ssh.banner", generic.Errors[0].Input)
+	if err != nil || m == nil || m.Fingerprint != generic.Fingerprint {
+		t.Fatalf("unexpected match %v: %s", m, err)
+	}
+	if c, _ := strconv.ParseFloat(m.Values["fp.certainty"], 64); c != generic.Proposed {
+		t.Errorf("expected the calibrated certainty, got %q", m.Values["fp.certainty"])
+	}
+
+	// A suppressed banner recognizes the input without being judged
+	err = fs.AddSuppressions(&recog.Suppressions{Suppressions: []recog.Suppression{
+		{Database: "ssh_banners.xml", Pattern: generic.Errors[0].Input},
+	}})
+	if err != nil {
+		t.Fatalf("failed to add suppression: %s", err)
+	}
+	report, err = Calibrate(context.Background(), fs, samples, Options{})
+	if err != nil {
+		t.Fatalf("calibrate failed: %s", err)
+	}
+	for _, db := range report.Databases {
+		if db.Database == "ssh_banners.xml" && (db.Incorrect != 0 || db.Missed != 0) {
+			t.Errorf("expected the suppressed banner to be recognized but not judged: %+v", db)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Calibrate(ctx, fs, samples, Options{}); err == nil {
+		t.Errorf("expected an error from a cancelled context")
+	}
+}
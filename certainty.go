@@ -0,0 +1,174 @@
+package recog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CertaintyOverridesFile is loaded alongside the XML databases by
+// LoadFingerprintsFromFS when it is present
+const CertaintyOverridesFile = "certainty_overrides.json"
+
+// CertaintyOverride replaces the certainty of a fingerprint, or of every
+// fingerprint in a database when Pattern is empty
+type CertaintyOverride struct {
+	// Database is the file name of the database, such as ssh_banners.xml
+	Database  string  `json:"database"`
+	Pattern   string  `json:"pattern,omitempty"`
+	Certainty float64 `json:"certainty"`
+	// Samples and Precision record the measurement behind a calibrated override
+	Samples   int     `json:"samples,omitempty"`
+	Precision float64 `json:"precision,omitempty"`
+}
+
+// CertaintyOverrides is a set of certainty overrides, stored as JSON:
+//
+//	{"overrides": [
+//	  {"database": "ssh_banners.xml", "certainty": 0.8},
+//	  {"database": "ssh_banners.xml", "pattern": "^OpenSSH_(\\S+)$", "certainty": 0.93, "samples": 40, "precision": 0.95}
+//	]}
+//
+// Database overrides are applied before fingerprint overrides, so a fingerprint
+// keeps its own override regardless of order.
+type CertaintyOverrides struct {
+	Overrides []CertaintyOverride `json:"overrides"`
+}
+
+// ReadCertaintyOverrides parses certainty overrides from JSON
+func ReadCertaintyOverrides(r io.Reader) (*CertaintyOverrides, error) {
+	var res CertaintyOverrides
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to parse certainty overrides: %s", err)
+	}
+	for _, o := range res.Overrides {
+		if o.Database == "" {
+			return nil, fmt.Errorf("certainty override is missing a database")
+		}
+		if o.Certainty < 0 || o.Certainty > 1 {
+			return nil, fmt.Errorf("certainty override for %s is out of range: %g", o.Database, o.Certainty)
+		}
+	}
+	return &res, nil
+}
+
+// Write stores the overrides as indented JSON
+func (o *CertaintyOverrides) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(o); err != nil {
+		return fmt.Errorf("failed to encode certainty overrides: %s", err)
+	}
+	return nil
+}
+
+// formatCertainty formats a certainty the way it appears in the XML databases
+func formatCertainty(c float64) string {
+	s := strconv.FormatFloat(c, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// setCertainty replaces the certainty of a fingerprint. Namespace certainties it
+// asserts, such as hw.certainty, are scaled by the same factor, so that values the
+// database deliberately distrusts stay distrusted.
+func (fp *Fingerprint) setCertainty(c float64) {
+	old, err := strconv.ParseFloat(fp.Certainty, 64)
+	fp.Certainty = formatCertainty(c)
+	if err != nil || old <= 0 {
+		return
+	}
+	for _, param := range fp.Params {
+		if param.Position != "0" || !strings.HasSuffix(param.Name, ".certainty") {
+			continue
+		}
+		ns, err := strconv.ParseFloat(param.Value, 64)
+		if err != nil {
+			continue
+		}
+		param.Value = formatCertainty(math.Min(1, math.Round(ns*c/old*1000)/1000))
+	}
+}
+
+// ApplyCertaintyOverrides replaces the certainty of the loaded fingerprints. Every
+// override is applied that can be, and an error names the first one that matched
+// no database or fingerprint.
+func (fs *FingerprintSet) ApplyCertaintyOverrides(o *CertaintyOverrides) error {
+	if missing := fs.applyCertaintyOverrides(o); len(missing) > 0 {
+		return missing[0]
+	}
+	return nil
+}
+
+// applyCertaintyOverrides applies the overrides and returns an error for each one
+// that matched no database or fingerprint
+func (fs *FingerprintSet) applyCertaintyOverrides(o *CertaintyOverrides) []error {
+	defer fs.Cache.Purge()
+
+	var missing []error
+	for _, dbOnly := range []bool{true, false} {
+		for _, override := range o.Overrides {
+			if (override.Pattern == "") != dbOnly {
+				continue
+			}
+			found := false
+			for _, fdb := range fs.databasesByName(override.Database) {
+				for _, fp := range fdb.Fingerprints {
+					if dbOnly || fp.Pattern == override.Pattern {
+						fp.setCertainty(override.Certainty)
+						found = true
+					}
+				}
+			}
+			if found {
+				continue
+			}
+			if dbOnly {
+				missing = append(missing, fmt.Errorf("certainty override for missing database %s", override.Database))
+			} else {
+				missing = append(missing, fmt.Errorf("certainty override for missing fingerprint %s in %s", override.Pattern, override.Database))
+			}
+		}
+	}
+	return missing
+}
+
+// LoadCertaintyOverrides reads a certainty overrides file and applies it
+func (fs *FingerprintSet) LoadCertaintyOverrides(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open certainty overrides: %s", err)
+	}
+	defer f.Close()
+	o, err := ReadCertaintyOverrides(f)
+	if err != nil {
+		return err
+	}
+	return fs.ApplyCertaintyOverrides(o)
+}
+
+// loadCertaintyOverridesFS applies the overrides file of a database directory, if
+// there is one. Overrides left stale by database updates are logged rather than
+// failing the load.
+func (fs *FingerprintSet) loadCertaintyOverridesFS(efs http.FileSystem) error {
+	f, err := efs.Open(CertaintyOverridesFile)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	o, err := ReadCertaintyOverrides(f)
+	if err != nil {
+		return err
+	}
+	for _, err := range fs.applyCertaintyOverrides(o) {
+		fs.DebugLogf("skipped %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package recog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestCertaintyOverrides(t *testing.T) {
+	dir := t.TempDir()
+	data, err := os.ReadFile("test/xml/html_title.xml")
+	if err != nil {
+		t.Fatalf("failed to read database: %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "html_title.xml"), data, 0o644); err != nil {
+		t.Fatalf("failed to write database: %s", err)
+	}
+
+	// The fingerprint override wins even though it is listed first, and a stale
+	// override is logged without failing the load
+	overrides := `{"overrides": [
+		{"database": "html_title.xml", "pattern": "^MoinMoinWiki", "certainty": 0.95, "samples": 20, "precision": 0.97},
+		{"database": "html_title.xml", "pattern": "^RemovedUpstream", "certainty": 0.2},
+		{"database": "html_title.xml", "certainty": 0.5}
+	]}`
+	if err := os.WriteFile(filepath.Join(dir, CertaintyOverridesFile), []byte(overrides), 0o644); err != nil {
+		t.Fatalf("failed to write overrides: %s", err)
+	}
+	var logged bytes.Buffer
+	fset := NewFingerprintSet()
+	fset.Logger = log.New()
+	fset.Logger.SetOutput(&logged)
+	if err := fset.LoadFingerprintsDir(dir); err != nil {
+		t.Fatalf("LoadFingerprintsDir() failed: %s", err)
+	}
+	if !strings.Contains(logged.String(), "^RemovedUpstream") {
+		t.Errorf("expected the stale override to be logged, got %q", logged.String())
+	}
+	if err := fset.LoadCertaintyOverrides(filepath.Join(dir, CertaintyOverridesFile)); err == nil {
+		t.Errorf("expected an explicit load to fail on the stale override")
+	}
+	m, err := fset.MatchFirst("html_title", "MoinMoinWiki - MoinMoin")
+	if err != nil || m == nil {
+		t.Fatalf("MatchFirst() failed: %v %s", m, err)
+	}
+	if m.Values["fp.certainty"] != "0.95" {
+		t.Errorf("expected an overridden certainty, got %q", m.Values["fp.certainty"])
+	}
+
+	err = fset.ApplyCertaintyOverrides(&CertaintyOverrides{Overrides: []CertaintyOverride{
+		{Database: "html_title.xml", Certainty: 1},
+		{Database: "missing.xml", Certainty: 0.5},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "missing.xml") {
+		t.Errorf("expected an error for a missing database, got %v", err)
+	}
+	if fp := fset.DatabasesByMatchKey["html_title.xml"][0].Fingerprints[0]; fp.Certainty != "1.0" {
+		t.Errorf("expected the other overrides to apply, got %q", fp.Certainty)
+	}
+
+	for _, bad := range []string{`{"overrides": [{"certainty": 0.5}]}`, `{"overrides": [{"database": "a.xml", "certainty": 2}]}`, `{`} {
+		if _, err := ReadCertaintyOverrides(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected an error for %s", bad)
+		}
+	}
+
+	// Namespace certainties are scaled rather than replaced, so distrusted values
+	// stay distrusted
+	fp := &Fingerprint{Certainty: "0.8", Params: []*FingerprintParam{
+		{Position: "0", Name: "os.certainty", Value: "0.4"},
+		{Position: "0", Name: "hw.certainty", Value: "0.0"},
+		{Position: "0", Name: "service.certainty", Value: "1.0"},
+		{Position: "1", Name: "os.version"},
+	}}
+	fp.setCertainty(0.6)
+	if fp.Certainty != "0.6" || fp.Params[0].Value != "0.3" || fp.Params[1].Value != "0.0" || fp.Params[2].Value != "0.75" || fp.Params[3].Value != "" {
+		t.Errorf("unexpected certainty %q %+v %+v %+v", fp.Certainty, fp.Params[0], fp.Params[1], fp.Params[2])
+	}
+	fp.setCertainty(1)
+	if fp.Params[2].Value != "1.0" {
+		t.Errorf("expected namespace certainty to be capped, got %q", fp.Params[2].Value)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/runZeroInc/recog-go"
+	"github.com/runZeroInc/recog-go/calibrate"
+)
+
+type Options struct {
+	Root        string  `long:"root" description:"Root directory of the fingerprint files" default:"xml"`
+	Corpus      string  `long:"corpus" short:"c" description:"Labeled corpus of JSON lines, or - for stdin" required:"true"`
+	Output      string  `long:"output" short:"o" description:"Write the proposed certainty overrides to a file, or - for stdout"`
+	MinSamples  int     `long:"min-samples" short:"n" description:"Only propose overrides for fingerprints judged on at least this many samples" default:"5"`
+	PriorWeight float64 `long:"prior-weight" description:"Pseudo-samples at the current certainty blended with the measured precision" default:"4"`
+	Errors      int     `long:"errors" short:"e" description:"Print up to this many incorrect samples per fingerprint" default:"3"`
+	Overrides   string  `long:"overrides" description:"Apply existing certainty overrides before calibrating"`
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return os.Stdin, nil
+	}
+	return os.Open(path)
+}
+
+// printReport writes the precision of each database and fingerprint
+func printReport(w io.Writer, report *calibrate.Report) {
+	fmt.Fprintf(w, "****** Databases ******\n")
+	for _, db := range report.Databases {
+		fmt.Fprintf(w, "%-32s samples=%d missed=%d matches=%d correct=%d incorrect=%d precision=%.3f\n",
+			db.Database, db.Samples, db.Missed, db.Matches, db.Correct, db.Incorrect, db.Precision())
+	}
+
+	fmt.Fprintf(w, "\n****** Fingerprints ******\n")
+	for _, fp := range report.Fingerprints {
+		fmt.Fprintf(w, "%s:%d %s\n", fp.Fingerprint.DB.Name, fp.Fingerprint.Index, fp.Fingerprint.Pattern)
+		fmt.Fprintf(w, "    matches=%d correct=%d incorrect=%d uninformative=%d precision=%.3f certainty=%.2f proposed=%.2f\n",
+			fp.Matches, fp.Correct, fp.Incorrect, fp.Uninformative, fp.Precision(), fp.Certainty, fp.Proposed)
+		for _, s := range fp.Errors {
+			fmt.Fprintf(w, "    INCORRECT: %s %q expected %v\n", s.MatchKey, s.Input, s.Expected)
+		}
+	}
+
+	if len(report.Unmatched) > 0 {
+		fmt.Fprintf(w, "\n****** Unmatched ******\n")
+		for _, s := range report.Unmatched {
+			fmt.Fprintf(w, "%s %q\n", s.MatchKey, s.Input)
+		}
+	}
+	for _, err := range report.Errors {
+		fmt.Fprintf(os.Stderr, "SKIPPED: %s\n", err)
+	}
+}
+
+func main() {
+	var opts Options
+	_, err := flags.ParseArgs(&opts, os.Args)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	fpset, err := recog.LoadFingerprintsDir(opts.Root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load fingerprints: %s\n", err)
+		os.Exit(1)
+	}
+	if opts.Overrides != "" {
+		if err := fpset.LoadCertaintyOverrides(opts.Overrides); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to apply overrides: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	r, err := openInput(opts.Corpus)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open corpus: %s\n", err)
+		os.Exit(1)
+	}
+	samples, err := calibrate.ReadCorpus(r)
+	r.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read corpus: %s\n", err)
+		os.Exit(1)
+	}
+
+	report, err := calibrate.Calibrate(context.Background(), fpset, samples, calibrate.Options{
+		PriorWeight: opts.PriorWeight,
+		MaxErrors:   opts.Errors,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to calibrate: %s\n", err)
+		os.Exit(1)
+	}
+
+	// keep stdout for the overrides when they are written there
+	out := io.Writer(os.Stdout)
+	if opts.Output == "-" {
+		out = os.Stderr
+	}
+	printReport(out, report)
+
+	if opts.Output == "" {
+		return
+	}
+	overrides := report.Overrides(opts.MinSamples)
+	w := io.Writer(os.Stdout)
+	if opts.Output != "-" {
+		f, err := os.Create(opts.Output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create %s: %s\n", opts.Output, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := overrides.Write(w); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write overrides: %s\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(out, "\nWrote %d certainty overrides\n", len(overrides.Overrides))
+}
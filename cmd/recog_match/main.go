@@ -2,16 +2,47 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
 
+	flags "github.com/jessevdk/go-flags"
+
 	recog "github.com/runZeroInc/recog-go"
+	"github.com/runZeroInc/recog-go/scaninput"
+	"github.com/runZeroInc/recog-go/telnet"
 )
 
+type Options struct {
+	Input   string `long:"input" short:"i" description:"Input mode: text (one banner per line), telnet (a raw Telnet capture read from stdin), or zgrab2, masscan or nmap scan output read from stdin" default:"text" choice:"text" choice:"telnet" choice:"zgrab2" choice:"masscan" choice:"nmap"`
+	Workers int    `long:"workers" short:"w" description:"Number of inputs to match concurrently, 0 for one per CPU"`
+	Explain string `long:"explain" short:"x" value-name:"MATCHKEY" description:"Explain which fingerprints for a match key came closest to matching each input"`
+	Closest int    `long:"closest" description:"Number of fingerprints to show when explaining" default:"5"`
+	Args    struct {
+		Root string   `positional-arg-name:"XMLDIR" description:"Directory of the fingerprint files"`
+		Text []string `positional-arg-name:"TEXT" description:"Text to match, otherwise lines are read from stdin"`
+	} `positional-args:"yes" required:"1"`
+}
+
+// parseArgs parses the command line, stopping at XMLDIR so that text arguments
+// beginning with a dash, such as a -ERR banner, are matched rather than rejected
+// as unknown options
+func parseArgs(args []string) (*Options, error) {
+	opts := &Options{}
+	parser := flags.NewParser(opts, flags.Default|flags.PassAfterNonOption)
+	parser.LongDescription = "Options must come before XMLDIR, as every argument after it is text to " +
+		"match, including text that begins with a dash such as -ERR. Use -- to end the options " +
+		"early when XMLDIR itself begins with a dash."
+	_, err := parser.ParseArgs(args)
+	return opts, err
+}
+
 func visit(files *[]string) filepath.WalkFunc {
 	return func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -27,22 +58,168 @@ func visit(files *[]string) filepath.WalkFunc {
 	}
 }
 
-func fingerprint(fingerprints []recog.FingerprintDB, text string) {
+// matcher matches input against each loaded database on a worker pool, printing
+// results in input order
+type matcher struct {
+	fingerprints []recog.FingerprintDB
+	files        []string
+	fset         *recog.FingerprintSet
+	items        chan recog.BatchItem
+	done         chan struct{}
+}
+
+// newMatcher registers each database under its file name, which is unique even when
+// databases in different directories share a name
+func newMatcher(fingerprints []recog.FingerprintDB, files []string, workers int, output func(*recog.BatchResult)) *matcher {
+	m := &matcher{
+		fingerprints: fingerprints,
+		files:        files,
+		fset:         recog.NewFingerprintSet(),
+		items:        make(chan recog.BatchItem),
+		done:         make(chan struct{}),
+	}
+	for i := range fingerprints {
+		m.fset.DatabasesByMatchKey[files[i]] = []*recog.FingerprintDB{&fingerprints[i]}
+	}
+
+	batch := m.fset.MatchBatch(context.Background(), m.items, recog.BatchOptions{Workers: workers, First: true})
+	go func() {
+		defer close(m.done)
+		for result := range batch.Results() {
+			// suppressed inputs are recognized but not attributed to anything
+			if len(result.Matches) > 0 && !recog.IsSuppressed(result.Matches) {
+				output(result)
+			}
+		}
+	}()
+	return m
+}
+
+// add queues the input for every database accepted by the filter
+func (m *matcher) add(id interface{}, text string, filter func(*recog.FingerprintDB) bool) {
+	for i := range m.fingerprints {
+		if filter == nil || filter(&m.fingerprints[i]) {
+			m.items <- recog.BatchItem{ID: id, MatchKey: m.files[i], Input: text}
+		}
+	}
+}
+
+// wait finishes matching the queued input
+func (m *matcher) wait() {
+	close(m.items)
+	<-m.done
+}
+
+func printValues(result *recog.BatchResult) {
+	j, _ := json.Marshal(result.Matches[0].Values)
+	fmt.Printf("%s\n", j)
+}
+
+// fingerprintTelnet strips option negotiation from a raw Telnet capture and matches
+// the remaining banner against the Telnet database, adding the decoded options to
+// the output.
+func fingerprintTelnet(fingerprints []recog.FingerprintDB, data []byte) {
+	b := telnet.Normalize(data)
+	if b.Text == "" {
+		return
+	}
+
+	negotiations := make([]string, 0, len(b.Negotiations))
+	for _, n := range b.Negotiations {
+		negotiations = append(negotiations, n.String())
+	}
+
 	for _, fdb := range fingerprints {
-		if match := fdb.MatchFirst(text); match != nil {
-			j, _ := json.Marshal(match.Values)
+		if fdb.Name != telnet.MatchKey {
+			continue
+		}
+		if match := fdb.MatchFirst(b.Text); match != nil && !match.Suppressed {
+			values := make(map[string]string)
+			for k, v := range b.Values {
+				values[k] = v
+			}
+			if len(negotiations) > 0 {
+				values["telnet.negotiations"] = strings.Join(negotiations, ",")
+			}
+			for k, v := range match.Values {
+				values[k] = v
+			}
+			j, _ := json.Marshal(values)
 			fmt.Printf("%s\n", j)
 		}
 	}
 }
 
+// scanMatch is the output for a scan record that matched a fingerprint
+type scanMatch struct {
+	*scaninput.Record
+	Values map[string]string `json:"values"`
+}
+
+// printScanMatch prints a match for a scan record, keeping the host and port
+func printScanMatch(result *recog.BatchResult) {
+	rec := result.Item.ID.(*scaninput.Record)
+	j, _ := json.Marshal(scanMatch{Record: rec, Values: result.Matches[0].Values})
+	fmt.Printf("%s\n", j)
+}
+
+// fingerprintScan matches every record of scanner output against the databases for
+// its match key
+func fingerprintScan(m *matcher, r scaninput.Reader) error {
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		m.add(rec, rec.Input, func(fdb *recog.FingerprintDB) bool {
+			return fdb.Matches == rec.MatchKey || fdb.Name == rec.MatchKey
+		})
+	}
+}
+
+// explain prints the fingerprints closest to matching the text, where each pattern
+// stopped matching, and any parameter errors
+func explain(fset *recog.FingerprintSet, key string, text string, closest int) {
+	res, err := fset.Explain(key, text, closest)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%q\n", text)
+	for _, e := range res {
+		description := ""
+		if e.Fingerprint.Description != nil {
+			description = e.Fingerprint.Description.Text
+		}
+		fmt.Printf("  [%.2f] %s: %s\n", e.Score, e.Fingerprint.DB.Name, description)
+		fmt.Printf("    pattern: %s\n", e.Fingerprint.Pattern)
+		if e.Matched {
+			fmt.Printf("    matched\n")
+		} else {
+			fmt.Printf("    matched %d of %d elements: %s\n", e.Consumed, e.Elements, e.Prefix)
+			fmt.Printf("    stopped at offset %d (%q) expecting: %s\n", e.StoppedAt, text[e.StoppedAt:], e.Remaining)
+		}
+		if len(e.Literals) > 0 || len(e.MissingLiterals) > 0 {
+			fmt.Printf("    literals found: %q missing: %q\n", e.Literals, e.MissingLiterals)
+		}
+		for _, err := range e.Errors {
+			fmt.Printf("    param error: %s\n", err)
+		}
+	}
+}
+
 func main() {
-	var files []string
-	if len(os.Args) < 2 {
-		log.Fatalf("missing: recog xml directory")
+	opts, err := parseArgs(os.Args[1:])
+	if err != nil {
+		os.Exit(1)
 	}
 
-	err := filepath.Walk(os.Args[1], visit(&files))
+	var files []string
+	err = filepath.Walk(opts.Args.Root, visit(&files))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -56,16 +233,62 @@ func main() {
 		fingerprints = append(fingerprints, fdb)
 	}
 
+	if opts.Input == "telnet" {
+		data, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			log.Fatalf("error reading telnet capture: %s", err)
+		}
+		fingerprintTelnet(fingerprints, data)
+		return
+	}
+
+	if opts.Input != "text" {
+		r, err := scaninput.NewReader(opts.Input, os.Stdin)
+		if err != nil {
+			log.Fatal(err)
+		}
+		m := newMatcher(fingerprints, files, opts.Workers, printScanMatch)
+		err = fingerprintScan(m, r)
+		m.wait()
+		if err != nil {
+			log.Fatalf("error reading %s output: %s", opts.Input, err)
+		}
+		return
+	}
+
+	if opts.Explain != "" {
+		fset := recog.NewFingerprintSet()
+		for i := range fingerprints {
+			if fingerprints[i].Matches == opts.Explain || fingerprints[i].Name == opts.Explain {
+				fset.DatabasesByMatchKey[opts.Explain] = append(fset.DatabasesByMatchKey[opts.Explain], &fingerprints[i])
+			}
+		}
+
+		text := strings.Join(opts.Args.Text, " ")
+		if len(text) > 0 {
+			explain(fset, opts.Explain, text, opts.Closest)
+			return
+		}
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			explain(fset, opts.Explain, scanner.Text(), opts.Closest)
+		}
+		return
+	}
+
+	m := newMatcher(fingerprints, files, opts.Workers, printValues)
+
 	var text string
 
-	text = strings.Join(os.Args[2:], " ")
+	text = strings.Join(opts.Args.Text, " ")
 	if len(text) < 1 {
 		scanner := bufio.NewScanner(os.Stdin)
 		for scanner.Scan() {
 			text = scanner.Text()
-			fingerprint(fingerprints, text)
+			m.add(nil, text, nil)
 		}
 	} else {
-		fingerprint(fingerprints, text)
+		m.add(nil, text, nil)
 	}
+	m.wait()
 }
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/runZeroInc/recog-go"
+)
+
+const testDB = `<?xml version='1.0' encoding='UTF-8'?>
+<fingerprints matches="test.banner" protocol="test" database_type="service" preference="0.90">
+  <fingerprint pattern="^Apache/0\.0$" suppress="true">
+    <description>Placeholder banner</description>
+    <param pos="0" name="service.product" value="HTTPD"/>
+  </fingerprint>
+  <fingerprint pattern="^Apache/(\S+)$">
+    <description>Apache</description>
+    <param pos="0" name="service.product" value="HTTPD"/>
+    <param pos="1" name="service.version"/>
+  </fingerprint>
+</fingerprints>
+`
+
+func TestMatcherSkipsSuppressed(t *testing.T) {
+	fdb, err := recog.LoadFingerprintDB("test_banner.xml", []byte(testDB))
+	if err != nil {
+		t.Fatalf("failed to load database: %s", err)
+	}
+
+	var printed []map[string]string
+	m := newMatcher([]recog.FingerprintDB{fdb}, []string{"test_banner.xml"}, 1, func(result *recog.BatchResult) {
+		printed = append(printed, result.Matches[0].Values)
+	})
+	m.add(0, "Apache/0.0", nil)
+	m.add(1, "Apache/2.4", nil)
+	m.wait()
+
+	if len(printed) != 1 || printed[0]["service.version"] != "2.4" {
+		t.Errorf("expected only the unsuppressed banner to be printed, got %v", printed)
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	opts, err := parseArgs([]string{"-w", "2", "xml", "-ERR", "Unknown", "command"})
+	if err != nil {
+		t.Fatalf("parseArgs() failed: %s", err)
+	}
+	if opts.Workers != 2 || opts.Args.Root != "xml" || strings.Join(opts.Args.Text, " ") != "-ERR Unknown command" {
+		t.Errorf("unexpected options %+v", opts)
+	}
+
+	opts, err = parseArgs([]string{"--", "-xml", "--version"})
+	if err != nil {
+		t.Fatalf("parseArgs() failed: %s", err)
+	}
+	if opts.Args.Root != "-xml" || strings.Join(opts.Args.Text, " ") != "--version" {
+		t.Errorf("unexpected options %+v", opts)
+	}
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/xlab/treeprint"
 
@@ -15,18 +18,113 @@ import (
 	flags "github.com/jessevdk/go-flags"
 
 	"github.com/runZeroInc/recog-go"
+	"github.com/runZeroInc/recog-go/scaninput"
 )
 
+// inputRecord is a single match key and value to traverse, along with a label
+// describing where it came from
+type inputRecord struct {
+	MatchKey string
+	Text     string
+	Label    string
+}
+
+// csvInput returns the records of a CSV or line separated stream
+func csvInput(r io.Reader, opts *Options) (func() (*inputRecord, error), error) {
+	scanner := csv.NewReader(r)
+
+	// parse headers
+	var headers []string
+	if opts.Headers {
+		var err error
+		headers, err = scanner.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read headers: %s", err)
+		}
+	}
+
+	// find the source and proof index from the header set
+	sourceIndex := -1
+	proofIndex := 0
+	for i, header := range headers {
+		if header == opts.HeaderMatchKey {
+			sourceIndex = i
+		}
+		if header == opts.HeaderValue {
+			proofIndex = i
+		}
+	}
+
+	return func() (*inputRecord, error) {
+		record, err := scanner.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		// find the match key from the source header if CSV
+		matches := opts.Matches
+		if sourceIndex != -1 {
+			matches = record[sourceIndex]
+		}
+		return &inputRecord{MatchKey: matches, Text: record[proofIndex], Label: record[proofIndex]}, nil
+	}, nil
+}
+
+// scanInput returns the records of zgrab2, masscan or nmap output
+func scanInput(r io.Reader, format string) (func() (*inputRecord, error), error) {
+	sr, err := scaninput.NewReader(format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() (*inputRecord, error) {
+		rec, err := sr.Next()
+		if err != nil {
+			return nil, err
+		}
+		label := fmt.Sprintf("%s:%d %s %s", rec.Host, rec.Port, rec.MatchKey, rec.Input)
+		return &inputRecord{MatchKey: rec.MatchKey, Text: rec.Input, Label: label}, nil
+	}, nil
+}
+
+// batchItems feeds the input records to a batch match, with each record as the
+// item ID
+func batchItems(next func() (*inputRecord, error)) <-chan recog.BatchItem {
+	items := make(chan recog.BatchItem)
+	go func() {
+		defer close(items)
+		for {
+			record, err := next()
+			if err != nil {
+				return
+			}
+			items <- recog.BatchItem{ID: record, MatchKey: record.MatchKey, Input: record.Text}
+		}
+	}()
+	return items
+}
+
 type Options struct {
-	Root                    string `long:"root" description:"Root directory of the fingerprint files" default:"xml"`
-	File                    string `long:"file" short:"f" description:"File containing the stream of input data, or - for stdin" default:"-"`
-	Headers                 bool   `long:"csv-headers" short:"c" description:"File contains CSV headers"`
-	HeaderMatchKey          string `long:"header-match-key" short:"k" description:"Header name to use as match key (only relevant if -c is used)" default:"key"`
-	HeaderValue             string `long:"header-value" short:"v" description:"Header name to use as match value (only relevant if -c is used)" default:"value"`
-	Matches                 string `long:"matches" short:"m" description:"Match key to use for all input data" default:"key"`
-	Performance             bool   `long:"performance" short:"p" description:"Enable performance profiling"`
-	PerformanceExtendedInfo bool   `long:"performance-extended" short:"e" description:"Enable software information in performance profiling"`
-	Nomatch                 bool   `long:"nomatch" short:"n" description:"Print only non-matching input"`
+	Root                    string        `long:"root" description:"Root directory of the fingerprint files" default:"xml"`
+	File                    string        `long:"file" short:"f" description:"File containing the stream of input data, or - for stdin" default:"-"`
+	Format                  string        `long:"input-format" short:"F" description:"Format of the input data: csv, or zgrab2, masscan or nmap scan output" default:"csv" choice:"csv" choice:"zgrab2" choice:"masscan" choice:"nmap"`
+	Headers                 bool          `long:"csv-headers" short:"c" description:"File contains CSV headers"`
+	HeaderMatchKey          string        `long:"header-match-key" short:"k" description:"Header name to use as match key (only relevant if -c is used)" default:"key"`
+	HeaderValue             string        `long:"header-value" short:"v" description:"Header name to use as match value (only relevant if -c is used)" default:"value"`
+	Matches                 string        `long:"matches" short:"m" description:"Match key to use for all input data" default:"key"`
+	OutputFormat            string        `long:"format" short:"o" description:"Output format: text, or a dot, graphml or json graph per input" default:"text" choice:"text" choice:"dot" choice:"graphml" choice:"json"`
+	Performance             bool          `long:"performance" short:"p" description:"Enable performance profiling"`
+	PerformanceExtendedInfo bool          `long:"performance-extended" short:"e" description:"Enable software information in performance profiling"`
+	Nomatch                 bool          `long:"nomatch" short:"n" description:"Print only non-matching input"`
+	MaxInputSize            int           `long:"max-input-size" description:"Maximum bytes of each input to match, 0 for unlimited"`
+	RejectOversize          bool          `long:"reject-oversize" description:"Skip input over --max-input-size instead of truncating it"`
+	MaxTime                 time.Duration `long:"max-time" description:"Maximum time to spend matching each input, such as 500ms, 0 for unlimited"`
+	Normalize               string        `long:"normalize" description:"Comma separated normalization steps applied to all input (trim-crlf, strip-nul, latin1, valid-utf8, strip-ansi, strip-control), or standard"`
+	Cache                   int           `long:"cache" description:"Number of match results to cache for repeated input, 0 to disable"`
+	MaxDepth                int           `long:"max-depth" description:"Maximum routed levels to follow below the initial matches, 0 for the default and -1 for unlimited"`
+	Dedup                   bool          `long:"dedup" description:"Match each routed input once, sharing the resulting nodes between parents"`
+	StableIds               bool          `long:"stable-ids" description:"Derive node ids from the input and fingerprint so repeated runs produce identical graphs"`
+	Workers                 int           `long:"workers" short:"w" description:"Number of inputs to match concurrently, 0 for one per CPU"`
 }
 
 // Renders / prints a hierarchical tree of the matches.
@@ -121,6 +219,9 @@ func printNodes(nodes []*recog.MatchNode) {
 		fmt.Printf("Matched: %s\n", description)
 		fmt.Printf("  Id: %s\n", node.Id)
 		fmt.Printf("  Type: %s\n", node.Match.Fingerprint.DB.DatabaseType)
+		if node.Match.Suppressed {
+			fmt.Printf("  Suppressed: known input, not attributed\n")
+		}
 		fmt.Printf("  Values:\n")
 
 		printValues(node.Match.Values)
@@ -135,6 +236,46 @@ func truncateText(text string, length int) string {
 	return text
 }
 
+// isLimitErr reports whether matching stopped at one of the configured limits
+func isLimitErr(err error) bool {
+	return err == recog.ErrInputTooLarge || err == recog.ErrMatchTimeout
+}
+
+// exportGraphs writes the graph of each input in a machine readable format. DOT
+// and JSON are streamed, while GraphML collects every graph into one document.
+func exportGraphs(fpset *recog.FingerprintSet, next func() (*inputRecord, error), batchOpts recog.BatchOptions, format string) error {
+	var graphs []recog.ExportGraph
+	batch := fpset.MatchBatch(context.Background(), batchItems(next), batchOpts)
+	for result := range batch.Results() {
+		record := result.Item.ID.(*inputRecord)
+		if isLimitErr(result.Err) {
+			fmt.Fprintf(os.Stderr, "SKIPPED: %s: %s\n", truncateText(record.Label, 70), result.Err)
+			continue
+		} else if result.Err != nil {
+			return result.Err
+		}
+
+		g := recog.ExportGraph{Name: record.Label, Nodes: result.Nodes, Edges: result.Edges}
+		var err error
+		switch format {
+		case "dot":
+			err = recog.WriteDOT(os.Stdout, g)
+		case "json":
+			err = recog.WriteJSON(os.Stdout, g)
+		default:
+			graphs = append(graphs, g)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if format == "graphml" {
+		return recog.WriteGraphML(os.Stdout, graphs...)
+	}
+	return nil
+}
+
 func MustParseFloat(s string) float64 {
 	v, err := strconv.ParseFloat(s, 64)
 	if err != nil {
@@ -157,11 +298,33 @@ func main() {
 		os.Exit(1)
 	}
 
+	// configure the input size and time limits
+	fpset.MaxInputSize = opts.MaxInputSize
+	if opts.RejectOversize {
+		fpset.OversizeInput = recog.RejectOversize
+	}
+	fpset.MaxMatchTime = opts.MaxTime
+	if opts.Cache > 0 {
+		fpset.EnableCache(opts.Cache)
+	}
+
+	// configure the normalization applied before matching
+	if opts.Normalize == "standard" {
+		fpset.DefaultNormalizers = recog.StandardNormalizers
+	} else if opts.Normalize != "" {
+		for _, name := range strings.Split(opts.Normalize, ",") {
+			n, err := recog.LookupNormalizer(strings.TrimSpace(name))
+			if err != nil {
+				fmt.Printf("Invalid normalization step: %s", err)
+				os.Exit(1)
+			}
+			fpset.DefaultNormalizers = append(fpset.DefaultNormalizers, n)
+		}
+	}
+
 	// read input from args or stdin
-	var scanner *csv.Reader
-	if opts.File == "-" {
-		scanner = csv.NewReader(os.Stdin)
-	} else {
+	var input io.Reader = os.Stdin
+	if opts.File != "-" {
 		// opts.File is a line separated list of strings
 		file, err := os.Open(opts.File)
 		if err != nil {
@@ -169,29 +332,24 @@ func main() {
 			os.Exit(1)
 		}
 		defer file.Close()
-		scanner = csv.NewReader(file)
+		input = file
 	}
 
-	// parse headers
-	var headers []string
-	if opts.Headers {
-		headers, err = scanner.Read()
-		if err != nil {
-			fmt.Printf("Failed to read headers: %s", err)
-			os.Exit(1)
-		}
+	var next func() (*inputRecord, error)
+	if opts.Format == "csv" {
+		next, err = csvInput(input, &opts)
+	} else {
+		next, err = scanInput(input, opts.Format)
+	}
+	if err != nil {
+		fmt.Printf("Failed to read input: %s", err)
+		os.Exit(1)
 	}
 
-	// find the source and proof index from the header set
-	sourceIndex := -1
-	proofIndex := 0
-	for i, header := range headers {
-		if header == opts.HeaderMatchKey {
-			sourceIndex = i
-		}
-		if header == opts.HeaderValue {
-			proofIndex = i
-		}
+	batchOpts := recog.BatchOptions{
+		Workers:         opts.Workers,
+		Traverse:        true,
+		TraverseOptions: recog.TraverseOptions{MaxDepth: opts.MaxDepth, Dedup: opts.Dedup, StableIds: opts.StableIds},
 	}
 
 	if opts.Performance {
@@ -200,24 +358,18 @@ func main() {
 		// start performance profiling
 		total := 0
 		matched := 0
+		limited := 0
 		package_counts := make(map[string]int)
 		software_counts := make(map[string]int)
 
-		// iterate over the csv records
-		for {
-			record, err := scanner.Read()
-			if err != nil {
-				break
-			}
-
-			// find the match key from the source header if CSV
-			matches := opts.Matches
-			if sourceIndex != -1 {
-				matches = record[sourceIndex]
-			}
-
-			nodes, _, err := recog.TraverseMatch(fpset, matches, record[proofIndex])
-			if err != nil {
+		// iterate over the input records, in whatever order they complete
+		batchOpts.Unordered = true
+		batch := fpset.MatchBatch(context.Background(), batchItems(next), batchOpts)
+		for result := range batch.Results() {
+			nodes, err := result.Nodes, result.Err
+			if isLimitErr(err) {
+				limited++
+			} else if err != nil {
 				fmt.Printf("Matching failed: %s", err)
 				os.Exit(1)
 			}
@@ -241,7 +393,13 @@ func main() {
 			}
 		}
 
-		fmt.Printf("Performance profiling results (NOTE: showing all regardless of certainty) total=%d matched=%d\n", total, matched)
+		stats := batch.Stats()
+		fmt.Printf("Performance profiling results (NOTE: showing all regardless of certainty) total=%d matched=%d limited=%d\n", total, matched, limited)
+		fmt.Printf("Throughput: %.1f inputs/s over %s\n", stats.ItemsPerSecond(), stats.Elapsed.Round(time.Millisecond))
+		if fpset.Cache != nil {
+			cs := fpset.Cache.Stats()
+			fmt.Printf("Cache: hits=%d misses=%d evictions=%d hit rate=%.1f%%\n", cs.Hits, cs.Misses, cs.Evictions, cs.HitRate()*100)
+		}
 		if opts.PerformanceExtendedInfo {
 			// make a list of keys that represent the package counts ordered descending
 			package_keys := make([]string, 0, len(package_counts))
@@ -277,21 +435,24 @@ func main() {
 		os.Exit(0)
 	}
 
-	// match the input text against the fingerprints (recursive)
-	for {
-		record, err := scanner.Read()
-		if err != nil {
-			break
-		}
-
-		// find the match key from the source header
-		matches := opts.Matches
-		if sourceIndex != -1 {
-			matches = record[sourceIndex]
+	if opts.OutputFormat != "text" {
+		if err := exportGraphs(fpset, next, batchOpts, opts.OutputFormat); err != nil {
+			fmt.Fprintf(os.Stderr, "Export failed: %s\n", err)
+			os.Exit(1)
 		}
+		os.Exit(0)
+	}
 
-		nodes, edges, err := recog.TraverseMatch(fpset, matches, record[proofIndex])
-		if err != nil {
+	// match the input text against the fingerprints (recursive), printing the
+	// results in input order
+	batch := fpset.MatchBatch(context.Background(), batchItems(next), batchOpts)
+	for result := range batch.Results() {
+		record := result.Item.ID.(*inputRecord)
+		nodes, edges, err := result.Nodes, result.Edges, result.Err
+		if isLimitErr(err) {
+			fmt.Printf("SKIPPED: %s: %s\n", truncateText(record.Label, 70), err)
+			continue
+		} else if err != nil {
 			fmt.Printf("Matching failed: %s", err)
 			os.Exit(1)
 		}
@@ -299,7 +460,7 @@ func main() {
 		// if no matches, print the input text and continue
 		if opts.Nomatch {
 			if len(nodes) == 0 {
-				fmt.Printf("NO MATCH: %s\n", record[proofIndex])
+				fmt.Printf("NO MATCH: %s\n", record.Label)
 			}
 			continue
 		}
@@ -336,7 +497,20 @@ func main() {
 			printNodes(rejects)
 		}
 
+		// print the values of every node merged into one set of facts
+		fmt.Println("****** Facts ******")
+		facts := recog.ResolveFacts(nodes, edges)
+		for _, key := range facts.Keys() {
+			f := facts[key]
+			fmt.Printf("    %s: %s (%s)\n", key, f.Value, f.Node.Match.Fingerprint.DB.Name)
+		}
+		fmt.Println()
+
 		fmt.Println("****** Tree ******")
-		printTree(fmt.Sprintf("Input: %s", truncateText(record[proofIndex], 70)), nodes, edges)
+		printTree(fmt.Sprintf("Input: %s", truncateText(record.Label, 70)), nodes, edges)
+
+		for _, t := range result.Truncated {
+			fmt.Printf("TRUNCATED (%s): %s %s\n", t.Reason, t.MatchKey, truncateText(t.Input, 70))
+		}
 	}
 }
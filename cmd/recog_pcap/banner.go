@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/runZeroInc/recog-go/banners"
+	"github.com/runZeroInc/recog-go/telnet"
+)
+
+// banner is a single value extracted from a flow along with the match key it feeds
+type banner struct {
+	MatchKey string `json:"match_key"`
+	Input    string `json:"input"`
+}
+
+// protocolPorts maps well-known server ports to the protocol expected on them
+var protocolPorts = map[uint16]string{
+	21:   "ftp",
+	22:   "ssh",
+	23:   "telnet",
+	25:   "smtp",
+	80:   "http",
+	110:  "pop3",
+	119:  "nntp",
+	143:  "imap",
+	554:  "rtsp",
+	587:  "smtp",
+	2323: "telnet",
+	5060: "sip",
+	8000: "http",
+	8080: "http",
+	8554: "rtsp",
+}
+
+func isServicePort(port uint16) bool {
+	_, ok := protocolPorts[port]
+	return ok
+}
+
+// identifyProtocol picks a protocol for a flow using the server's first payload and
+// the client's first payload, falling back to the server port when the content is
+// ambiguous.
+func identifyProtocol(port uint16, server []byte, client []byte) string {
+	byPort := protocolPorts[port]
+
+	switch {
+	case bytes.HasPrefix(server, []byte("SSH-")):
+		return "ssh"
+	case bytes.HasPrefix(server, []byte("HTTP/1.")):
+		return "http"
+	case bytes.HasPrefix(server, []byte("RTSP/1.")), bytes.HasPrefix(client, []byte("OPTIONS rtsp:")), bytes.HasPrefix(client, []byte("DESCRIBE rtsp:")):
+		return "rtsp"
+	case bytes.HasPrefix(server, []byte("SIP/2.0")), isSIPRequest(client), isSIPRequest(server):
+		return "sip"
+	case bytes.HasPrefix(server, []byte("+OK")), bytes.HasPrefix(server, []byte("-ERR")):
+		return "pop3"
+	case bytes.HasPrefix(server, []byte("* OK")), bytes.HasPrefix(server, []byte("* PREAUTH")), bytes.HasPrefix(server, []byte("* BYE")):
+		return "imap"
+	case len(server) > 0 && server[0] == telnet.IAC:
+		return "telnet"
+	}
+
+	// Numeric reply codes are shared by FTP, SMTP and NNTP
+	if code := banners.ReplyCode(string(server)); code != "" {
+		line := strings.ToUpper(banners.FirstLine(string(server)))
+		switch {
+		case byPort == "ftp" || byPort == "smtp" || byPort == "nntp":
+			return byPort
+		case strings.Contains(line, "SMTP") || strings.Contains(line, "MAIL"):
+			return "smtp"
+		case strings.Contains(line, "FTP"):
+			return "ftp"
+		case strings.Contains(line, "NNTP") || strings.Contains(line, "NEWS") || code == "200" || code == "201":
+			return "nntp"
+		case code == "220":
+			return "ftp"
+		}
+	}
+
+	if byPort == "http" && len(server) > 0 && !bytes.HasPrefix(server, []byte("HTTP/")) {
+		return ""
+	}
+	return byPort
+}
+
+var sipMethods = []string{"INVITE ", "REGISTER ", "OPTIONS ", "SUBSCRIBE ", "NOTIFY ", "BYE ", "ACK ", "CANCEL ", "MESSAGE ", "INFO "}
+
+func isSIPRequest(data []byte) bool {
+	line := banners.FirstLine(string(data))
+	if !strings.HasSuffix(line, " SIP/2.0") {
+		return false
+	}
+	for _, m := range sipMethods {
+		if strings.HasPrefix(line, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractBanners converts the reassembled payloads of a flow into the inputs expected
+// by the corresponding recog databases.
+func extractBanners(proto string, server []byte, client []byte) []banner {
+	switch proto {
+	case "ssh":
+		// ssh_banners.xml matches the software version and comment after "SSH-x.x-"
+		if software := banners.SSHSoftware(string(server)); software != "" {
+			return []banner{{MatchKey: "ssh.banner", Input: software}}
+		}
+	case "ftp":
+		return replyBanner("ftp.banner", server)
+	case "smtp":
+		return replyBanner("smtp.banner", server)
+	case "nntp":
+		return replyBanner("nntp.banner", server)
+	case "pop3":
+		line := banners.FirstLine(string(server))
+		for _, prefix := range []string{"+OK", "-ERR"} {
+			if strings.HasPrefix(line, prefix) {
+				if text := strings.TrimSpace(line[len(prefix):]); text != "" {
+					return []banner{{MatchKey: "pop3.banner", Input: text}}
+				}
+			}
+		}
+	case "imap":
+		line := banners.FirstLine(string(server))
+		if strings.HasPrefix(line, "* OK ") {
+			return []banner{{MatchKey: "imap4.banner", Input: strings.TrimSpace(line[5:])}}
+		}
+	case "telnet":
+		// Option negotiation is removed before matching against telnet_banners.xml
+		if b := telnet.Normalize(server); b.Text != "" {
+			return []banner{{MatchKey: telnet.MatchKey, Input: b.Text}}
+		}
+	case "http":
+		return httpBanners(server)
+	case "rtsp":
+		if v := headerValue(banners.Headers(string(server)), "server"); v != "" {
+			return []banner{{MatchKey: "rtsp_header.server", Input: v}}
+		}
+	case "sip":
+		return sipBanners(server, client)
+	}
+	return nil
+}
+
+// replyBanner returns the text after the reply code on the first line of a greeting
+func replyBanner(matchKey string, data []byte) []banner {
+	if banners.ReplyCode(string(data)) == "" {
+		return nil
+	}
+	if text := banners.ReplyText(string(data)); text != "" {
+		return []banner{{MatchKey: matchKey, Input: text}}
+	}
+	return nil
+}
+
+func httpBanners(data []byte) []banner {
+	var res []banner
+	headers := banners.Headers(string(data))
+
+	if v := headerValue(headers, "server"); v != "" {
+		res = append(res, banner{MatchKey: "http_header.server", Input: v})
+	}
+	for _, v := range headers["set-cookie"] {
+		res = append(res, banner{MatchKey: "http_header.cookie", Input: v})
+	}
+	for _, v := range headers["www-authenticate"] {
+		res = append(res, banner{MatchKey: "http_header.wwwauth", Input: v})
+	}
+	_, body := banners.SplitHeaders(string(data))
+	if title := banners.HTMLTitle(body); title != "" {
+		res = append(res, banner{MatchKey: "html_title", Input: title})
+	}
+	return res
+}
+
+func sipBanners(server []byte, client []byte) []banner {
+	var res []banner
+	for _, msg := range [][]byte{server, client} {
+		headers := banners.Headers(string(msg))
+		if v := headerValue(headers, "server"); v != "" {
+			res = append(res, banner{MatchKey: "sip_header.server", Input: v})
+		}
+		if v := headerValue(headers, "user-agent"); v != "" {
+			res = append(res, banner{MatchKey: "sip_header.user_agent", Input: v})
+		}
+	}
+	return res
+}
+
+// headerValue returns the first value of a header parsed by banners.Headers
+func headerValue(headers map[string][]string, name string) string {
+	if vals := headers[name]; len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
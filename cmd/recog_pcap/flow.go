@@ -0,0 +1,497 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+)
+
+// TCP flag bits
+const (
+	tcpFin = 0x01
+	tcpSyn = 0x02
+	tcpRst = 0x04
+	tcpAck = 0x10
+)
+
+// segment is a decoded TCP or UDP payload
+type segment struct {
+	ts        time.Time
+	transport string
+	srcIP     net.IP
+	dstIP     net.IP
+	srcPort   uint16
+	dstPort   uint16
+	seq       uint32
+	flags     uint8
+	payload   []byte
+}
+
+// decodePacket extracts the transport segment from a captured frame, returning nil
+// for anything other than unfragmented TCP or UDP over IPv4 or IPv6.
+func decodePacket(pkt *packet) *segment {
+	data := pkt.data
+	etherType := uint16(0)
+
+	switch pkt.linkType {
+	case linkTypeEthernet:
+		if len(data) < 14 {
+			return nil
+		}
+		etherType = binary.BigEndian.Uint16(data[12:14])
+		data = data[14:]
+		// Skip any 802.1Q or 802.1ad tags
+		for (etherType == 0x8100 || etherType == 0x88a8) && len(data) >= 4 {
+			etherType = binary.BigEndian.Uint16(data[2:4])
+			data = data[4:]
+		}
+	case linkTypeNull, linkTypeLoop:
+		if len(data) < 4 {
+			return nil
+		}
+		data = data[4:]
+	case linkTypeRaw, linkTypeRawAlt, linkTypeIPv4, linkTypeIPv6:
+	case linkTypeLinuxSLL:
+		if len(data) < 16 {
+			return nil
+		}
+		etherType = binary.BigEndian.Uint16(data[14:16])
+		data = data[16:]
+	case linkTypeSLL2:
+		if len(data) < 20 {
+			return nil
+		}
+		etherType = binary.BigEndian.Uint16(data[0:2])
+		data = data[20:]
+	default:
+		return nil
+	}
+
+	if etherType != 0 && etherType != 0x0800 && etherType != 0x86dd {
+		return nil
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	var seg *segment
+	switch data[0] >> 4 {
+	case 4:
+		seg = decodeIPv4(data)
+	case 6:
+		seg = decodeIPv6(data)
+	}
+	if seg != nil {
+		seg.ts = pkt.ts
+	}
+	return seg
+}
+
+func decodeIPv4(data []byte) *segment {
+	if len(data) < 20 {
+		return nil
+	}
+	ihl := int(data[0]&0x0F) * 4
+	total := int(binary.BigEndian.Uint16(data[2:4]))
+	if ihl < 20 || len(data) < ihl {
+		return nil
+	}
+
+	// Fragments are not reassembled
+	frag := binary.BigEndian.Uint16(data[6:8])
+	if frag&0x3FFF != 0 {
+		return nil
+	}
+
+	// Trim Ethernet padding, but tolerate captures with TSO-inflated lengths of zero
+	if total >= ihl && total < len(data) {
+		data = data[:total]
+	}
+
+	return decodeTransport(data[9], net.IP(data[12:16]), net.IP(data[16:20]), data[ihl:])
+}
+
+func decodeIPv6(data []byte) *segment {
+	if len(data) < 40 {
+		return nil
+	}
+	plen := int(binary.BigEndian.Uint16(data[4:6]))
+	next := data[6]
+	src := net.IP(data[8:24])
+	dst := net.IP(data[24:40])
+	data = data[40:]
+	if plen > 0 && plen < len(data) {
+		data = data[:plen]
+	}
+
+	// Walk the common extension headers to find the transport
+	for {
+		switch next {
+		case 0, 43, 60:
+			if len(data) < 8 {
+				return nil
+			}
+			hlen := (int(data[1]) + 1) * 8
+			if len(data) < hlen {
+				return nil
+			}
+			next = data[0]
+			data = data[hlen:]
+		case 44:
+			// Fragments are not reassembled
+			return nil
+		default:
+			return decodeTransport(next, src, dst, data)
+		}
+	}
+}
+
+func decodeTransport(proto uint8, src net.IP, dst net.IP, data []byte) *segment {
+	switch proto {
+	case 6:
+		if len(data) < 20 {
+			return nil
+		}
+		off := int(data[12]>>4) * 4
+		if off < 20 || len(data) < off {
+			return nil
+		}
+		return &segment{
+			transport: "tcp",
+			srcIP:     src,
+			dstIP:     dst,
+			srcPort:   binary.BigEndian.Uint16(data[0:2]),
+			dstPort:   binary.BigEndian.Uint16(data[2:4]),
+			seq:       binary.BigEndian.Uint32(data[4:8]),
+			flags:     data[13],
+			payload:   data[off:],
+		}
+	case 17:
+		if len(data) < 8 {
+			return nil
+		}
+		return &segment{
+			transport: "udp",
+			srcIP:     src,
+			dstIP:     dst,
+			srcPort:   binary.BigEndian.Uint16(data[0:2]),
+			dstPort:   binary.BigEndian.Uint16(data[2:4]),
+			payload:   data[8:],
+		}
+	}
+	return nil
+}
+
+// endpoint is an address and port pair in string form
+type endpoint string
+
+func newEndpoint(ip net.IP, port uint16) endpoint {
+	return endpoint(net.JoinHostPort(ip.String(), fmt.Sprintf("%d", port)))
+}
+
+// flowKey identifies a flow regardless of direction
+type flowKey struct {
+	transport string
+	a         endpoint
+	b         endpoint
+}
+
+func newFlowKey(seg *segment) (flowKey, endpoint, endpoint) {
+	src := newEndpoint(seg.srcIP, seg.srcPort)
+	dst := newEndpoint(seg.dstIP, seg.dstPort)
+	if src < dst {
+		return flowKey{transport: seg.transport, a: src, b: dst}, src, dst
+	}
+	return flowKey{transport: seg.transport, a: dst, b: src}, src, dst
+}
+
+// stream holds the payload sent in one direction of a flow
+type stream struct {
+	port     uint16
+	isn      uint32
+	haveISN  bool
+	segments []*streamSegment
+	size     int
+	fin      bool
+}
+
+type streamSegment struct {
+	seq  uint32
+	data []byte
+}
+
+// add records a segment, stopping once the stream holds maxBytes of data
+func (s *stream) add(seg *segment, maxBytes int) {
+	if seg.flags&tcpSyn != 0 {
+		s.isn = seg.seq + 1
+		s.haveISN = true
+	}
+	if len(seg.payload) == 0 || s.size >= maxBytes {
+		return
+	}
+	data := make([]byte, len(seg.payload))
+	copy(data, seg.payload)
+	s.segments = append(s.segments, &streamSegment{seq: seg.seq, data: data})
+	s.size += len(data)
+}
+
+// addDatagram records a UDP payload, keeping datagrams in arrival order
+func (s *stream) addDatagram(seg *segment, maxBytes int) {
+	if len(seg.payload) == 0 || s.size >= maxBytes {
+		return
+	}
+	data := make([]byte, len(seg.payload))
+	copy(data, seg.payload)
+	s.segments = append(s.segments, &streamSegment{seq: uint32(len(s.segments)), data: data})
+	s.size += len(data)
+}
+
+// reassemble returns the contiguous payload starting at the initial sequence
+// number, discarding retransmitted data and stopping at the first gap.
+func (s *stream) reassemble(maxBytes int) []byte {
+	if len(s.segments) == 0 {
+		return nil
+	}
+
+	// Without a SYN, assume the earliest segment seen starts the stream
+	base := s.isn
+	if !s.haveISN {
+		base = s.segments[0].seq
+		for _, seg := range s.segments[1:] {
+			if int32(seg.seq-base) < 0 {
+				base = seg.seq
+			}
+		}
+	}
+
+	segs := make([]*streamSegment, len(s.segments))
+	copy(segs, s.segments)
+	sort.SliceStable(segs, func(i, j int) bool {
+		return int32(segs[i].seq-base) < int32(segs[j].seq-base)
+	})
+
+	var out []byte
+	next := base
+	for _, seg := range segs {
+		offset := int32(seg.seq - next)
+		if offset > 0 {
+			break
+		}
+		// Skip the portion of the segment that was already received
+		skip := int(-offset)
+		if skip >= len(seg.data) {
+			continue
+		}
+		out = append(out, seg.data[skip:]...)
+		next += uint32(len(seg.data) - skip)
+		if len(out) >= maxBytes {
+			return out[:maxBytes]
+		}
+	}
+	return out
+}
+
+// datagrams returns the recorded UDP payloads
+func (s *stream) datagrams() [][]byte {
+	var out [][]byte
+	for _, seg := range s.segments {
+		out = append(out, seg.data)
+	}
+	return out
+}
+
+// flow tracks both directions of a TCP connection or UDP exchange
+type flow struct {
+	key       flowKey
+	transport string
+	start     time.Time
+	last      time.Time
+	client    endpoint
+	server    endpoint
+	streams   map[endpoint]*stream
+	sawSyn    bool
+	reset     bool
+	done      bool
+	order     int
+}
+
+// closed reports whether either side has sent a FIN or RST
+func (fl *flow) closed() bool {
+	return fl.reset || fl.streams[fl.client].fin || fl.streams[fl.server].fin
+}
+
+// payloads returns the server and client data used to identify and fingerprint the
+// flow: the reassembled streams for TCP and the first datagram in each direction
+// for UDP.
+func (fl *flow) payloads(maxBytes int) ([]byte, []byte) {
+	serverStream := fl.streams[fl.server]
+	clientStream := fl.streams[fl.client]
+	if fl.transport == "tcp" {
+		return serverStream.reassemble(maxBytes), clientStream.reassemble(maxBytes)
+	}
+
+	var server, client []byte
+	if d := serverStream.datagrams(); len(d) > 0 {
+		server = d[0]
+	}
+	if d := clientStream.datagrams(); len(d) > 0 {
+		client = d[0]
+	}
+	return server, client
+}
+
+// complete reports whether the server has sent everything needed to fingerprint the
+// flow. Greetings on line-based protocols are complete at the end of the first line,
+// while HTTP, RTSP and telnet banners can span many segments and are only complete
+// once the stream fills up or the connection closes.
+func (fl *flow) complete(maxBytes int) bool {
+	st := fl.streams[fl.server]
+	if fl.transport != "tcp" {
+		return len(st.segments) > 0
+	}
+	if st.size >= maxBytes {
+		return true
+	}
+
+	data := st.reassemble(maxBytes)
+	if bytes.IndexByte(data, '\n') < 0 {
+		return false
+	}
+	switch proto := identifyProtocol(st.port, data, nil); proto {
+	case "ssh", "ftp", "smtp", "nntp", "pop3", "imap":
+		return len(extractBanners(proto, data, nil)) > 0
+	}
+	return false
+}
+
+// flowTable groups segments into flows, handing each flow to done once it has
+// closed, produced its banner, or gone idle, and then releasing its payload. A
+// finished flow stays in the table without its payload so that trailing segments
+// are ignored until the flow expires or a new connection reuses its ports.
+type flowTable struct {
+	flows    map[flowKey]*flow
+	maxBytes int
+	idle     time.Duration
+	done     func(*flow)
+	swept    time.Time
+	count    int
+}
+
+func newFlowTable(maxBytes int, idle time.Duration, done func(*flow)) *flowTable {
+	return &flowTable{flows: make(map[flowKey]*flow), maxBytes: maxBytes, idle: idle, done: done}
+}
+
+func (ft *flowTable) add(seg *segment) {
+	ft.expire(seg.ts)
+
+	key, src, dst := newFlowKey(seg)
+	fl, ok := ft.flows[key]
+
+	// A bare SYN after the previous connection closed or was fingerprinted means the
+	// client reused its source port for a new connection
+	syn := seg.transport == "tcp" && seg.flags&(tcpSyn|tcpAck) == tcpSyn
+	if ok && syn && (fl.done || fl.closed()) {
+		ft.finish(fl)
+		ok = false
+	}
+	if ok && fl.done {
+		fl.last = seg.ts
+		return
+	}
+
+	if !ok {
+		fl = &flow{
+			key:       key,
+			transport: seg.transport,
+			start:     seg.ts,
+			client:    src,
+			server:    dst,
+			streams:   make(map[endpoint]*stream),
+			order:     ft.count,
+		}
+		fl.streams[src] = &stream{port: seg.srcPort}
+		fl.streams[dst] = &stream{port: seg.dstPort}
+		ft.flows[key] = fl
+		ft.count++
+	}
+	fl.last = seg.ts
+
+	// A bare SYN identifies the client; otherwise the first sender is assumed to be
+	// the client unless it is using the well-known port.
+	if syn {
+		fl.client, fl.server = src, dst
+		fl.sawSyn = true
+	} else if !fl.sawSyn && !ok && isServicePort(seg.srcPort) && !isServicePort(seg.dstPort) {
+		fl.client, fl.server = dst, src
+	}
+
+	st := fl.streams[src]
+	if seg.transport == "tcp" {
+		st.add(seg, ft.maxBytes)
+		st.fin = st.fin || seg.flags&tcpFin != 0
+		fl.reset = fl.reset || seg.flags&tcpRst != 0
+	} else {
+		st.addDatagram(seg, ft.maxBytes)
+	}
+
+	switch {
+	case fl.reset || (fl.streams[fl.client].fin && fl.streams[fl.server].fin):
+		ft.finish(fl)
+	case src == fl.server && len(seg.payload) > 0 && fl.complete(ft.maxBytes):
+		ft.finish(fl)
+	}
+}
+
+// finish hands a flow to the done callback once and releases its payload
+func (ft *flowTable) finish(fl *flow) {
+	if fl.done {
+		return
+	}
+	ft.done(fl)
+	fl.done = true
+	fl.streams = nil
+}
+
+// expire finishes and removes the flows that have been idle for longer than the
+// idle timeout, sweeping the table at most once per timeout.
+func (ft *flowTable) expire(now time.Time) {
+	if ft.idle <= 0 || now.Sub(ft.swept) < ft.idle {
+		return
+	}
+	ft.swept = now
+
+	var idle []*flow
+	for _, fl := range ft.flows {
+		if now.Sub(fl.last) >= ft.idle {
+			idle = append(idle, fl)
+		}
+	}
+	sortFlows(idle)
+	for _, fl := range idle {
+		ft.finish(fl)
+		delete(ft.flows, fl.key)
+	}
+}
+
+// flush finishes every remaining flow in the order they were first seen
+func (ft *flowTable) flush() {
+	flows := make([]*flow, 0, len(ft.flows))
+	for _, fl := range ft.flows {
+		flows = append(flows, fl)
+	}
+	sortFlows(flows)
+	for _, fl := range flows {
+		ft.finish(fl)
+	}
+	ft.flows = make(map[flowKey]*flow)
+}
+
+// sortFlows orders flows by when they were first seen
+func sortFlows(flows []*flow) {
+	sort.Slice(flows, func(i, j int) bool {
+		return flows[i].order < flows[j].order
+	})
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/runZeroInc/recog-go"
+)
+
+type Options struct {
+	Root     string        `long:"root" description:"Directory of the fingerprint files (defaults to the embedded databases)"`
+	MaxBytes int           `long:"max-bytes" short:"b" description:"Maximum payload bytes to reassemble per flow direction" default:"65536"`
+	All      bool          `long:"all" short:"a" description:"Print flows that did not produce any matches"`
+	Idle     time.Duration `long:"idle" description:"Time without packets after which a flow is fingerprinted and dropped" default:"2m"`
+	Args     struct {
+		File string `positional-arg-name:"FILE" description:"pcap or pcapng file to read, or - for stdin"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// bannerMatch is a banner along with the values of each fingerprint that matched it
+type bannerMatch struct {
+	banner
+	Matches []map[string]string `json:"matches,omitempty"`
+}
+
+// flowResult is the JSON output for a single flow
+type flowResult struct {
+	Time      time.Time     `json:"time"`
+	Transport string        `json:"transport"`
+	Client    string        `json:"client"`
+	Server    string        `json:"server"`
+	Protocol  string        `json:"protocol"`
+	Banners   []bannerMatch `json:"banners"`
+}
+
+// readFlows reassembles the TCP and UDP flows in a capture, passing each one to done
+// as soon as it is finished. Flows still open at the end of the capture, including
+// a capture that ends with an error, are passed on in the order they were first seen.
+func readFlows(pr packetReader, maxBytes int, idle time.Duration, done func(*flow)) error {
+	ft := newFlowTable(maxBytes, idle, done)
+	defer ft.flush()
+	for {
+		pkt, err := pr.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if seg := decodePacket(pkt); seg != nil {
+			ft.add(seg)
+		}
+	}
+}
+
+// fingerprintFlow identifies the protocol of a flow, extracts its banners, and matches them
+func fingerprintFlow(fpset *recog.FingerprintSet, fl *flow, maxBytes int) *flowResult {
+	server, client := fl.payloads(maxBytes)
+	res := &flowResult{
+		Time:      fl.start,
+		Transport: fl.transport,
+		Client:    string(fl.client),
+		Server:    string(fl.server),
+		Protocol:  identifyProtocol(fl.streams[fl.server].port, server, client),
+	}
+
+	for _, b := range extractBanners(res.Protocol, server, client) {
+		bm := bannerMatch{banner: b}
+		matches, err := fpset.MatchAll(b.MatchKey, b.Input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Matching failed: %s\n", err)
+		}
+		for _, m := range matches {
+			bm.Matches = append(bm.Matches, m.Values)
+		}
+		res.Banners = append(res.Banners, bm)
+	}
+	return res
+}
+
+func hasMatches(res *flowResult) bool {
+	for _, b := range res.Banners {
+		if len(b.Matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func main() {
+	var opts Options
+	_, err := flags.ParseArgs(&opts, os.Args[1:])
+	if err != nil {
+		os.Exit(1)
+	}
+
+	// load fingerprint databases
+	var fpset *recog.FingerprintSet
+	if opts.Root != "" {
+		fpset, err = recog.LoadFingerprintsDir(opts.Root)
+	} else {
+		fpset, err = recog.LoadFingerprints()
+	}
+	if err != nil {
+		fmt.Printf("Failed to load fingerprints: %s", err)
+		os.Exit(1)
+	}
+
+	var input io.Reader = os.Stdin
+	if opts.Args.File != "-" {
+		file, err := os.Open(opts.Args.File)
+		if err != nil {
+			fmt.Printf("Failed to open file: %s", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	write := func(fl *flow) {
+		res := fingerprintFlow(fpset, fl, opts.MaxBytes)
+		if !opts.All && !hasMatches(res) {
+			return
+		}
+		if err := enc.Encode(res); err != nil {
+			fmt.Printf("Failed to write output: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	pr, err := newPacketReader(input)
+	if err != nil {
+		fmt.Printf("Failed to read capture: %s", err)
+		os.Exit(1)
+	}
+
+	// a truncated capture still yields the flows read so far
+	if err := readFlows(pr, opts.MaxBytes, opts.Idle, write); err != nil {
+		fmt.Fprintf(os.Stderr, "Capture ended early: %s\n", err)
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/runZeroInc/recog-go"
+)
+
+// tcpFrame builds an Ethernet/IPv4/TCP frame
+func tcpFrame(src string, sport uint16, dst string, dport uint16, seq uint32, flags uint8, payload string) []byte {
+	tcp := make([]byte, 20)
+	binary.BigEndian.PutUint16(tcp[0:2], sport)
+	binary.BigEndian.PutUint16(tcp[2:4], dport)
+	binary.BigEndian.PutUint32(tcp[4:8], seq)
+	tcp[12] = 5 << 4
+	tcp[13] = flags
+	tcp = append(tcp, payload...)
+
+	ip := make([]byte, 20)
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:4], uint16(20+len(tcp)))
+	ip[8] = 64
+	ip[9] = 6
+	copy(ip[12:16], net.ParseIP(src).To4())
+	copy(ip[16:20], net.ParseIP(dst).To4())
+
+	eth := make([]byte, 14)
+	binary.BigEndian.PutUint16(eth[12:14], 0x0800)
+	return append(append(eth, ip...), tcp...)
+}
+
+// sshSession returns the frames of an SSH connection whose banner arrives out of
+// order and with a retransmitted segment
+func sshSession() [][]byte {
+	c, s := "10.0.0.1", "10.0.0.2"
+	return [][]byte{
+		tcpFrame(c, 40000, s, 22, 1000, tcpSyn, ""),
+		tcpFrame(s, 22, c, 40000, 5000, tcpSyn|tcpAck, ""),
+		tcpFrame(c, 40000, s, 22, 1001, tcpAck, ""),
+		tcpFrame(s, 22, c, 40000, 5016, tcpAck, "_8.9p1 Ubuntu-3ubuntu0.1\r\n"),
+		tcpFrame(s, 22, c, 40000, 5001, tcpAck, "SSH-2.0-Op"),
+		tcpFrame(s, 22, c, 40000, 5001, tcpAck, "SSH-2.0-OpenSSH"),
+		tcpFrame(c, 40000, s, 22, 1001, tcpAck, "SSH-2.0-Go\r\n"),
+	}
+}
+
+func httpSession() [][]byte {
+	c, s := "10.0.0.1", "10.0.0.3"
+	return [][]byte{
+		tcpFrame(c, 40001, s, 8080, 2000, tcpSyn, ""),
+		tcpFrame(s, 8080, c, 40001, 9000, tcpSyn|tcpAck, ""),
+		tcpFrame(c, 40001, s, 8080, 2001, tcpAck, "GET / HTTP/1.1\r\nHost: x\r\n\r\n"),
+		tcpFrame(s, 8080, c, 40001, 9001, tcpAck, "HTTP/1.1 200 OK\r\nServer: nginx/1.18.0\r\n\r\n<html><title>CloudKey</title></html>"),
+	}
+}
+
+func writePcap(frames [][]byte) []byte {
+	buf := &bytes.Buffer{}
+	hdr := make([]byte, pcapGlobalHeaderLength)
+	binary.LittleEndian.PutUint32(hdr[0:4], pcapMagicMicroseconds)
+	binary.LittleEndian.PutUint16(hdr[4:6], 2)
+	binary.LittleEndian.PutUint16(hdr[6:8], 4)
+	binary.LittleEndian.PutUint32(hdr[16:20], 65535)
+	binary.LittleEndian.PutUint32(hdr[20:24], linkTypeEthernet)
+	buf.Write(hdr)
+
+	for i, frame := range frames {
+		rec := make([]byte, pcapRecordHeaderLength)
+		binary.LittleEndian.PutUint32(rec[0:4], uint32(1600000000+i))
+		binary.LittleEndian.PutUint32(rec[8:12], uint32(len(frame)))
+		binary.LittleEndian.PutUint32(rec[12:16], uint32(len(frame)))
+		buf.Write(rec)
+		buf.Write(frame)
+	}
+	return buf.Bytes()
+}
+
+func pcapngBlock(blockType uint32, body []byte) []byte {
+	for len(body)%4 != 0 {
+		body = append(body, 0)
+	}
+	blk := make([]byte, 8)
+	binary.LittleEndian.PutUint32(blk[0:4], blockType)
+	binary.LittleEndian.PutUint32(blk[4:8], uint32(len(body)+12))
+	blk = append(blk, body...)
+	return append(blk, blk[4:8]...)
+}
+
+func writePcapng(frames [][]byte) []byte {
+	buf := &bytes.Buffer{}
+
+	shb := make([]byte, 16)
+	binary.LittleEndian.PutUint32(shb[0:4], pcapngByteOrderMagic)
+	binary.LittleEndian.PutUint16(shb[4:6], 1)
+	binary.LittleEndian.PutUint64(shb[8:16], 0xFFFFFFFFFFFFFFFF)
+	buf.Write(pcapngBlock(pcapngSectionHeader, shb))
+
+	idb := make([]byte, 8)
+	binary.LittleEndian.PutUint16(idb[0:2], linkTypeEthernet)
+	buf.Write(pcapngBlock(pcapngInterfaceDesc, idb))
+
+	for _, frame := range frames {
+		epb := make([]byte, 20)
+		binary.LittleEndian.PutUint32(epb[12:16], uint32(len(frame)))
+		binary.LittleEndian.PutUint32(epb[16:20], uint32(len(frame)))
+		buf.Write(pcapngBlock(pcapngEnhancedPacket, append(epb, frame...)))
+	}
+	return buf.Bytes()
+}
+
+// readCapture returns the flows in a capture in the order they were finished, keeping
+// a copy of each so that its payload survives being released by the flow table
+func readCapture(capture []byte) ([]*flow, error) {
+	pr, err := newPacketReader(bytes.NewReader(capture))
+	if err != nil {
+		return nil, err
+	}
+	var flows []*flow
+	err = readFlows(pr, 65536, 0, func(fl *flow) {
+		c := *fl
+		flows = append(flows, &c)
+	})
+	return flows, err
+}
+
+// addFrame decodes a frame captured at the given second and adds it to a flow table
+func addFrame(ft *flowTable, sec int64, frame []byte) {
+	ft.add(decodePacket(&packet{ts: time.Unix(sec, 0), linkType: linkTypeEthernet, data: frame}))
+}
+
+func TestReadFlows(t *testing.T) {
+	frames := append(sshSession(), httpSession()...)
+	for name, capture := range map[string][]byte{"pcap": writePcap(frames), "pcapng": writePcapng(frames)} {
+		flows, err := readCapture(capture)
+		if err != nil {
+			t.Fatalf("%s: readFlows() failed: %s", name, err)
+		}
+
+		if len(flows) != 2 {
+			t.Fatalf("%s: expected 2 flows, got %d", name, len(flows))
+		}
+
+		ssh := flows[0]
+		if ssh.server != "10.0.0.2:22" || ssh.client != "10.0.0.1:40000" {
+			t.Errorf("%s: unexpected endpoints %s -> %s", name, ssh.client, ssh.server)
+		}
+		if got := string(ssh.streams[ssh.server].reassemble(65536)); got != "SSH-2.0-OpenSSH_8.9p1 Ubuntu-3ubuntu0.1\r\n" {
+			t.Errorf("%s: unexpected reassembled server payload %q", name, got)
+		}
+	}
+}
+
+func TestFlowTable(t *testing.T) {
+	var done []*flow
+	ft := newFlowTable(65536, 2*time.Minute, func(fl *flow) {
+		c := *fl
+		done = append(done, &c)
+	})
+
+	// The SSH flow is finished as soon as the server greeting is complete, and the
+	// client's later payload does not start another flow
+	for i, frame := range sshSession() {
+		addFrame(ft, int64(i), frame)
+	}
+	if len(done) != 1 || len(ft.flows) != 1 || ft.flows[done[0].key].streams != nil {
+		t.Fatalf("expected the SSH flow to be finished and released, got %d finished of %d", len(done), len(ft.flows))
+	}
+
+	// A SYN after a FIN starts a new connection on the same ports
+	c, s := "10.0.0.1", "10.0.0.3"
+	addFrame(ft, 10, tcpFrame(c, 40001, s, 80, 2000, tcpSyn, ""))
+	addFrame(ft, 10, tcpFrame(s, 80, c, 40001, 9001, tcpAck, "HTTP/1.1 200 OK\r\nServer: first\r\n\r\n"))
+	addFrame(ft, 11, tcpFrame(c, 40001, s, 80, 2001, tcpFin|tcpAck, ""))
+	addFrame(ft, 20, tcpFrame(c, 40001, s, 80, 7000, tcpSyn, ""))
+	addFrame(ft, 20, tcpFrame(s, 80, c, 40001, 3001, tcpAck, "HTTP/1.1 200 OK\r\nServer: second\r\n\r\n"))
+	if len(done) != 2 || !bytes.Contains(done[1].streams[done[1].server].reassemble(65536), []byte("first")) {
+		t.Fatalf("expected the first HTTP connection to be finished by the second SYN, got %d finished", len(done))
+	}
+
+	// Idle flows are finished and dropped from the table
+	addFrame(ft, 200, tcpFrame("10.0.0.4", 40002, "10.0.0.5", 80, 100, tcpSyn, ""))
+	if len(done) != 3 || len(ft.flows) != 1 {
+		t.Fatalf("expected idle flows to be dropped, got %d finished with %d in the table", len(done), len(ft.flows))
+	}
+	if got := done[2].streams[done[2].server].reassemble(65536); !bytes.Contains(got, []byte("second")) {
+		t.Errorf("unexpected payload for the second HTTP connection: %q", got)
+	}
+
+	ft.flush()
+	if len(done) != 4 || len(ft.flows) != 0 {
+		t.Errorf("expected flush to finish the remaining flow, got %d finished with %d in the table", len(done), len(ft.flows))
+	}
+}
+
+func TestExtractBanners(t *testing.T) {
+	cases := []struct {
+		port     uint16
+		server   string
+		proto    string
+		matchKey string
+		input    string
+	}{
+		{22, "SSH-2.0-OpenSSH_8.9p1 Ubuntu-3\r\n", "ssh", "ssh.banner", "OpenSSH_8.9p1 Ubuntu-3"},
+		{2121, "220 ProFTPD 1.3.5 Server (Debian) [::ffff:10.0.0.2]\r\n", "ftp", "ftp.banner", "ProFTPD 1.3.5 Server (Debian) [::ffff:10.0.0.2]"},
+		{25, "220 mail.example.com ESMTP Postfix (Ubuntu)\r\n", "smtp", "smtp.banner", "mail.example.com ESMTP Postfix (Ubuntu)"},
+		{110, "+OK Dovecot ready.\r\n", "pop3", "pop3.banner", "Dovecot ready."},
+		{143, "* OK [CAPABILITY IMAP4rev1] Dovecot ready.\r\n", "imap", "imap4.banner", "[CAPABILITY IMAP4rev1] Dovecot ready."},
+		{119, "200 news.example.com InterNetNews NNRP server INN 2.6.4 ready\r\n", "nntp", "nntp.banner", "news.example.com InterNetNews NNRP server INN 2.6.4 ready"},
+		{554, "RTSP/1.0 200 OK\r\nCSeq: 1\r\nServer: Dahua Rtsp Server\r\n\r\n", "rtsp", "rtsp_header.server", "Dahua Rtsp Server"},
+		{5060, "SIP/2.0 200 OK\r\nServer: Cisco-SIPGateway/IOS-12.x\r\n\r\n", "sip", "sip_header.server", "Cisco-SIPGateway/IOS-12.x"},
+		{23, "\r\nlogin: ", "telnet", "telnet_banners.xml", "login:"},
+		{2323, "\xff\xfb\x01\xff\xfb\x03\r\nUser Access Verification\r\n\r\nUsername: ", "telnet", "telnet_banners.xml", "User Access Verification\r\n\r\nUsername:"},
+	}
+
+	for _, c := range cases {
+		proto := identifyProtocol(c.port, []byte(c.server), nil)
+		if proto != c.proto {
+			t.Errorf("identifyProtocol(%d, %q) returned %q, expected %q", c.port, c.server, proto, c.proto)
+			continue
+		}
+		banners := extractBanners(proto, []byte(c.server), nil)
+		if len(banners) != 1 || banners[0].MatchKey != c.matchKey || banners[0].Input != c.input {
+			t.Errorf("extractBanners(%q) returned %#v", c.server, banners)
+		}
+	}
+}
+
+func TestFingerprintFlow(t *testing.T) {
+	fpset, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	flows, err := readCapture(writePcap(httpSession()))
+	if err != nil || len(flows) != 1 {
+		t.Fatalf("readFlows() failed: %v", err)
+	}
+
+	res := fingerprintFlow(fpset, flows[0], 65536)
+	if res.Protocol != "http" || len(res.Banners) != 2 {
+		t.Fatalf("unexpected result: %#v", res)
+	}
+
+	for _, b := range res.Banners {
+		if len(b.Matches) == 0 {
+			t.Errorf("%s %q did not match", b.MatchKey, b.Input)
+		}
+	}
+	if res.Banners[1].Matches[0]["hw.product"] != "UniFi Cloud Key" {
+		t.Errorf("unexpected html_title match: %v", res.Banners[1].Matches[0])
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Link types supported by the packet decoder
+const (
+	linkTypeNull     = 0
+	linkTypeEthernet = 1
+	linkTypeRaw      = 101
+	linkTypeRawAlt   = 12
+	linkTypeLoop     = 108
+	linkTypeLinuxSLL = 113
+	linkTypeIPv4     = 228
+	linkTypeIPv6     = 229
+	linkTypeSLL2     = 276
+)
+
+// Block types used by the pcapng reader
+const (
+	pcapngSectionHeader     = 0x0A0D0D0A
+	pcapngInterfaceDesc     = 0x00000001
+	pcapngSimplePacket      = 0x00000003
+	pcapngEnhancedPacket    = 0x00000006
+	pcapngByteOrderMagic    = 0x1A2B3C4D
+	pcapngOptionEnd         = 0
+	pcapngOptionTSResol     = 9
+	pcapngMaxBlockSize      = 64 * 1024 * 1024
+	pcapMaxSnapLen          = 256 * 1024
+	pcapMagicMicroseconds   = 0xA1B2C3D4
+	pcapMagicNanoseconds    = 0xA1B23C4D
+	pcapGlobalHeaderLength  = 24
+	pcapRecordHeaderLength  = 16
+	pcapngBlockHeaderLength = 8
+)
+
+// packet is a single captured frame along with its link type
+type packet struct {
+	ts       time.Time
+	linkType int
+	data     []byte
+}
+
+// packetReader returns captured frames from a pcap or pcapng file
+type packetReader interface {
+	next() (*packet, error)
+}
+
+// newPacketReader detects the capture format and returns a matching reader
+func newPacketReader(r io.Reader) (packetReader, error) {
+	br := bufio.NewReaderSize(r, 1024*1024)
+	magic, err := br.Peek(4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read capture header: %s", err)
+	}
+
+	if binary.BigEndian.Uint32(magic) == pcapngSectionHeader {
+		return &pcapngReader{r: br}, nil
+	}
+	return newPcapReader(br)
+}
+
+// pcapReader reads the classic libpcap file format
+type pcapReader struct {
+	r        io.Reader
+	order    binary.ByteOrder
+	nanos    bool
+	linkType int
+}
+
+func newPcapReader(r io.Reader) (*pcapReader, error) {
+	hdr := make([]byte, pcapGlobalHeaderLength)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, fmt.Errorf("failed to read pcap header: %s", err)
+	}
+
+	pr := &pcapReader{r: r}
+	switch {
+	case binary.LittleEndian.Uint32(hdr) == pcapMagicMicroseconds:
+		pr.order = binary.LittleEndian
+	case binary.BigEndian.Uint32(hdr) == pcapMagicMicroseconds:
+		pr.order = binary.BigEndian
+	case binary.LittleEndian.Uint32(hdr) == pcapMagicNanoseconds:
+		pr.order = binary.LittleEndian
+		pr.nanos = true
+	case binary.BigEndian.Uint32(hdr) == pcapMagicNanoseconds:
+		pr.order = binary.BigEndian
+		pr.nanos = true
+	default:
+		return nil, fmt.Errorf("unknown capture format (magic %x)", hdr[0:4])
+	}
+
+	// The upper bits of the link type field may carry FCS information
+	pr.linkType = int(pr.order.Uint32(hdr[20:24]) & 0x0FFFFFFF)
+	return pr, nil
+}
+
+func (pr *pcapReader) next() (*packet, error) {
+	hdr := make([]byte, pcapRecordHeaderLength)
+	if _, err := io.ReadFull(pr.r, hdr); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("truncated pcap record header")
+		}
+		return nil, err
+	}
+
+	sec := int64(pr.order.Uint32(hdr[0:4]))
+	frac := int64(pr.order.Uint32(hdr[4:8]))
+	inclLen := pr.order.Uint32(hdr[8:12])
+	if inclLen > pcapMaxSnapLen {
+		return nil, fmt.Errorf("pcap record length %d exceeds the maximum of %d", inclLen, pcapMaxSnapLen)
+	}
+
+	data := make([]byte, inclLen)
+	if _, err := io.ReadFull(pr.r, data); err != nil {
+		return nil, fmt.Errorf("truncated pcap record: %s", err)
+	}
+
+	if !pr.nanos {
+		frac *= 1000
+	}
+	return &packet{ts: time.Unix(sec, frac).UTC(), linkType: pr.linkType, data: data}, nil
+}
+
+// pcapngInterface tracks the properties of an interface description block
+type pcapngInterface struct {
+	linkType int
+	tsResol  float64
+}
+
+// pcapngReader reads the pcapng file format
+type pcapngReader struct {
+	r          io.Reader
+	order      binary.ByteOrder
+	interfaces []*pcapngInterface
+}
+
+func (pr *pcapngReader) next() (*packet, error) {
+	for {
+		hdr := make([]byte, pcapngBlockHeaderLength)
+		if _, err := io.ReadFull(pr.r, hdr); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("truncated pcapng block header")
+			}
+			return nil, err
+		}
+
+		// The section header block sets the byte order for the blocks that follow
+		if binary.BigEndian.Uint32(hdr[0:4]) == pcapngSectionHeader {
+			if err := pr.readSectionHeader(hdr); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if pr.order == nil {
+			return nil, fmt.Errorf("pcapng block found before the section header")
+		}
+
+		blockType := pr.order.Uint32(hdr[0:4])
+		blockLen := pr.order.Uint32(hdr[4:8])
+		if blockLen < 12 || blockLen%4 != 0 || blockLen > pcapngMaxBlockSize {
+			return nil, fmt.Errorf("invalid pcapng block length %d", blockLen)
+		}
+
+		// The body excludes the leading header and the trailing length
+		body := make([]byte, blockLen-12)
+		if _, err := io.ReadFull(pr.r, body); err != nil {
+			return nil, fmt.Errorf("truncated pcapng block: %s", err)
+		}
+		if _, err := io.ReadFull(pr.r, make([]byte, 4)); err != nil {
+			return nil, fmt.Errorf("truncated pcapng block trailer: %s", err)
+		}
+
+		switch blockType {
+		case pcapngInterfaceDesc:
+			pr.readInterface(body)
+		case pcapngEnhancedPacket:
+			if pkt := pr.readEnhancedPacket(body); pkt != nil {
+				return pkt, nil
+			}
+		case pcapngSimplePacket:
+			if pkt := pr.readSimplePacket(body); pkt != nil {
+				return pkt, nil
+			}
+		}
+	}
+}
+
+func (pr *pcapngReader) readSectionHeader(hdr []byte) error {
+	rest := make([]byte, 4)
+	if _, err := io.ReadFull(pr.r, rest); err != nil {
+		return fmt.Errorf("truncated pcapng section header: %s", err)
+	}
+
+	switch {
+	case binary.LittleEndian.Uint32(rest) == pcapngByteOrderMagic:
+		pr.order = binary.LittleEndian
+	case binary.BigEndian.Uint32(rest) == pcapngByteOrderMagic:
+		pr.order = binary.BigEndian
+	default:
+		return fmt.Errorf("invalid pcapng byte order magic %x", rest)
+	}
+
+	blockLen := pr.order.Uint32(hdr[4:8])
+	if blockLen < 28 || blockLen%4 != 0 || blockLen > pcapngMaxBlockSize {
+		return fmt.Errorf("invalid pcapng section header length %d", blockLen)
+	}
+
+	// Skip the version, section length, options, and trailing length
+	if _, err := io.ReadFull(pr.r, make([]byte, blockLen-12)); err != nil {
+		return fmt.Errorf("truncated pcapng section header: %s", err)
+	}
+
+	// Interface ids are scoped to their section
+	pr.interfaces = nil
+	return nil
+}
+
+func (pr *pcapngReader) readInterface(body []byte) {
+	iface := &pcapngInterface{tsResol: 1e-6}
+	if len(body) >= 8 {
+		iface.linkType = int(pr.order.Uint16(body[0:2]))
+		pr.readInterfaceOptions(iface, body[8:])
+	}
+	pr.interfaces = append(pr.interfaces, iface)
+}
+
+func (pr *pcapngReader) readInterfaceOptions(iface *pcapngInterface, opts []byte) {
+	for len(opts) >= 4 {
+		code := pr.order.Uint16(opts[0:2])
+		olen := int(pr.order.Uint16(opts[2:4]))
+		if code == pcapngOptionEnd || len(opts) < 4+olen {
+			return
+		}
+		if code == pcapngOptionTSResol && olen >= 1 {
+			v := opts[4]
+			if v&0x80 != 0 {
+				iface.tsResol = math.Pow(2, -float64(v&0x7F))
+			} else {
+				iface.tsResol = math.Pow(10, -float64(v))
+			}
+		}
+		// Options are padded to a 32-bit boundary
+		opts = opts[4+(olen+3)&^3:]
+	}
+}
+
+func (pr *pcapngReader) readEnhancedPacket(body []byte) *packet {
+	if len(body) < 20 {
+		return nil
+	}
+
+	ifaceID := int(pr.order.Uint32(body[0:4]))
+	if ifaceID >= len(pr.interfaces) {
+		return nil
+	}
+	iface := pr.interfaces[ifaceID]
+
+	ts := uint64(pr.order.Uint32(body[4:8]))<<32 | uint64(pr.order.Uint32(body[8:12]))
+	capLen := int(pr.order.Uint32(body[12:16]))
+	if capLen > len(body)-20 {
+		return nil
+	}
+
+	return &packet{ts: pcapngTimestamp(ts, iface.tsResol), linkType: iface.linkType, data: body[20 : 20+capLen]}
+}
+
+func (pr *pcapngReader) readSimplePacket(body []byte) *packet {
+	if len(body) < 4 || len(pr.interfaces) == 0 {
+		return nil
+	}
+
+	// Simple packet blocks always refer to the first interface and omit the capture length
+	origLen := int(pr.order.Uint32(body[0:4]))
+	data := body[4:]
+	if origLen < len(data) {
+		data = data[:origLen]
+	}
+	return &packet{linkType: pr.interfaces[0].linkType, data: data}
+}
+
+func pcapngTimestamp(ts uint64, resol float64) time.Time {
+	secs := float64(ts) * resol
+	whole := math.Floor(secs)
+	return time.Unix(int64(whole), int64((secs-whole)*1e9)).UTC()
+}
@@ -0,0 +1,372 @@
+// Package conflict compares the fingerprints that different protocols report for
+// the same host, such as an SSH banner that says Ubuntu and an SMB native OS that
+// says Windows. Values are put in their canonical form with the Recog identifier
+// vocabularies, and each disagreement is classified as incompatible, or as one
+// value being a more specific form of another.
+package conflict
+
+//go:generate go run ../gen/identifiers/main.go
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+// Kind classifies a disagreement
+type Kind string
+
+const (
+	// Incompatible values cannot describe the same host, such as Linux and Windows
+	Incompatible Kind = "incompatible"
+	// MoreSpecific values refine one another, such as Windows and Windows Server
+	// 2008 R2, and only disagree in detail
+	MoreSpecific Kind = "more-specific"
+)
+
+// DefaultKeys are the value keys compared by Analyze
+var DefaultKeys = []string{"os.family", "os.product", "os.device", "hw.vendor", "hw.device"}
+
+// Source is the set of matches reported by one protocol
+type Source struct {
+	// Name identifies the protocol, such as "ssh" or "smb"
+	Name    string
+	Matches []*recog.FingerprintMatch
+}
+
+// Evidence is one source's value for a key
+type Evidence struct {
+	Source string
+	// Value is the value as matched, and Canonical its vocabulary form
+	Value     string
+	Canonical string
+	// Known is set when the value is in the vocabulary
+	Known bool
+	Match *recog.FingerprintMatch
+}
+
+// Conflict is a key that sources disagree on
+type Conflict struct {
+	Key  string
+	Kind Kind
+	// Values holds each distinct canonical value, from the most general to the
+	// most specific for MoreSpecific conflicts
+	Values []string
+	// Specific is the most specific value of a MoreSpecific conflict
+	Specific string
+	Evidence []Evidence
+}
+
+// Sources returns the names of the sources that reported a value
+func (c *Conflict) Sources(value string) []string {
+	var res []string
+	for _, e := range c.Evidence {
+		if fold(e.Canonical) == fold(value) {
+			res = append(res, e.Source)
+		}
+	}
+	return res
+}
+
+// Vocabulary holds the Recog identifier lists, mapping each value key to the
+// canonical spelling of its known values
+type Vocabulary struct {
+	values map[string]map[string]string
+	// refinements maps a key and general value to the values that refine it
+	refinements map[string]map[string][]string
+}
+
+// vocabularyFiles maps the identifier files of a Recog checkout to value keys
+var vocabularyFiles = map[string][]string{
+	"device":          {"os.device", "hw.device"},
+	"hw_family":       {"hw.family"},
+	"hw_product":      {"hw.product"},
+	"os_architecture": {"os.arch"},
+	"os_family":       {"os.family"},
+	"os_product":      {"os.product"},
+	"service_family":  {"service.family"},
+	"service_product": {"service.product"},
+	"vendor":          {"os.vendor", "hw.vendor", "service.vendor"},
+}
+
+// defaultRefinements are relations between values that share no words
+var defaultRefinements = map[string]map[string][]string{
+	"os.family": {
+		"Unix": {"Linux", "FreeBSD", "OpenBSD", "NetBSD", "Solaris", "AIX", "HP-UX", "Mac OS X"},
+		"BSD":  {"FreeBSD", "OpenBSD", "NetBSD"},
+	},
+	"os.device": {
+		"Printer": {"Multifunction Device"},
+	},
+	"hw.device": {
+		"Printer": {"Multifunction Device"},
+	},
+}
+
+// NewVocabulary returns an empty vocabulary with the default refinements
+func NewVocabulary() *Vocabulary {
+	v := &Vocabulary{values: make(map[string]map[string]string), refinements: make(map[string]map[string][]string)}
+	for key, general := range defaultRefinements {
+		for g, specific := range general {
+			v.AddRefinement(key, g, specific...)
+		}
+	}
+	return v
+}
+
+// LoadVocabulary reads the identifier lists from the identifiers directory of a
+// Recog checkout, such as $RECOG_HOME/identifiers. Lists that are missing are
+// skipped.
+func LoadVocabulary(dir string) (*Vocabulary, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to load identifiers: %s", err)
+	}
+	return LoadVocabularyFromFS(http.Dir(dir))
+}
+
+// LoadVocabularyFromFS reads the identifier lists from the root of a filesystem,
+// skipping lists that are missing
+func LoadVocabularyFromFS(fs http.FileSystem) (*Vocabulary, error) {
+	v := NewVocabulary()
+	for name, keys := range vocabularyFiles {
+		f, err := fs.Open("/" + name + ".txt")
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %q identifiers: %s", name, err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			v.Add(scanner.Text(), keys...)
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %q identifiers: %s", name, err)
+		}
+	}
+	return v, nil
+}
+
+var (
+	defaultVocabulary     *Vocabulary
+	defaultVocabularyOnce sync.Once
+)
+
+// DefaultVocabulary returns the vocabulary of the embedded identifier lists, which
+// hold the fixed values assigned by the embedded fingerprints. It is loaded once
+// and shared, so callers that add to it should load their own copy with
+// LoadVocabularyFromFS(Identifiers).
+func DefaultVocabulary() *Vocabulary {
+	defaultVocabularyOnce.Do(func() {
+		v, err := LoadVocabularyFromFS(Identifiers)
+		if err != nil {
+			panic(err)
+		}
+		defaultVocabulary = v
+	})
+	return defaultVocabulary
+}
+
+// Add registers a known value for keys
+func (v *Vocabulary) Add(value string, keys ...string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	for _, key := range keys {
+		if v.values[key] == nil {
+			v.values[key] = make(map[string]string)
+		}
+		v.values[key][fold(value)] = value
+	}
+}
+
+// AddRefinement records that specific values of a key refine a general one
+func (v *Vocabulary) AddRefinement(key string, general string, specific ...string) {
+	if v.refinements[key] == nil {
+		v.refinements[key] = make(map[string][]string)
+	}
+	g := fold(general)
+	for _, s := range specific {
+		v.refinements[key][g] = append(v.refinements[key][g], fold(s))
+	}
+}
+
+// Canonical returns the vocabulary spelling of a value and whether it is known.
+// Unknown values are returned trimmed.
+func (v *Vocabulary) Canonical(key string, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if v == nil {
+		return value, false
+	}
+	if c, ok := v.values[key][fold(value)]; ok {
+		return c, true
+	}
+	return value, false
+}
+
+// Refines reports whether specific is a more specific form of general. Either the
+// words of general appear in order within specific, as Windows does in Windows
+// Server 2008 R2, or the vocabulary relates them.
+func (v *Vocabulary) Refines(key string, specific string, general string) bool {
+	s, g := fold(specific), fold(general)
+	if s == g {
+		return false
+	}
+	if v != nil {
+		for _, r := range v.refinements[key][g] {
+			if r == s {
+				return true
+			}
+		}
+	}
+	return containsWords(strings.Fields(s), strings.Fields(g))
+}
+
+// containsWords reports whether the words of sub appear in order within words
+func containsWords(words []string, sub []string) bool {
+	if len(sub) == 0 || len(sub) >= len(words) {
+		return false
+	}
+	i := 0
+	for _, w := range words {
+		if w == sub[i] {
+			i++
+			if i == len(sub) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fold compares values without case or surrounding space
+func fold(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// Analyzer compares the values of sources
+type Analyzer struct {
+	Keys       []string
+	Vocabulary *Vocabulary
+}
+
+// NewAnalyzer returns an analyzer of the DefaultKeys. The vocabulary may be nil,
+// in which case values are compared as they were matched.
+func NewAnalyzer(vocab *Vocabulary) *Analyzer {
+	return &Analyzer{Keys: DefaultKeys, Vocabulary: vocab}
+}
+
+// Analyze compares sources with the DefaultKeys and the DefaultVocabulary
+func Analyze(sources []Source) []Conflict {
+	return NewAnalyzer(DefaultVocabulary()).Analyze(sources)
+}
+
+// Analyze returns the keys that more than one source gives different values for,
+// in the order of the analyzer's keys. Disagreements within a single source, such
+// as two fingerprints of one banner, are not conflicts.
+func (a *Analyzer) Analyze(sources []Source) []Conflict {
+	var res []Conflict
+	for _, key := range a.Keys {
+		var evidence []Evidence
+		for _, src := range sources {
+			for _, m := range src.Matches {
+				// suppressed matches recognize an input without attributing it
+				if m == nil || m.Suppressed {
+					continue
+				}
+				value, ok := m.Values[key]
+				if !ok || strings.TrimSpace(value) == "" {
+					continue
+				}
+				canonical, known := a.Vocabulary.Canonical(key, value)
+				evidence = append(evidence, Evidence{Source: src.Name, Value: value, Canonical: canonical, Known: known, Match: m})
+			}
+		}
+		if c, ok := a.compare(key, evidence); ok {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// compare classifies the evidence for one key
+func (a *Analyzer) compare(key string, evidence []Evidence) (Conflict, bool) {
+	var values []string
+	seen := make(map[string]bool)
+	sources := make(map[string]map[string]bool)
+	for _, e := range evidence {
+		f := fold(e.Canonical)
+		if !seen[f] {
+			seen[f] = true
+			values = append(values, e.Canonical)
+		}
+		if sources[f] == nil {
+			sources[f] = make(map[string]bool)
+		}
+		sources[f][e.Source] = true
+	}
+	if len(values) < 2 || !acrossSources(sources) {
+		return Conflict{}, false
+	}
+
+	c := Conflict{Key: key, Evidence: evidence}
+	c.Kind, c.Values = a.Vocabulary.Classify(key, values...)
+	if c.Kind == MoreSpecific {
+		c.Specific = c.Values[len(c.Values)-1]
+	}
+	return c, true
+}
+
+// Classify reports how distinct values of a key disagree. MoreSpecific values are
+// returned from the most general to the most specific, and Incompatible values in
+// their original order.
+func (v *Vocabulary) Classify(key string, values ...string) (Kind, []string) {
+	// In a chain of refinements each value refines every value before it, so
+	// ordering by the number of values refined puts the most general first
+	refined := make(map[string]int, len(values))
+	for _, s := range values {
+		for _, g := range values {
+			if v.Refines(key, s, g) {
+				refined[s]++
+			}
+		}
+	}
+	chain := append([]string{}, values...)
+	sort.SliceStable(chain, func(i, j int) bool {
+		return refined[chain[i]] < refined[chain[j]]
+	})
+	for i := 1; i < len(chain); i++ {
+		if !v.Refines(key, chain[i], chain[i-1]) {
+			return Incompatible, values
+		}
+	}
+	return MoreSpecific, chain
+}
+
+// acrossSources reports whether different sources hold different values, given
+// the sources of each value
+func acrossSources(sources map[string]map[string]bool) bool {
+	for v1, s1 := range sources {
+		for v2, s2 := range sources {
+			if v1 == v2 {
+				continue
+			}
+			for a := range s1 {
+				for b := range s2 {
+					if a != b {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
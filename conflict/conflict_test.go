@@ -0,0 +1,153 @@
+package conflict
+
+import (
+	"reflect"
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+func match(values map[string]string) *recog.FingerprintMatch {
+	return &recog.FingerprintMatch{Values: values}
+}
+
+func TestAnalyze(t *testing.T) {
+	vocab, err := LoadVocabulary("testdata/identifiers")
+	if err != nil {
+		t.Fatalf("failed to load vocabulary: %s", err)
+	}
+	if _, err := LoadVocabulary("testdata/missing"); err == nil {
+		t.Errorf("expected an error for a missing directory")
+	}
+	if c, ok := vocab.Canonical("os.product", "windows server 2008 r2"); !ok || c != "Windows Server 2008 R2" {
+		t.Errorf("unexpected canonical value %q %v", c, ok)
+	}
+	if c, ok := vocab.Canonical("os.product", " Plan 9 "); ok || c != "Plan 9" {
+		t.Errorf("unexpected canonical value %q %v", c, ok)
+	}
+
+	tests := []struct {
+		name     string
+		sources  []Source
+		key      string
+		kind     Kind
+		values   []string
+		specific string
+	}{
+		{
+			name: "incompatible",
+			sources: []Source{
+				{Name: "ssh", Matches: []*recog.FingerprintMatch{match(map[string]string{"os.family": "Linux", "os.vendor": "Ubuntu"})}},
+				{Name: "smb", Matches: []*recog.FingerprintMatch{match(map[string]string{"os.family": "windows"})}},
+			},
+			key:    "os.family",
+			kind:   Incompatible,
+			values: []string{"Linux", "Windows"},
+		},
+		{
+			name: "more specific by words",
+			sources: []Source{
+				{Name: "smb", Matches: []*recog.FingerprintMatch{match(map[string]string{"os.product": "Windows Server 2008 R2"})}},
+				{Name: "http", Matches: []*recog.FingerprintMatch{match(map[string]string{"os.product": "Windows"})}},
+				{Name: "snmp", Matches: []*recog.FingerprintMatch{match(map[string]string{"os.product": "Windows Server"})}},
+			},
+			key:      "os.product",
+			kind:     MoreSpecific,
+			values:   []string{"Windows", "Windows Server", "Windows Server 2008 R2"},
+			specific: "Windows Server 2008 R2",
+		},
+		{
+			name: "more specific by vocabulary",
+			sources: []Source{
+				{Name: "snmp", Matches: []*recog.FingerprintMatch{match(map[string]string{"hw.device": "Multifunction Device"})}},
+				{Name: "ipp", Matches: []*recog.FingerprintMatch{match(map[string]string{"hw.device": "printer"})}},
+			},
+			key:      "hw.device",
+			kind:     MoreSpecific,
+			values:   []string{"Printer", "Multifunction Device"},
+			specific: "Multifunction Device",
+		},
+	}
+
+	a := NewAnalyzer(vocab)
+	for _, test := range tests {
+		res := a.Analyze(test.sources)
+		if len(res) != 1 {
+			t.Errorf("%s: expected 1 conflict, got %+v", test.name, res)
+			continue
+		}
+		c := res[0]
+		if c.Key != test.key || c.Kind != test.kind || !reflect.DeepEqual(c.Values, test.values) || c.Specific != test.specific {
+			t.Errorf("%s: unexpected conflict %+v", test.name, c)
+		}
+		if len(c.Evidence) != len(test.sources) {
+			t.Errorf("%s: expected evidence from every source, got %d", test.name, len(c.Evidence))
+		}
+	}
+
+	// Values that differ only within one source, or only in case, do not conflict,
+	// and suppressed matches give no evidence
+	res := a.Analyze([]Source{
+		{Name: "http", Matches: []*recog.FingerprintMatch{
+			match(map[string]string{"os.family": "Linux"}),
+			match(map[string]string{"os.family": "Windows"}),
+		}},
+		{Name: "ssh", Matches: []*recog.FingerprintMatch{match(map[string]string{"hw.vendor": "cisco"}), nil}},
+		{Name: "snmp", Matches: []*recog.FingerprintMatch{match(map[string]string{"hw.vendor": "Cisco"})}},
+		{Name: "ftp", Matches: []*recog.FingerprintMatch{{Values: map[string]string{"os.family": "Solaris"}, Suppressed: true}}},
+	})
+	if len(res) != 0 {
+		t.Errorf("expected no conflicts, got %+v", res)
+	}
+}
+
+func TestAnalyzeMatches(t *testing.T) {
+	fs, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("failed to load fingerprints: %s", err)
+	}
+	ssh, err := fs.MatchAll("ssh.banner", "OpenSSH_8.9p1 Ubuntu-3ubuntu0.1")
+	if err != nil {
+		t.Fatalf("failed to match ssh: %s", err)
+	}
+	smb, err := fs.MatchAll("smb.native_os", "Windows Server 2008 R2 Standard 7601 Service Pack 1")
+	if err != nil {
+		t.Fatalf("failed to match smb: %s", err)
+	}
+
+	res := Analyze([]Source{{Name: "ssh", Matches: ssh}, {Name: "smb", Matches: smb}})
+	var product *Conflict
+	for i := range res {
+		if res[i].Key == "os.product" {
+			product = &res[i]
+		}
+	}
+	if product == nil {
+		t.Fatalf("expected an os.product conflict, got %+v", res)
+	}
+	if product.Kind != Incompatible {
+		t.Errorf("expected an incompatible conflict, got %+v", product)
+	}
+	if s := product.Sources("Windows Server 2008 R2"); !reflect.DeepEqual(s, []string{"smb"}) {
+		t.Errorf("unexpected sources %v", s)
+	}
+}
+
+func TestDefaultVocabulary(t *testing.T) {
+	vocab := DefaultVocabulary()
+	if c, ok := vocab.Canonical("os.family", "linux"); !ok || c != "Linux" {
+		t.Errorf("unexpected canonical value %q %v", c, ok)
+	}
+	if c, ok := vocab.Canonical("hw.vendor", "CISCO"); !ok || c != "Cisco" {
+		t.Errorf("unexpected canonical value %q %v", c, ok)
+	}
+
+	// Analyze reports values in the spelling of the embedded lists
+	res := Analyze([]Source{
+		{Name: "ssh", Matches: []*recog.FingerprintMatch{match(map[string]string{"os.family": "linux"})}},
+		{Name: "smb", Matches: []*recog.FingerprintMatch{match(map[string]string{"os.family": "WINDOWS"})}},
+	})
+	if len(res) != 1 || !reflect.DeepEqual(res[0].Values, []string{"Linux", "Windows"}) {
+		t.Errorf("unexpected conflicts %+v", res)
+	}
+}
@@ -0,0 +1,282 @@
+// Code generated by vfsgen; DO NOT EDIT.
+
+package conflict
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	pathpkg "path"
+	"time"
+)
+
+// Identifiers statically implements the virtual filesystem provided to vfsgen.
+var Identifiers = func() http.FileSystem {
+	fs := vfsgen۰FS{
+		"/": &vfsgen۰DirInfo{
+			name:    "/",
+			modTime: time.Date(2026, 10, 19, 1, 59, 13, 896921508, time.UTC),
+		},
+		"/device.txt": &vfsgen۰CompressedFileInfo{
+			name:             "device.txt",
+			modTime:          time.Date(2026, 10, 19, 1, 59, 13, 896921508, time.UTC),
+			uncompressedSize: 1384,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x4c\x54\x5d\x72\xb3\x3a\x0c\x7d\xd7\x2a\xbc\x81\x6f\xee\x1a\x08\xb4\x5f\xb9\x17\x52\x4f\x4c\xc8\xb3\x02\x6a\xa2\xa9\xb1\x19\x61\x9a\x66\xf7\x77\xcc\x5f\x78\x89\x75\x64\xa1\xe8\x48\x47\x4e\x32\x53\xa8\xd2\xb7\xd4\xc1\x64\x9e\xfc\x18\x48\x20\xa9\x4a\x15\xe1\xd9\x71\x80\xa4\x56\x27\x6a\x88\x7f\xe2\x45\xd3\xd0\x30\xa8\xd4\xbb\x20\xde\x42\x62\x51\x3a\xa5\xd1\x91\x85\xa4\xef\x2d\xa3\x6b\x08\x92\xb1\x65\xaf\xde\x5c\xe3\x5b\x12\x38\x88\xc7\xf6\x8a\xae\x5d\x93\x1f\x46\xb6\x2d\xbb\x9b\x4a\xc6\xe0\x3b\x0c\xec\x1d\xa4\x78\xb5\xb4\x14\x92\xde\xa9\xf9\x56\xa6\x41\xe7\x48\x20\xf5\x3d\x93\x40\xf6\x99\x9a\xdc\xa8\x7d\xdc\xab\xf4\xcc\x14\x49\x09\x99\x39\xff\x93\x9a\x33\x64\xf5\x09\x32\x0c\xa8\x2a\x92\x8e\x1d\x5a\xc8\x68\xf8\x0e\xbe\x87\x8c\x7e\xb8\xa1\xe5\x50\x1f\xe3\x75\x35\x0d\x49\x64\x97\xf1\xd0\x5b\x7c\xae\xf4\x2c\x09\xbc\xb9\x1f\x16\xef\x3a\x72\x61\x63\xfd\x16\xee\x24\x8e\x82\x4a\x5a\xec\x23\xa3\x77\xfc\x5d\x53\xbc\xb3\xd0\x03\xad\x85\x77\xc1\x8e\xd4\x89\x2c\x3e\xe1\xa3\xcc\xf7\x39\x3f\xd0\xb5\x77\xb2\xed\xc6\x31\x56\xf2\xf1\xec\x49\x7e\x78\xf0\x02\x79\x66\x20\xd7\x2a\xc5\x8e\x04\x21\xd7\x11\x55\x35\xe4\xae\x1d\x87\x20\x8c\x76\xab\xe4\xdf\x2a\xf9\xbb\x55\xf1\x5f\x5d\x42\x81\x7d\x24\x5a\xf0\xed\x1e\xd4\x61\xb4\xd7\xd9\x1c\xd4\xe7\x18\x54\x89\x0e\x6f\x14\xa9\x40\xe1\xb1\x55\x07\xb4\x71\x5e\x02\x25\xb2\xfb\x8a\xf5\xc2\x2b\x44\x69\xf1\x71\xd6\x5e\xa0\xa4\x96\x51\xfd\xc5\x40\x0f\x7c\x2e\x48\x5b\x7c\xd2\x7a\xb5\xc9\x63\x86\x4b\x2b\x22\x68\xd0\x42\xe9\xaf\x6c\x69\x39\x94\xbe\x7b\x17\x81\xe3\xe0\x85\xdd\x0d\xca\xd1\x06\xfe\x1a\x5d\x13\x85\xa0\x96\x11\x4d\xce\xde\xd2\x2f\x09\x1c\x93\x14\x8e\x89\x81\x23\x85\x87\x97\xef\xf5\x54\x2f\xc1\x6d\x9e\x28\xbc\x0d\xed\xb8\x2c\x59\xd7\x9b\xb5\xef\x9f\xee\xea\x51\x5a\x95\xb4\x1d\x3b\x1e\x82\x60\xf0\x02\x3a\x3b\x83\x2e\x52\xd0\x9e\x5d\x50\xfe\x4b\x19\xb4\x04\xda\x3f\x48\xd6\x44\x33\x28\x29\xb6\x7d\xb6\xe7\x41\x4f\xb6\x65\x47\xa0\x25\x7e\xbc\x74\x62\x02\x24\x30\x05\xed\x95\x70\xa2\xce\x07\x52\xcb\x56\x2d\xd1\x8b\x73\x53\xef\xb2\x36\x26\xfb\x73\x49\x8e\x3b\xd6\x26\xd7\x6b\x3d\xd1\x5c\xe7\x63\x4c\xf1\xa7\xd6\x47\x58\x49\x1a\x6a\x46\xe1\xf0\xdc\x7f\x49\x2e\xce\xd5\x74\x28\x41\x55\x35\x98\xe0\x05\x6f\xb4\x9e\xfb\xc8\xb1\xef\xbd\x84\xbd\xe7\xc1\xa1\xb9\x43\x15\x17\x31\x40\x85\x3d\xa9\x82\xaf\x82\xf2\x84\x8a\x2c\x35\xbe\x83\x8a\x86\xa0\x72\x37\x04\x19\x27\xb1\x55\x77\x76\x2a\xb5\x1c\xed\xb3\x36\x70\x36\x87\x95\xeb\xd9\xf1\xef\x05\x85\x20\x56\x5c\x73\x4b\x3e\xb6\xe7\x8b\x84\x5c\x13\xc5\x31\xbb\x32\x9a\x9f\x92\x19\xad\x0f\x4b\xcd\xf4\x30\x61\x7e\x3f\x6a\x9f\xeb\xe9\x67\x6b\xc3\x04\x96\x7f\x99\xed\xb9\xf0\xda\xc7\x85\x7f\xf1\x99\x7a\xda\x34\x64\x69\x9e\xfe\x25\xd1\x70\x29\x92\xa3\x3a\x51\x4f\x18\x1b\x7f\xa1\x6b\xdc\xc5\xe9\xd4\xe2\x7f\x9f\x70\xb9\x73\xa0\x49\x3a\x70\x61\x21\xbb\x7b\x12\xe3\x54\x37\x9f\x16\x1a\x68\x9a\xfc\xff\x03\x00\xde\x4e\x81\x42\x68\x05\x00\x00"),
+		},
+		"/hw_family.txt": &vfsgen۰CompressedFileInfo{
+			name:             "hw_family.txt",
+			modTime:          time.Date(2026, 10, 19, 1, 59, 13, 896921508, time.UTC),
+			uncompressedSize: 1279,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\x93\xdf\x72\xab\x38\x0c\xc6\xef\xf5\x14\xde\x07\xd8\x99\x9e\xce\x9e\xd9\x6b\x4e\xc8\xbf\xb3\x01\x3c\x98\x12\x26\x77\x2e\xa8\xa9\x26\x60\x31\xc2\x34\xe5\xed\x77\x4c\x12\xda\x8b\x73\xe3\xfc\x64\xc5\xb6\xf8\x3e\x29\xca\x95\x41\x21\x1c\x20\x6a\x6c\xef\xe9\x03\x95\xc1\x7a\x14\xf2\x93\x8a\xfa\xbe\x25\xeb\x6a\x84\xe8\x8d\x6a\x62\x88\x48\x34\x8b\x87\x90\x40\x55\x94\xb0\xe2\xae\x1b\x1d\xd5\xd6\x13\x3b\x95\x58\x67\xcf\x28\x10\x97\x39\xc4\xd6\x5b\x95\xa5\x45\xa4\x21\xa6\xe1\x62\xfc\xfc\x17\x88\xb9\x1e\x3b\x74\x5e\xad\xd0\x79\x41\x58\xc7\x39\xac\x47\x61\xff\x8e\xd2\xc1\xfa\xd3\xa3\x6b\xb0\x51\x66\x1a\x3c\x76\x83\xba\x6d\xa4\xe8\x61\x93\xef\x8b\xd3\x5f\xbf\xf8\xf3\x4e\x1b\x76\x77\xd2\x7c\x45\x69\xc9\xe1\x3d\x3e\x1e\xa2\x54\xe5\xd8\xa3\xf5\x28\xb0\x31\xb0\x21\xc1\xab\x6d\xdb\xbf\x7f\xc0\x86\xa5\x1b\x94\x16\x72\x73\x8e\xc5\xd3\xd6\x7a\xbc\x51\xc9\x54\x23\x6c\x8f\xcf\x3f\x61\x5b\x95\xb0\xad\x8e\xb0\x8b\x2b\xd8\x59\xd7\x4c\x05\x3b\x84\x1d\x77\xa8\xb9\x81\x1d\x3b\x9c\xae\xd8\xb6\xb0\x3f\x64\x09\xec\x93\xf0\xc1\xfb\x2c\x85\xdf\xe8\x63\x12\xac\x3d\x1c\xaa\x87\xac\x07\x3b\xa0\xfc\x46\x0f\x07\x72\x97\x15\x77\xaa\xea\x05\x87\x01\x92\xf9\xe1\x44\xaf\xb7\xff\xa8\x70\x3e\x29\x20\x59\x0e\x25\xb6\x56\x1d\x39\x0a\xf0\x8b\xf9\xf2\xf8\x55\x11\xc9\xc2\x5a\x18\x12\x3c\xdb\x3c\x5a\x41\x42\x94\xe2\xec\x4d\x32\xb6\x9e\xde\x46\x57\xcf\x7a\x27\x93\x9a\xcf\xa7\x6b\x48\xe7\x74\x8a\xde\xd4\xb6\x45\x09\x54\x5a\xe7\x6d\x80\x61\xd9\xba\xb2\x5c\x54\x34\x36\xc4\x4b\xf4\x87\x7e\x78\xa4\x4a\x6a\x90\x55\xcc\x2c\xea\xe1\x70\xd6\xa3\x33\xb5\xed\x51\xc5\x38\x5c\x94\x7e\x0f\xca\x65\xbd\x17\x0b\x99\xbc\x12\xe8\xec\xb8\xce\xef\x06\x83\x7e\x0f\xea\x80\x16\xea\x50\xce\x13\x68\xe1\x87\x04\x79\x52\x41\x8e\xb6\x99\xd2\xc8\x04\x68\xb5\xe0\x80\xe1\xf1\xef\x81\xda\x0a\x8f\x3d\xe4\x3c\x06\x43\xcd\xcf\xa7\x27\x95\x5b\x77\x46\x30\x7b\xad\x62\xfc\xa0\xfa\x86\x41\xec\xab\x9d\xc0\x1c\x9e\x7f\x3c\x3d\x81\x41\x09\x29\x15\xd5\x35\x0e\x83\x32\x57\xf2\xf5\xfb\xb2\xfb\xb8\x8d\x3a\xeb\xa9\x56\xbb\x64\xbf\x70\xba\x2e\x16\x36\xff\x7e\x21\xb9\xb1\x43\xa1\x0b\x18\x1e\x5d\xa3\x99\x9c\xbf\xe1\x5d\x16\xb5\xd7\xb7\xb8\xe0\x31\xbc\xd4\x23\x7e\xc7\x3e\x58\x63\x3c\x8b\x0d\xa5\x8f\x4e\x85\x9e\x0d\xe0\x26\x78\x14\x37\x2b\xa6\x2a\x28\x22\xf3\x9f\x6d\xdf\x2c\x14\xd8\xe2\x22\x4a\x41\x1d\xaa\x95\xed\x87\xb1\x0d\x41\xdf\x93\x3b\xdf\xca\x28\x46\x79\xe5\xc5\x9e\x97\x74\x5f\xae\xf3\xed\x1a\x5e\x1c\x6d\x28\xac\x6f\x84\xcd\x97\xc7\x0f\xa1\xca\xb8\x82\xd2\x54\x50\x96\x15\x94\x74\x66\x81\x92\xf7\x1a\x8e\xf1\xf3\x36\x83\x23\x25\xf6\x13\x8e\xd4\xa0\x0a\x13\x65\xfd\x77\x5e\xc6\xeb\xc8\x72\xb9\x8f\xf9\x17\xce\x5d\x5b\x0d\x28\x1f\x08\xa7\x2a\x36\x07\x38\x55\xbb\x14\x4e\x55\x09\x27\x7c\x15\x1b\x66\xdd\x92\x24\x51\x05\x74\xc8\x80\x12\x5b\x03\x69\xdb\xcc\xcb\xdc\xfe\x33\x84\x5b\x66\x98\xa7\x84\x6e\x5d\x46\x61\x38\xc9\x14\x51\x7e\x6b\xc9\x15\x3b\x2f\xdc\xb6\x28\x03\x50\x67\xcf\xb8\x6a\xed\x70\xc7\x7c\x74\x0e\x05\xfe\x1f\x00\x16\x31\x47\x05\xff\x04\x00\x00"),
+		},
+		"/hw_product.txt": &vfsgen۰CompressedFileInfo{
+			name:             "hw_product.txt",
+			modTime:          time.Date(2026, 10, 19, 1, 59, 13, 896921508, time.UTC),
+			uncompressedSize: 8250,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x59\x4b\x7b\xea\xb8\xcf\xdf\xeb\x53\x78\xd9\x79\xfe\x87\x99\x5c\xa0\xc0\x32\x4d\xa0\xcd\x1c\x2e\x99\x04\x68\xdf\xd9\x99\x44\x05\x3f\x0d\x31\xe3\x38\x3d\x65\x3e\xfd\xfb\xd8\xe4\x46\xb9\xf4\xcc\x59\x34\x96\xf5\x93\x6d\x49\x96\x8d\xa5\xda\xb6\x65\x19\x0e\x79\xa6\xef\xf8\xca\xc5\x8e\x3c\x62\x86\x82\x4a\x2e\xc0\xb6\x7b\x96\x79\x19\xe9\x0e\xfb\x96\x43\x3c\x2a\x29\x99\xf0\xcd\x06\x05\xd8\x81\x13\x42\xcf\xb6\x6c\xc3\x21\x63\x81\xff\x14\x98\xc5\x07\xe2\xf2\x22\x93\x28\x60\x30\xb0\xc9\x8a\xfb\x01\x38\xde\x43\xa7\x3b\xb0\x0c\xd7\x03\x27\xf0\x5d\x70\x56\x24\xc4\x18\xd9\x3b\x0a\x70\x56\x6e\x7f\xd0\x07\x27\x8e\x31\xcf\x89\xcb\x33\x29\x78\x5a\x75\x1f\xa9\xc4\x1f\xf4\x50\x75\x03\xce\x32\x09\x4e\x42\xf7\x92\xbd\x23\x89\x30\x2e\x04\x93\x07\xe2\xec\xf7\x29\xa3\x59\x8c\xe0\x30\x11\x70\x21\xc9\xe8\x63\x2f\x30\xcf\x5b\x7d\x29\x70\x87\xe0\xa4\x54\xec\x48\x40\x33\x4c\xc1\xd9\xed\x53\x36\x66\xe0\xec\xf7\xde\x21\xa3\x3b\x16\xe7\x8a\x4e\x91\x2c\x56\xe4\xce\xca\x12\xb2\x39\xda\xce\x78\xf6\x5b\x0b\xb1\xc5\x35\xa4\x2b\xb7\x97\x91\xee\xf7\x36\x7d\x79\xf2\xd2\x00\x11\x6f\xd9\x3b\x2e\x90\xee\xc8\x33\x15\x82\x71\x01\x4e\x9e\xa3\x24\x53\x9a\xd1\x0d\xee\x50\x79\xa0\x90\xdc\xe3\xca\x9c\x42\xf2\x55\x41\xa2\x2d\x15\xfb\x15\x3c\xa4\x34\x7e\x7b\xe0\x1f\x8a\x48\x30\x3a\xe4\x12\x77\x64\x9e\xad\x39\x15\x09\x71\x92\x1d\xcb\x58\x2e\x8f\x7b\xf9\xc0\x45\x82\x82\x3c\x3e\xc3\x83\x60\xc9\x06\xc1\x75\xc9\x48\x6e\x51\xcc\x50\xfe\xe1\x07\xe0\x06\xe0\x2e\xed\x7b\x03\x5c\xba\x43\x41\xc1\x2d\x5d\xae\x9c\x49\x53\x70\xa9\xf0\x50\x62\xac\xa6\x72\xa9\xa4\xe9\x21\x97\x35\x41\xcc\xa1\x61\x80\x8b\x29\x0a\x35\x72\x2b\xf8\x0e\x63\xaa\x04\x52\xa4\x22\xa0\x7a\x23\x53\x16\x1f\x4a\x93\x04\xb8\x29\x8b\xdf\x94\x11\x08\x6e\xca\x8b\xe4\x3b\x1e\xc0\xe5\x29\x17\xe4\xa1\x48\xd7\xe0\xf2\xdd\x2e\x42\xa1\xa2\x45\x91\x34\x4b\x02\xae\xa6\xe3\x59\x86\xb1\x5c\x06\x11\x79\xc6\x35\x71\xa9\x48\xc0\x15\x3c\xcf\x7f\x70\xf1\x46\xa2\xf9\xac\x15\x17\x1e\x89\x50\x30\xcc\xc1\x73\xa3\xce\xc0\xea\x4d\x34\x31\xb4\x2d\xf0\x3c\x32\x8f\xc0\x9b\x39\xc4\x45\x1d\xb5\x5e\x34\x21\x21\x2f\x34\xf9\x32\x30\x0c\x07\x74\xc4\xcf\x67\x0b\x27\x00\x8f\x6d\x98\xa4\x29\x89\xd8\x46\xa9\x4e\x82\x94\x1e\x94\x20\xcb\x63\xfe\xde\x22\x0e\xe0\xf1\xd8\xa5\x3b\xd5\x14\x6a\xcf\xf4\xec\x02\xc1\x13\x3c\x43\x52\x3b\xcf\x2b\x04\x1d\xf3\x0c\x46\xa6\x35\x84\x51\x6f\x60\x1a\x55\xcc\x13\x0f\xdf\x59\x8c\x30\x72\xe7\x53\xd3\x30\x60\xe4\x85\x9d\xc7\xa1\x61\x55\x84\x0d\xa3\x69\x1f\x46\x41\x27\x3f\x1a\x36\x7a\x71\x8e\x5a\xa5\xc4\xc9\x68\x7a\xf8\x17\x05\x8c\xe8\x26\x45\x32\x3a\x20\xf1\x98\xd0\x0b\x12\xdf\x87\x51\xbc\xe5\x13\xf6\x8a\xe4\x89\xef\xb0\x3e\x62\xa3\x64\x83\x47\xb3\xc9\x8b\xee\x44\x3f\x98\x8c\xb7\x30\x4a\xf1\x5d\x47\x29\x8c\x76\x94\xa5\xcd\xa1\xab\x07\xfe\x53\xd0\x74\xc2\x37\x2c\x86\x91\x44\x91\x15\x39\xe8\x48\xca\x50\x92\x10\x53\x7a\xa8\x0e\x75\x8a\xe2\x33\x32\xe5\x49\x91\x22\x8c\x3e\x62\x4c\x53\x0a\x63\x9a\xcb\x1d\x15\x6f\x64\xda\x83\x31\x5b\xa3\x20\x33\x94\x7a\x37\x55\x54\x8f\x99\xc0\x3d\xff\x81\x42\x53\x3f\x68\x9a\xd6\x44\xc7\x84\x71\x8a\x1f\x2c\xa0\xf1\x1b\x4a\xf2\x54\xac\x61\xcc\x85\x64\x53\xca\xd2\x8a\x3a\x86\xda\xe3\x4b\x60\xde\x9b\x46\xd9\xf6\x8e\xad\x55\xb5\x03\xd5\x5a\x96\x61\xc0\x23\xdb\xd0\xd5\x72\x04\x4f\x9e\xf2\x52\x58\x64\xf0\xe4\xf9\x81\xda\xd1\xa7\xc5\xc0\x30\xf5\xd7\x52\x5f\xf3\xf8\xb5\xf5\xb7\xab\xbf\x03\x78\xc2\x94\x15\x3b\x32\x65\x19\x0a\x78\x62\x01\x0a\x52\x5e\x61\xa5\x8b\x75\xb0\x6a\xff\xb7\x9c\x73\xb2\x1f\xaa\x13\xf0\xa4\x6a\x89\x3a\xbd\xf0\x54\x20\x3c\x1d\xf6\x28\x94\xb5\xa4\x3c\x00\xe0\x3b\xad\x48\xf7\x9d\x29\xf8\x0f\x4f\x13\x96\xbd\x91\xa8\xff\xbf\xff\x81\x3f\x99\x4f\xc1\x9f\xba\xe0\x07\xa4\x3c\xcd\x7e\x40\x34\x5e\xae\x4d\x02\xc1\x95\x72\x5c\x80\x1f\x58\x7e\x08\x7e\x30\xf5\xc1\x5f\x85\xe0\x67\xaf\x2c\x53\x9b\x5d\x4a\xa2\x00\x3f\xcb\xa5\x40\x35\x0f\xf8\x99\xc4\x34\x65\x51\xca\x65\x73\x02\x15\x53\xef\x70\x40\x0f\x3a\xf0\x2b\x83\x1a\x60\xab\x8e\x80\xbe\xc5\xd5\x24\x39\x4b\x79\x46\xfc\x2c\x67\x9b\xad\xf4\xff\xaa\x18\xf3\x0c\xc7\x11\xfc\xd9\xd1\x8a\x06\x82\xc3\x9f\x43\xb3\xd7\x73\xe0\x4f\x94\xc7\x60\x86\xef\xa6\x61\x18\xf0\xfd\x39\xea\x98\x46\xd7\x9e\xc1\xf7\x97\xce\x2c\x3a\xf2\xf0\xd0\xbe\x2e\x4f\xbb\xa4\xbc\x4a\x26\xf3\xd0\x7f\x21\xf3\xd9\x08\x26\x34\x93\x2c\x7e\x23\xd7\xc3\x76\x82\x59\xcc\x05\x92\x88\x17\x59\x52\xce\x25\x88\x05\x53\x2f\x22\x43\xb5\xe2\xf4\xc2\xe9\x9b\xd2\x58\x29\x4e\xee\x46\x54\xa4\x07\x62\x19\xc6\xe0\xb7\x0b\xcc\x61\x8b\x39\xa1\x12\x89\x65\x98\xf6\x05\x5e\x5b\x6e\xca\x12\x35\xf4\xfe\x9c\xd5\x3f\x63\x99\xd6\x91\xa5\x02\xa8\x9e\xcd\x18\x5e\x60\x5e\x94\x34\xbb\x97\x98\x83\x36\x73\x6a\x7e\x23\x96\x61\x19\x27\xbc\x13\x75\xda\x3c\xf3\x82\x9c\x69\x6a\xde\x03\xe7\x6f\xe4\xce\xb4\x3b\x2c\x8b\xb7\xdf\xc8\x89\xfc\x11\x0b\x51\xb2\x8c\x7e\x23\xa6\x55\xca\x58\x86\xd9\xbf\x85\x57\x6e\x36\x7b\x3f\x25\x75\xdf\x48\x39\x4c\x90\x3b\xd3\xfc\x2c\xd1\xfd\x52\xa2\x77\x4d\xa2\x72\x9e\x71\x4d\xe0\xdc\x19\x17\x71\xeb\x33\x6e\x5f\x74\xc6\x19\x36\xb8\x86\x5d\x37\xce\xfe\x4a\x75\xfb\x0b\xd5\xed\xdb\xaa\x9f\x84\x4e\x8b\x6d\x69\xf6\x99\x34\x4b\x9a\xd8\x6d\xd8\xf5\x76\xde\xb0\xf5\x92\xcc\x4f\xcc\xd3\xd6\x4c\x9f\xa9\xf6\xf8\xfb\x6f\x64\xcc\x0b\x41\x16\xdb\x22\x4b\x50\xac\x79\x2a\x89\x4d\xf6\x5c\xc8\xfc\xf6\xa8\xc5\x0f\xfe\x1f\x07\xf5\x7f\x69\xa9\xfe\xaf\x2c\x35\xf8\xa5\xa5\x86\xbf\xb0\xd4\x2d\xe7\x5a\xc6\xaf\xa8\x61\x19\xff\x59\x8d\x2a\xb2\xcd\x6b\x02\xe7\x21\xfa\x09\x3f\x0b\xca\x4b\xb8\xf9\xc5\x78\xd3\xfa\x8c\x77\x1b\xab\xce\x74\xeb\xb5\xc2\xe9\x06\xd6\xbf\x81\x0d\x6e\x60\xc3\x6b\x58\xf5\xeb\x31\xf8\x42\xc0\xbc\xaa\xf1\x35\x6f\xf5\xbe\xf0\x56\xef\x0b\x6f\xdd\xdf\xd0\xfe\xfe\x86\x27\xfb\x9f\xae\x40\x63\x70\x4d\xe2\xaa\x69\xfd\x2f\x4c\xeb\xdf\x36\xed\xec\xd6\xa9\x6f\x63\xfb\xa7\x25\x7b\x5f\x49\x9e\x3e\x2c\x2e\x08\xf6\x7e\x7a\xf1\xde\x7f\x9d\xb2\xb4\x5b\xeb\xf8\xc6\x24\x27\x2f\xc4\xc3\x98\x27\xea\x89\x84\x09\xa3\xf5\x0b\x51\xf7\xda\x0f\xd3\xb4\x12\x89\x30\xcb\x11\xa6\xa8\xa6\xde\xb4\x9f\x76\x15\xab\x7c\xd3\x4d\x71\x43\x43\xc7\x85\x29\x0a\xfa\xc6\xaa\x14\x6a\x8a\x22\x2e\x84\x9a\x5f\x6c\x70\xaf\x6b\x08\x53\x95\x0b\x97\x63\x0e\x44\x2b\x3f\x61\xef\x08\x33\x73\x68\xda\x0e\x09\x54\x9a\x41\xa6\x28\x51\xc0\xac\x67\xf6\xad\x87\xea\x75\xd7\x94\x43\x66\xce\x14\x66\x4e\xd4\x1d\x0b\x44\x98\x8d\x57\x7e\x04\x33\x95\x1e\xc3\x2c\x54\xf9\xef\x6c\x11\x74\x2c\xfd\x0d\x1f\x3b\x66\xd7\xb0\x1e\x61\x86\x32\x60\xd9\x46\xb5\x51\x4c\x53\x14\xb5\xe5\x0d\x27\xf2\x5e\x4e\xb9\x02\x31\x53\xd4\x2a\x54\xdf\x35\xff\x50\x4d\x7e\x36\x5c\x67\x49\xe5\xf3\xbe\xea\x7e\x86\x67\x3c\xc1\xba\x73\xa1\x78\x32\xc3\x8f\x22\x27\xea\x05\xbd\x2a\xe9\x32\xbd\x9d\xf1\xf7\x22\x27\xcb\x20\x82\xf9\xeb\x2b\x8b\xb1\x4c\x3c\x48\x99\x22\xce\x33\x95\x70\xcd\xf7\x98\x1d\x77\xa6\x45\xd6\x22\x7b\xcc\x66\x8e\x0b\x73\xb1\x66\x64\xc7\x62\xc1\x35\x29\x49\x93\x94\x04\x2e\xf7\x03\x32\xca\x12\xbd\x43\xd5\xde\x05\x5e\x48\xa6\x03\xc3\x80\xc0\x77\xc6\x64\xc5\x84\x2c\x68\xda\xd2\x39\x98\x38\xff\x07\xc1\xc4\xed\xf4\x20\xa0\x82\x6e\x78\xd6\x31\x0d\xe3\x11\x82\x2d\x97\x3c\x63\x71\xa5\x40\x90\x16\x1b\x32\x2f\x64\xc2\xb9\x00\x55\x7e\x78\x56\xf9\xa3\xde\x67\x9d\xbb\x9e\x6c\xb9\xa6\x57\xb4\x48\x25\x31\xad\xee\x02\x02\xc1\x74\xa6\x96\xa6\x74\xcd\x8f\x35\x9b\xba\x76\xa1\x31\xb1\x39\x28\x42\xd7\x0f\x02\xc1\x73\xfa\xaa\x4a\x03\x45\x0e\x7f\x15\x34\xd1\x54\x53\xf0\x0a\xab\x52\x44\xb8\xb0\xcd\xc0\x82\x70\xb1\x84\x90\xc6\x6f\x24\xf0\x96\xc7\x14\x2a\xa4\x09\xe3\x13\x96\x7d\x40\x48\xf3\xfd\x1a\x85\x38\x90\x80\x41\x88\x34\x39\xcc\x9c\x08\x42\xd4\x35\x86\x63\x35\x2c\x44\x54\x59\x69\xc8\xdf\x0a\x08\x39\xdf\x11\x27\x45\x21\x35\xb9\xa6\x10\xf5\x89\xe7\x4c\xfc\x3a\x14\x22\xaf\xf3\xec\xcc\x20\x7a\x6a\x55\x39\xa2\x27\x7f\x34\xf1\x20\xf2\x1f\xa7\x0e\x89\xf6\x18\x4b\x51\xec\x88\x9f\xbd\x16\xb9\x32\xf4\x58\x47\x82\xc8\x0f\x9a\x59\x7c\xe5\x6b\xc9\x05\xa9\x33\xf1\x68\x62\xa9\x2a\x45\x34\x89\x20\x0a\x1c\xf5\x67\x9a\x96\x6e\x2c\xdd\x58\xb6\xe5\xa9\x76\x30\x30\x0c\xb5\xe9\x0b\x4c\x71\xbf\xe5\x59\x53\x47\x88\xc2\x00\xa2\xd5\x40\xcf\xb2\x1a\xea\x26\xa6\xd9\x58\xf0\x4c\x42\x14\x8b\x42\xb2\x8c\xa9\xb4\x4a\xdd\x05\x5c\x37\x52\x1c\xca\xed\xc5\x84\xb8\xde\x12\x74\x09\xa9\x4e\x18\xa3\x2d\x17\xb8\xc0\x14\x22\xb6\xd9\xd1\x3a\xd9\xb5\x20\x62\xaa\xf2\xb7\x52\xc1\x3f\xdf\x65\x2c\x92\x34\x7e\x83\x88\x49\x24\xa5\x67\x9b\xca\x54\xb4\xa3\x42\xaa\x6a\x08\xa9\x96\xe5\xaf\x32\x3f\x86\x54\xc4\xd3\x5c\xb2\x58\x67\xe9\x91\x8a\xb5\xe3\x86\xe8\x1c\xb1\x24\xf7\x5c\x42\x24\x91\xa6\x72\xfb\x83\xea\x31\x12\x31\xdd\x22\x4d\x20\x92\x5c\xa8\x03\xd2\x84\x72\x54\xac\x21\x2a\xb2\xec\xa0\x92\x69\x55\xf0\x88\x8a\x3d\x0a\xad\x1d\xb1\x4f\x3a\x2d\xb7\x37\x5c\xdf\x87\xe8\xb0\xdb\xa1\xf2\xca\x68\xd6\xb1\xbc\x07\x17\x8e\x7b\xd7\xbe\x30\x17\x8f\xfd\xc1\xf0\x9d\xc6\xa0\x36\x20\x10\x98\xa3\x5a\xba\xdd\x21\x53\x77\x09\x0b\xcc\xe8\x3a\x6d\x6b\x57\x71\x5c\x2e\x54\x47\x1e\x8f\x01\x2c\xf4\xc9\xa0\xfb\x5c\xd5\x71\x16\xbc\x88\xb7\xa7\x95\x05\x5d\x69\x5d\xba\xd3\x7b\xcb\xb0\xca\xb6\x5b\xb6\x03\x58\xba\x51\xed\xe9\xa5\x17\x81\xba\x61\x96\xd1\xa3\x65\x74\x56\xc1\x4c\x51\x5d\x43\x7d\xef\x0d\x58\x66\x6c\xcc\x88\x2e\x0e\x12\x55\x1d\x3c\xf6\x67\xab\xb0\xa4\xce\xaa\x52\xcb\x4c\x1d\xb6\x0d\x96\x44\x4e\x53\x72\xfa\x63\xb3\x7a\x10\x2a\xe7\x0f\xf1\x1d\x56\xc1\xac\x61\xa3\x60\xff\xf2\x8c\x8c\xd9\x3c\xaa\x0e\xc8\x8a\x25\xc8\xdb\xbf\x49\x2b\xb6\xe1\x02\xaa\xcb\xa8\xba\x0d\x2b\x4b\x2a\xfe\x42\x50\x75\x5b\x36\x7c\xce\x13\x78\x9e\x38\x33\xe2\x04\xf0\x3c\x0b\x2d\x55\x38\x28\xb7\xfa\x79\xcb\x24\xea\x42\x2d\x3c\x33\x81\xa9\x2a\x15\x79\x3c\x7e\x6b\x7a\x6a\x5c\x4b\x85\x9a\xaf\x2f\x8b\x56\xf7\xa8\xf2\xf3\x21\x47\x7d\x93\xc3\x8b\xeb\xc2\x4b\x59\xc5\x79\x10\x9c\x26\x6b\x9a\x25\x95\xd8\x8b\xfe\x0d\x3c\xad\x50\x9c\xf1\x86\x0d\xaf\x7a\xff\xdd\xd7\xac\xc7\x6e\x43\x91\x3b\x5d\x0a\x9a\x70\x9a\x34\x78\x0f\xfe\xc6\xb5\xa0\x33\x94\x44\xdf\x90\xe5\x2f\xf5\xdf\xba\x0c\x5a\x56\x25\x81\x32\xe1\x16\x6b\x04\xec\x44\x8b\xa5\xe7\xcf\x81\xb9\xf3\xe9\xa7\x20\x62\x9e\xfa\x65\x67\x93\xb9\xfa\x23\xb6\xfe\x76\x81\xa9\x52\xc2\x9d\x65\xfc\x61\x75\x2f\xbc\xe4\x4a\xd4\xfc\xfd\xf4\x51\x7c\xc6\x6e\x15\x40\xae\x62\xf6\x0d\xac\x77\x01\x6b\x9e\x7b\xd7\x20\xf3\x3a\xd4\xad\xa1\x33\xb3\x86\x67\x50\x99\xa1\x34\xd3\x7d\x7a\xb2\x1a\xc3\x6b\x88\x69\x5d\x45\xec\x33\xe4\xcc\xa0\xfe\x15\x73\x8e\x2f\x40\xd2\xfd\xfe\x8d\x5c\x71\xfd\x55\x89\xe1\x6d\x89\x33\x7f\x97\x62\x3d\x25\xd6\xbf\xbe\xd0\x67\x7c\x78\x1b\xb7\x8c\x5b\xf8\x4f\x29\xd1\x3c\x7a\x59\x59\x8f\xab\x89\x93\x01\xd5\x64\x4a\xe3\x80\x26\xe7\xff\x43\x3a\x72\x7b\x17\xb9\xf7\x17\xb9\xfd\x8b\xdc\xc1\x45\xee\xf0\x12\xd7\x61\xa2\x26\xce\xff\xdd\xd5\x20\xdd\x2b\x83\x89\x75\x24\x8f\xe9\x8f\x51\xee\xde\x6f\x6d\xa6\x79\x8d\xf5\x8d\x7c\xfe\xdf\xd8\x05\x11\x5b\x5c\x15\xb1\x7e\x1f\x9e\x4f\x5d\x31\x6f\x4e\xde\x08\xfd\xc4\xf4\xdf\xc8\x45\xe3\xb5\xd0\xf0\xf7\x7e\x5b\x05\x5d\xb8\xaf\xa9\x2b\x5b\x79\x84\xee\xaf\x42\x56\x8b\xb6\x5b\x74\x17\x98\x2e\xa7\x97\x0d\x31\xcd\x86\x3a\x06\x5d\xbb\x47\xa6\xf4\xa3\xe6\x58\xa4\xf7\xd8\xea\xa8\x8c\xe8\x94\xa3\x06\x9c\x31\xa6\xf4\xa3\xcd\xb4\x1b\xea\x64\x39\xfb\x6c\x39\xbb\x72\x84\xee\xda\x8f\x0d\x15\x55\x64\xb7\x26\xf2\x8a\xea\xd5\x44\x5c\x53\x35\x78\x5f\x13\xc7\xc7\x76\xd5\x6b\x51\x27\x40\xbf\x26\x4e\xd8\x83\x9a\x38\x61\x47\xa3\x86\x22\x77\xe5\xa5\x70\x64\xbc\xd4\x44\x58\x53\x51\x43\x95\x76\xf3\x84\xe8\x07\x11\xb9\x33\x73\xf9\x69\x5f\x1b\xec\x3c\x22\x1b\xcc\x16\xd7\xb1\xf3\xf8\x6b\xb0\xde\x0d\xec\xfe\x06\x76\x76\x73\x44\x0b\x27\x24\xcb\x54\x0a\x0a\xef\x65\x6e\xf7\xff\x03\x00\xba\x3a\x43\xff\x3a\x20\x00\x00"),
+		},
+		"/os_architecture.txt": &vfsgen۰FileInfo{
+			name:    "os_architecture.txt",
+			modTime: time.Date(2026, 10, 19, 1, 59, 13, 896921508, time.UTC),
+			content: []byte("\x41\x52\x4d\x0a\x41\x52\x4d\x36\x34\x0a\x41\x6c\x70\x68\x61\x0a\x4d\x49\x50\x53\x0a\x4d\x49\x50\x53\x36\x34\x0a\x50\x6f\x77\x65\x72\x50\x43\x0a\x53\x70\x61\x72\x63\x0a\x53\x79\x73\x74\x65\x6d\x2f\x36\x30\x30\x30\x0a\x78\x38\x36\x0a\x78\x38\x36\x5f\x36\x34\x0a"),
+		},
+		"/os_family.txt": &vfsgen۰CompressedFileInfo{
+			name:             "os_family.txt",
+			modTime:          time.Date(2026, 10, 19, 1, 59, 13, 896921508, time.UTC),
+			uncompressedSize: 2301,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\x55\xdd\x92\xb2\xba\x12\xbd\xef\xa7\xc8\x79\x00\x6b\x9c\xf3\xfd\xec\x9a\x4b\x44\x9d\x61\xb6\x08\x9b\x20\x52\xfb\x2e\x42\x8f\x76\x09\x09\x15\xc2\xe8\x7c\x4f\x7f\xaa\x03\xea\x9c\x0b\x93\xd5\x4d\x0c\xcd\x5a\xfd\xf3\xe3\xf9\xd7\x2f\x21\xd1\x12\xf6\xf0\xe3\xf9\xf7\x1d\xff\xfc\xfd\x32\x87\xbf\x7e\xcf\x6f\x76\xf0\xb4\x2b\x21\x88\x4a\x08\xb2\xbb\xab\xaa\xb0\x51\xd6\xef\x7d\x2f\x52\x43\xda\x41\x50\xab\xce\xd1\x27\x0a\x89\xd5\x60\xc9\x7d\x89\xa0\xeb\x1a\x52\xba\x42\x08\x3e\xa8\x22\x03\x01\xd9\xd4\x58\x07\xfc\x00\x05\x25\xd2\x23\xaa\x94\x23\xa3\x85\xbc\x90\xab\x4e\xb0\x48\xa4\x84\x85\xfa\xca\xfc\x2a\x9d\xaa\xce\xb0\x20\x27\x9d\xb1\x2d\x2c\x1a\x55\xe3\xfd\x24\x1b\x21\x6a\x87\x16\x16\xcd\x80\x22\x34\xca\xc1\xc2\x1a\x77\x42\x0b\x61\x7c\x0b\x37\x94\xe2\x79\x3e\x9f\xf3\x7e\xf3\x28\x97\x48\x08\x4f\x58\x9d\xa7\xe8\x3d\xee\x46\xd8\x28\x4b\x64\x34\x84\x0d\x55\x67\x79\x52\x16\xf9\xb0\x69\x8c\x15\x1b\xd5\xa3\x15\xa9\x25\xff\x52\xef\xfb\x67\x38\xe0\x88\xf6\xea\x93\x51\x7b\x51\x96\x77\xad\xb1\x72\xbb\x54\xde\xa0\xa5\x2b\x84\xa6\x23\xfe\xa3\xfd\xea\x9c\xe1\x4f\x42\x58\xbe\x32\xc1\x4b\xf9\x60\x7c\xa9\x9c\x12\xc9\x36\x0f\x52\x58\x62\xd3\x88\x0c\x5b\xe3\x50\x4c\x6c\x87\x46\x3b\x6b\x9a\x06\x2d\x2c\xb1\xa7\xa3\x7e\x47\x07\x4b\xfc\xa4\x8a\xa9\xb7\x9f\xec\xa7\x23\x39\xd5\x08\x89\xba\xfe\x66\xee\xb6\x51\xf9\x30\x34\x5d\x61\x69\xaa\xc1\x87\xee\x91\xff\x2c\x8f\x5a\xd4\x4e\x30\xb3\x1c\xdf\x17\x9f\x5c\x2d\x33\x58\x65\x12\x56\x25\xac\xda\x03\xd6\x35\xd6\xb0\x62\x12\x3a\x4b\x3d\x8a\x0d\xe9\xe1\x0a\x2b\x26\x5e\xa3\x13\x11\x3f\xf9\x50\x15\x3e\x5c\x99\x19\x1c\xe9\xe3\x4d\xbb\xd5\xd5\x71\x70\xb5\x90\x5f\xbd\xc3\xb6\x17\xa3\x63\x8b\x0e\xd6\x59\x94\xff\xfb\x9f\x85\xb9\x4e\x68\x6d\x34\xac\x25\xac\xcb\x1b\x3f\x6b\x42\xfb\x05\x6b\xb2\x78\x51\x4d\x33\x7b\x86\xb5\xb1\x6d\x7f\x57\x65\x6d\xac\xe3\xdc\x5a\x5b\xd5\xa2\x54\x4c\xc8\xda\x22\x2e\xe4\x12\x5e\xe9\xa8\x8a\xdd\x4a\xbc\x3d\x60\x1e\xc0\xab\x55\x7a\x29\x37\x41\x0c\x6f\xeb\x00\xde\xd2\xd9\xae\x84\xb7\xf4\x07\xa7\xcc\x1b\xa5\xca\x9d\xe0\xcd\xb4\x38\xa9\x08\x6f\xc6\xed\xc9\x22\x44\xb3\xb0\x51\x7d\x0f\xd1\x26\x89\x21\xba\x27\x5b\x94\x48\x88\x52\x56\xa9\x37\xcd\x3d\x55\xa3\x54\xfc\x5d\xc4\x10\xa5\x32\x4a\x78\x4d\x20\xca\xa2\x12\xa2\x56\x1d\xc9\x8c\x5b\xef\xa8\xea\x21\xd2\x1f\xa6\xf3\x3a\x30\x87\x47\xae\x23\x8f\x9a\x86\xde\xb9\x12\x22\xce\xa2\xc8\x1a\xbd\xe7\x24\x7b\x47\xb7\x24\xcb\x51\xbd\x0f\xda\xf4\xf0\xff\xe9\x39\x5a\xf2\x64\xdc\x08\x39\x51\x36\xa4\xcf\x8b\x81\x1a\xce\x8b\x51\xb4\x38\xdc\x4a\x11\xaa\x43\x83\x22\x36\x35\xb6\x10\xcb\x00\xe2\x1c\xe2\x12\xe2\x3b\xe5\xb1\xaa\x44\x22\xa7\x4d\x94\x10\x2b\x7b\x66\xad\x62\xe5\xaa\x93\xaf\xe9\x18\x8f\x2a\x0b\x42\x88\xd1\x52\x4d\x4a\x8b\x67\x88\x87\xc6\xd1\xc1\x5c\x47\xf0\x31\xe8\x8a\xeb\x1c\xb6\xe1\xed\xd6\xed\x2a\x81\x2d\x3a\x56\x66\x8b\x2e\x54\xd5\x09\x19\xfc\x33\xf8\x4d\x56\x8a\x93\x7c\x8b\xae\x50\xda\x29\x06\xfe\xa3\xb7\xe8\x4c\x47\xde\xee\x8c\x75\xab\x6b\x67\xb1\xef\xd9\xec\xef\xff\xb8\x18\x7b\xbe\xf3\x30\xd9\x89\x84\x24\xcd\xa3\x59\x5c\x42\x22\x9f\x7e\xce\xe7\x90\x7c\x7c\x50\x75\x17\x36\xe9\x50\x73\x28\xbc\x4f\x85\xc4\xb0\x88\xa5\xdf\xf7\x59\x0e\x49\xe7\xac\x82\x34\xd8\xce\x12\x09\x69\x54\x42\xba\x09\x21\x55\xd5\x19\xdd\x2c\xe9\x1c\x55\xaa\x81\x54\x35\x2d\x3f\x3d\x31\xe5\x90\x36\xc6\x5d\xd4\x27\xde\x3e\x39\x35\x17\xb4\xab\xfa\x88\x62\xd4\x57\x39\xac\x47\x67\xa1\x86\xc6\x81\x8f\x79\x7a\x7b\x6a\x0d\xff\xee\x7f\xb5\x26\x1c\xec\x27\x32\xd8\x90\xd2\x7c\xd8\x54\xde\x93\x45\x32\x64\x7d\xb2\x1c\xb2\x3c\x15\xfe\xbe\xef\x8d\x22\x53\xd5\x79\x61\xdc\x1f\xc8\x54\xe7\x8c\x05\x2e\x46\xbc\x6d\x89\x04\x19\x26\x63\x77\x90\xd1\xb8\xa6\x62\xec\x28\x1e\xbe\x2a\x87\x17\xf5\x05\x72\x1b\xa7\x33\x4e\x20\x90\x72\x33\x2b\xd2\x2d\xc8\xfc\x65\x3e\xbf\xf7\x2d\x99\xbf\xfc\xfe\x66\x15\xd9\x4f\x90\xea\x88\xdb\x55\x0e\xac\xa5\x9f\x04\xb2\xb2\x88\x9a\x5f\xc9\x53\x02\xc5\x4d\xab\xa9\xb9\x4d\xe5\x32\x3d\x9c\xc2\xe4\x16\xdc\x89\xc7\xf8\x19\xed\x47\x72\xca\x93\xb1\xf8\x8a\xca\x82\x34\xdc\xbb\x7b\x90\x46\x53\xc5\x2f\xe9\xb8\xef\xaa\xd0\xb4\x2d\x63\xac\x73\x33\xf0\xf5\xce\x78\x0d\x3c\x50\x47\xdc\x1b\x7b\xee\x41\x0e\x87\xbe\xb2\x74\x40\x7b\x8b\x8a\x7d\x3e\x2e\x1e\x4f\x3c\x42\x9e\x62\x90\x83\x8f\x7e\x8a\xd3\x37\x2f\x31\x5d\x03\xf9\x0f\x91\xa3\x6d\x49\xfb\x81\x06\x79\x20\xff\x56\xcd\x87\x82\x7c\x29\xff\xfa\x75\x27\x26\x47\xab\x6a\xaa\x08\x72\x6a\x51\x84\xaa\xeb\x87\x06\x21\xa7\xae\x23\x7d\x1c\xa7\x51\x6e\xb8\x45\x8f\x9c\x40\x6e\x15\xe7\xa9\xcf\xfd\x5d\x98\x48\xd8\x85\x12\x76\x4b\x09\x5e\xb1\x5d\xe3\x07\xcb\x4e\x4f\x8b\x3f\x56\x3c\xbf\xcc\x7f\xf1\xfa\x3c\x87\x22\x2c\xa1\x78\xfd\xef\x7c\x0e\x45\xcc\x93\x49\xac\x64\xf9\xb4\x92\x25\x01\x2b\x58\x64\x29\xf8\xf2\x3a\x22\x14\xca\x92\xf1\xfa\x7a\x34\x8e\x83\x82\x8e\xc6\x42\x61\xa2\x14\x8a\xeb\x48\xd4\x7e\x23\x57\xc0\xc3\x2e\x34\x16\x61\x4f\xdc\xaa\xf7\x54\xa3\xe0\x3e\xac\xdc\x77\x7c\xaf\xc1\x3d\xe9\xda\x5c\x7a\xe0\x1b\xa6\xc1\xf2\x80\x82\x33\xbd\x0c\xa1\xf4\x9d\xa4\x4c\x7d\x3d\xff\x8b\x07\xab\xb8\xc7\xa8\xa1\x26\x93\x48\x38\xd0\x9f\xd3\x70\x00\x9c\xc9\x7c\xb7\x8c\x12\xa0\x4d\x02\xdc\xe7\x29\xbd\x67\x06\x3d\x50\xab\x8e\x18\x6e\x02\x29\xc1\x74\x8e\x46\x5a\xdd\x67\x22\xe1\xcf\x53\x22\xe1\x7f\x03\x00\xaf\x43\x38\xfb\xfd\x08\x00\x00"),
+		},
+		"/os_product.txt": &vfsgen۰CompressedFileInfo{
+			name:             "os_product.txt",
+			modTime:          time.Date(2026, 10, 19, 1, 59, 13, 896921508, time.UTC),
+			uncompressedSize: 4762,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x58\x5b\x97\xaa\x3a\x12\x7e\xaf\x5f\x91\x79\x9b\x59\x33\xce\x06\xb5\xdd\xdd\x8f\x08\xb6\xcd\xd9\x22\x1c\x82\x97\xb5\xdf\x22\x54\x6b\xa6\x21\x71\x42\xe8\xd6\xf3\xeb\x67\x25\xa0\x62\xef\x3e\x6b\xce\x83\xa4\xea\x4b\xe5\x56\xb7\x54\x74\x9d\xf9\x8e\x4c\x4b\x56\x20\xa1\x1f\x5c\xe7\x07\x70\x1f\x5d\x07\x46\xee\xc3\x03\x8c\xdc\xc9\x03\x8c\x9d\x07\x07\xc6\x93\x27\xf3\x39\x9d\x60\x32\x76\x1c\x12\x31\xad\xf8\x89\x24\x8a\x0b\x8d\x0a\xbe\x3f\x38\xf0\x7d\xe2\x82\x17\xd0\x05\x89\x64\x81\x15\x78\xe1\x16\xbc\x28\x00\x2f\xa6\xe0\x65\xb3\x25\x59\x70\xd1\x9c\xc0\xcb\x73\xac\x6b\x32\x67\x1a\x3f\xd8\xb9\x63\xd3\x46\x08\x54\xc4\x8e\x56\xb2\x31\x33\x7a\x05\x3b\x6a\xfe\x8e\x84\x62\xde\x28\xae\xcf\xc4\x3b\x1e\x4b\xce\x44\x8e\xe0\x71\x95\x48\xa5\xc9\x94\xd5\x48\xa8\x66\x9a\x4b\x41\x9e\xb9\xaa\x3e\x98\xba\xf5\xce\x4e\x5a\x61\x85\xe0\x95\x25\xc7\x82\x64\x58\x62\x7d\x16\xd7\xf9\x45\xa1\x24\x2f\xe0\x36\xeb\x94\x06\xdf\x62\x0a\xfd\x49\xa1\x53\xcb\xb9\xd6\x58\xb5\x8c\x8f\xe6\xc0\xc4\x2b\xde\xcd\xa0\x82\x44\x4c\xb0\x3d\x56\x28\xb4\x39\x77\x53\xe2\x9d\xd8\x9f\xf4\xb6\x13\xd6\x30\x55\x52\x1f\x50\x5d\xd5\x38\x6d\x6a\x2e\x8c\x7a\x12\x59\xf2\xfc\xdc\xd9\x83\x0c\x1d\xc7\x01\xff\xfb\xc4\x01\x9f\x12\xd7\x71\x1c\xe2\xb3\xb2\x24\x14\xd5\x3b\xaa\x2b\x46\xf9\x5e\xb0\x92\x8b\xfd\xe7\x8e\xb5\xe4\x39\x5e\x34\x4e\x22\x2c\x38\x23\x3e\x53\x05\xf8\x5b\x12\x30\xcd\x88\x2f\xcb\x12\x73\xab\xc5\x0c\x55\xc5\x05\x2b\xc1\x67\xff\x69\xc4\xc5\x21\xee\x96\x63\x3a\xa6\xe0\x63\x89\x4a\x31\x30\xe7\x34\xec\x01\xf3\xb7\xa3\xe4\x42\x93\xe7\x8d\x0b\xfe\x41\xc9\x0a\x89\xe9\x28\x65\x53\xfc\xc0\x33\xf8\x72\x2a\x4f\xe0\xcb\x1d\x2b\x35\x49\xd9\xef\xe0\xcb\xaa\x62\xa2\x48\x64\xad\x0d\x6d\x4d\xe7\x4b\x21\x30\xd7\xab\x84\x82\xdf\x54\x4d\xd9\xd4\x9d\xd7\x04\xc3\x80\x4c\x59\xfe\xd6\x1c\x2f\xc6\x08\x7c\x3a\x18\xba\x8e\x03\x41\x60\xd6\x09\x82\xc1\x26\xcd\x20\x98\xf9\x75\xbb\xcd\x60\xfe\x6d\xb5\x85\x60\xe9\x91\xd6\x14\x10\xd0\x89\x03\x01\x5d\x78\x11\xa1\x07\x2c\x5f\x21\xa0\x11\xd8\xe3\xc7\xcb\xcc\x4b\x20\xc0\x57\x2e\x8c\x9f\xc5\x02\x21\xc0\xb2\x24\x29\x56\x52\x23\xe9\x3c\xd6\x97\x42\x2b\xa3\x28\x05\x01\xdf\x73\xcd\x4a\xe2\xcb\x23\xef\xb1\x2b\xc1\x4f\x96\x69\xaa\x9b\x37\x06\xbc\xce\xa5\xdd\x90\xcc\x1b\xeb\x08\x66\x3f\xa6\xe3\x6c\xc4\x67\x41\x4a\xe6\x4f\xce\xf0\x36\xa0\x43\x46\x3d\xa4\xd8\xe3\xb4\x64\xb5\x39\x85\xa1\x63\x0a\xb3\x8a\xf1\xb2\x17\x11\x33\x73\xc4\xa3\xe2\x35\x12\x2f\xe9\x73\xad\xfa\x7a\xc0\xc6\xf4\xff\xb7\x61\xe5\x42\xee\x79\x0e\xb3\x53\x8e\x65\xc9\xe0\x39\x0d\xb3\x9f\x7f\x8b\x29\x3c\xb3\x9d\xe2\x39\xb1\x54\xad\x2b\xa6\xde\x48\xf4\x00\xcf\xec\x94\x28\xac\x6b\x78\xc6\x42\x2a\xd6\x35\xc4\x97\x0a\xe1\x19\x95\x39\x97\x62\xf9\x1b\x3c\x73\x54\xe7\xd6\x9d\x2f\xfe\xf2\xcc\x15\x1e\xe5\x47\x47\x7d\xb0\xb2\x1c\xb8\x1d\x69\x06\x4b\xa5\xb9\x59\x4c\x21\x4e\x69\x60\xdb\xa5\x47\x6f\x87\x37\xc0\x4e\x9e\x48\x2b\x53\x1f\x32\x59\x31\x2d\x61\x3e\x34\x06\xa9\x65\x79\x4d\x5a\x73\x8f\x7b\x46\x6a\xbe\x4d\xdc\x89\xeb\xdc\x66\x68\x81\x87\x4f\xc0\xf0\x17\xe0\xf1\x0e\x18\x0e\x9d\xfe\x1c\x7c\xcf\xd6\xab\x19\x79\x09\xae\x64\xe6\xb9\x30\x6f\x98\x2a\x38\x13\x31\x85\x97\x64\xb0\xda\xc2\x4b\xf6\xe8\xb8\xb7\x61\x86\x1d\xde\xb1\xee\x27\x76\x74\xcf\x8e\xef\xd9\xde\x96\x5e\x78\xc2\xf4\x81\x8c\x4c\x2a\x78\xe1\x47\xa3\x5c\x78\x39\x17\x8a\x41\x18\x24\x10\x2e\xe2\x08\xc2\xc8\x87\x30\xa6\x10\x26\x29\xb2\xfc\x00\x61\x42\x63\x08\xd3\x70\x0b\xa1\xd0\xb8\x57\x4c\x63\x41\x16\x7c\x7f\xd0\x35\x89\x1b\xdd\x25\x27\x05\xa1\xe2\x27\x08\x6b\x5e\x4a\x61\x9c\xff\x99\x1a\x35\xfe\x86\x3a\xe0\x0a\x73\x0d\xbf\x35\x42\xd6\x06\xfa\xc1\x2a\xfe\xc6\xfe\x40\xf8\x81\x28\x50\xf3\x3c\xa6\xb0\x88\xd3\x70\x6b\x3a\x17\x5b\x68\x7d\xcd\x7e\x89\x17\x85\x1d\xd5\xf3\xbd\x00\xeb\x37\x2d\x8f\xbf\x76\x74\xae\x12\x05\x94\x3c\x99\x03\x46\xc9\x8c\x6c\x17\x10\x25\x5b\xd7\x71\x08\xa7\x3e\x0d\xc9\x54\xf1\x62\x8f\x10\xd1\x05\xc9\xd8\xd1\x78\xf6\x4e\x31\x75\x86\x88\x59\x6f\x6d\x1b\xb2\xbd\x12\xd7\x49\x6f\x29\x38\x51\xd2\x84\xb2\x54\xd0\x26\xc1\x8b\x04\xa2\x36\x69\xf3\x26\x09\x11\xee\x59\xea\xf9\x10\xa1\xda\xa3\xcd\x6b\x86\xe4\xc6\xd6\xc4\xbd\xcb\xbe\x91\x14\x7b\x29\x6b\x9b\xec\x96\x5e\x04\x4b\x8f\x8e\x8d\xcf\xc2\x72\x16\xdf\x6f\x74\x39\xcb\xfe\x69\x84\x9e\xd7\x21\x85\x65\x3a\xbf\x66\xfd\x65\x06\xcb\x2c\x19\xf4\x3c\xc3\xb0\xe9\x7c\xe0\x8e\x9d\xe1\xbc\x87\x6e\x07\x66\x3c\x6a\x13\x28\x4b\xd4\x3e\xcb\x0f\x68\x88\xc4\xec\xfe\x26\x86\xfa\xf7\xc6\x36\x34\x67\x26\x5d\x5d\xa9\xeb\xad\x7b\x43\x68\xb0\xed\xa3\x6b\x26\x34\x33\xc4\xa6\x9b\xa9\xbe\x1b\x78\xb7\xc8\x87\x54\x6f\xfd\xb1\x96\xa7\x39\x33\x57\xf9\x8d\xd7\x52\xb1\x3d\x92\xb4\xbd\x75\x63\xfa\x6d\xec\x38\x5d\x43\xfe\x1e\x4e\x23\xc2\xff\x01\x31\x7d\xfe\xe6\x42\x4c\x69\x18\x41\xfc\xfa\xca\x73\xec\x2e\x02\xe2\xb3\x5d\x89\x5d\x41\x11\x8b\x9d\x64\xaa\x20\x5e\x51\x71\xc1\x6b\xad\x98\x96\x0a\xac\xc3\x42\x2c\xce\x27\x88\x8f\x28\x8c\x6a\x4c\x6b\x0d\xbc\x66\x4d\xa9\x2d\xdb\x19\xcb\x90\xd9\xda\x36\xeb\x88\xda\x76\xc3\xca\x12\x12\x6f\x69\x54\x9b\x84\x5b\x48\x16\xfe\xe0\x01\x92\x34\xfe\x66\x2e\x97\x84\xe5\x6f\xa8\xe9\x81\x1d\x51\x41\xc2\xca\xca\x48\x1d\xa4\x96\xa2\xcb\xac\x89\x34\x02\x89\x92\x90\xc8\xf2\x9c\xcb\x0a\x12\x93\xeb\xda\x95\x4d\xca\xd4\x7c\x8f\x64\x32\x1e\xa6\x03\x77\xd4\x07\x1e\x7a\xcc\x83\x93\x0e\xb2\x7e\xef\xc4\x79\xd9\x0c\x26\xee\x67\x24\xb8\x47\xa2\x59\x2b\xc3\x2b\xb4\x57\x38\xdb\x49\xd5\xd6\x42\x97\xc8\xbe\x4b\xc4\x17\x77\xeb\x5a\x32\x35\xda\x84\x44\xc9\x05\x67\xc2\x6c\x56\xd6\xec\x15\x6f\x26\x4e\x94\x3c\x55\xf2\x04\x49\x53\xd6\x48\x2e\x16\xb1\xe5\x18\xc2\xef\x19\x85\xd4\xa3\xb3\xd3\xd1\x5e\x0b\x69\xf0\x03\xd2\x90\xfa\x26\x0a\xd2\x0c\xd2\x6c\xe4\x26\x3d\x7f\x4e\xd9\xd1\xd8\x2a\x45\x7b\x15\x26\x36\x9e\x52\x2c\x5e\x98\xee\x67\x01\x8f\x7e\x01\x6e\x28\xa4\xe6\x82\xe8\x1c\xa8\x6d\x62\x0a\xd4\x8f\x2e\xc7\x24\x55\x5b\x5a\x51\x3f\x26\xab\x65\xb8\x05\x3a\x7b\x09\x81\x86\xf3\xc8\x23\xf4\x88\xb9\x56\x4d\x45\x42\xf1\xda\xd4\x46\x37\x6d\xfd\x70\xdb\x1b\x0d\xed\x90\x85\x29\x26\x7a\x68\xd4\x0b\x3c\x9a\x78\xae\x3b\xbc\xe7\x87\xf7\xfc\x70\x34\x0c\xee\x80\xc7\x47\xc7\x21\x8f\x03\x5b\x89\x86\x89\xad\x3e\x8f\x07\x29\xce\xbf\x86\x12\x5d\x3f\xde\x2f\xbd\x7e\xfa\xc4\xa7\x60\xc2\xea\x59\x49\xa1\x81\xe6\x0a\xd1\x5c\x38\xad\x21\x3a\x37\x6c\x19\x8b\x2a\x34\x55\x0c\xd0\x8a\x29\x6d\xaa\x05\x63\x12\xcb\x74\x6e\x40\x2b\x29\xf5\xc1\xba\x3d\x95\x25\x53\xbc\x06\x2a\x85\x4d\xe6\x54\x36\xa2\xc8\x64\x93\x1f\xe0\xa2\xb7\xe1\x98\xcc\x4c\x89\x2a\x50\x5f\x0a\xa1\xd6\x7c\xf4\x88\x78\x91\xd5\xc8\x4a\x7d\xf8\x60\xda\x32\x6d\xc0\x7b\x4a\xb1\x33\xd0\xc6\x8e\x31\x7a\x37\xa5\xe8\xb7\xe8\x2e\x77\xd2\xc6\x1e\xa4\x39\xa2\xa2\x9a\xe5\x6f\x64\x74\xc7\x90\x4b\xc1\xd0\x47\xc3\x10\x68\xa3\xd0\x2c\x83\xf7\xf9\x95\x7e\x98\xc9\xda\x62\x20\x8b\x28\xf9\xa3\xbc\xd4\xdc\x59\xba\x9a\x8c\xc1\x0a\x5f\x2a\xef\x8c\xd5\x15\x13\xa4\x4b\x53\xf5\xe5\x55\x60\xcc\x64\x42\x0c\x4d\x51\x95\xa1\xb0\xe9\xc7\x56\x39\x19\xea\x36\xb8\x62\x0a\x19\xaf\xda\x2f\x12\x9f\x1d\xeb\xa6\xec\x45\x4d\x57\x9f\xb4\xcd\x8a\x4e\x21\x53\xec\x1d\x55\x8d\x90\xa9\x66\x32\x6e\x8b\xc4\x95\x1f\x4d\x86\xfd\xca\xa0\x05\xc6\x9f\x81\xc7\x3e\x40\x49\x80\xef\x3c\x47\xb0\x3e\xbe\xa2\xf3\xa1\x33\x58\x27\x4b\xf2\x7a\x15\xa1\xf3\xb1\x73\xc7\x4e\xfa\xec\xae\x11\xba\xe9\xfc\x65\x55\x9a\xe7\x1b\xac\x04\x7f\x35\x6f\x23\x1a\x26\x24\x39\x48\x81\x64\xf4\xd4\x77\x3d\xb3\x59\x7b\x13\xac\xa7\x8a\xe7\x6f\x24\xc5\x77\x58\x87\x41\x4c\x07\xcb\x75\x0a\x6b\x53\x6c\x98\x3c\xba\x8e\x8c\x34\x99\xd1\xeb\xa5\x7b\x43\xf8\x15\x4a\x96\xb6\x7a\x31\x99\x24\xb7\xf5\xa2\x49\x09\xeb\x34\x81\x35\x57\xba\x61\xe5\xd5\x90\x6b\x19\x26\xb0\x3e\x6d\x8c\x61\xc0\xd4\xab\x9b\x69\x3a\x74\xc6\x73\xd8\xb0\x77\xb4\xc6\xd8\xe0\xce\xa7\x2b\xd8\x70\x51\xc8\x8f\xfa\xd2\x12\xd7\xe9\x91\x24\x92\x3b\x5e\x62\x1f\x91\x8a\x5c\xb8\x76\x53\x64\xe8\xb8\x93\xab\x84\x7d\x66\xf5\x19\xfb\x3a\xca\xdb\x97\x5c\x77\x8a\xbb\xee\x4f\xd8\xf7\x1b\xf5\xe5\x52\xce\x23\x49\x87\x57\x99\xc7\x1b\xf5\xf5\xc6\x7a\xa2\xff\x76\xfb\xf4\x9f\x88\xf7\x27\x7f\x7a\xb8\x92\xfe\xec\x4a\x2e\xb3\x1e\xf9\x79\xf7\xcb\x8c\x58\x8d\x77\x2f\xdf\x0b\x6c\xdd\x02\x7e\x3d\x8b\xf3\x05\x36\xfa\x0a\xeb\x6f\xab\x07\xff\xab\xaf\xdc\x59\xc1\xef\x16\xed\xa9\xec\x2b\xec\x2f\x0e\xfd\x7a\x65\x03\xff\xb5\xc5\xdd\x21\xfc\x1f\x35\x7f\xe5\x45\x37\xec\xe9\x8a\xad\x79\xad\xd9\x95\xdb\x26\xb0\xe1\x0a\xcb\x4f\xaf\xca\x2b\xb6\xf0\x96\x77\xb8\x54\x6f\xed\x9b\xd1\x14\xb0\xb0\x1d\x21\x19\xb9\xfe\x20\x82\xad\xef\x77\xe1\xbc\x8d\x29\x6c\xaf\x55\xce\x4f\xdc\x29\xb6\x44\xdd\x16\x98\xdd\x7e\xae\x31\xfd\x13\x85\x3e\xb3\x12\x7e\x9a\x70\x6f\x4b\x7c\xa9\xe0\xe7\x79\x19\xd3\x5b\xb2\x60\x4d\xc1\x65\x4c\x01\x07\x34\x5b\x05\x61\x0c\xe8\xcb\x1a\xf8\x83\xf9\x83\x84\x07\xa9\x77\x59\x98\x2f\x62\xf3\x23\x43\xfb\x1d\xd9\xef\x18\xcc\x7d\xcd\x6d\x81\x09\xbc\x02\x7e\x8c\x29\xe8\xf7\x98\xc2\x7b\x7b\x61\xc3\x3b\x3d\x1e\x50\x61\xff\xdf\x11\xaf\xae\x8d\x8e\x84\x86\x0f\xdc\xc5\x14\xfe\x30\x2b\xfd\x6f\x00\x28\xd8\xf2\x83\x9a\x12\x00\x00"),
+		},
+		"/service_family.txt": &vfsgen۰CompressedFileInfo{
+			name:             "service_family.txt",
+			modTime:          time.Date(2026, 10, 19, 1, 59, 13, 896921508, time.UTC),
+			uncompressedSize: 2690,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x56\x4f\xb3\xe2\xb8\x11\xbf\xf7\xa7\x70\x6e\xc9\x81\xad\x4d\xed\x56\xaa\x72\x34\x36\x3c\x78\x83\x41\x83\x0c\x6c\xbd\xcb\x96\x6c\x37\xb6\xf2\x64\xb5\xa7\x25\x03\xde\x4f\x9f\x92\x0d\x0c\x93\xd4\x1c\x40\x3f\x77\xcb\xad\xee\x5f\xff\x91\x7f\xd9\x2e\x72\x88\x77\x12\x62\x71\x84\x58\x66\x10\x17\x83\x73\xd1\x09\x8b\x48\x22\x5f\x90\x21\x2e\xbd\xbe\x60\xf6\x15\x62\xe3\x91\x2c\xc4\xd6\xeb\x8b\xe6\xde\x45\x67\xe2\xe8\x4d\x79\xbc\xaa\xc1\x41\xdc\xa9\xb2\x41\x88\xbb\xce\xa0\x6c\x14\x63\xb4\x16\x51\xa6\xb4\x79\xda\xe9\x3a\xa3\x4b\xe5\x35\xd9\x48\x30\x79\x2c\x47\x28\x07\xe7\xb1\x0d\xda\x2b\x16\x10\x7b\xa3\x5c\x14\xdb\xb2\x21\x86\xb8\x67\x05\xf1\x5f\x3d\x23\xcc\xd7\x6f\xb3\xb5\x80\xf9\x7a\x9b\xc2\xfc\xec\xbb\x0a\xe6\xba\x3e\x13\xf9\x68\xd1\x86\x33\x72\x22\xe3\x20\x49\x04\xd3\x6d\x80\x24\x93\xe1\xf7\xfb\xaf\xbf\x8e\xe1\x25\xfb\x0c\x92\x83\x90\x90\x34\x58\x7e\x46\x82\xb4\xf5\x01\x33\x0f\x62\x80\xc4\xa0\xb2\x73\xa6\xab\xd3\xb6\x86\xc4\x50\x5f\x4d\xff\x4b\xa3\x18\xef\x90\x29\xbc\x42\xa6\x5a\xf6\x4e\x93\x85\x84\xda\x16\xb9\xc4\x47\x70\x09\xb5\x9d\xfa\x16\xad\xf2\x5c\x7c\x17\x59\x8b\xa5\x7f\xac\xe3\xf9\x64\x3d\x5a\xff\xb2\xe3\xf9\xa8\x83\xad\xab\xf6\x65\x03\x09\xf5\xac\x91\xa3\x2c\x8f\x21\x19\x02\xd1\x01\xa5\x5b\x09\xa9\xcc\x20\x95\x47\x8d\x57\x48\xd5\x45\x57\x90\xa2\xfb\xf4\xd4\x45\x71\xef\x1b\x62\xed\x07\x48\xb5\xfb\x74\x7e\x64\x19\x52\xeb\x5a\xe5\xbe\x41\x4a\x17\x2c\xc9\x43\xca\xd4\x15\xa8\x18\xd2\xc1\xaa\x96\x60\x31\x6b\x1f\xf9\xd1\x25\x3a\x58\x9c\x24\x2c\x4a\x34\x18\x2c\xfd\x90\xbb\x45\x7b\xc2\x02\x16\xf7\xed\x65\x3f\x1e\xb5\x68\x0b\xac\x2a\xac\x22\x29\x57\xcf\x8d\xb6\xea\x02\xbd\xaf\x19\xce\x94\x55\x75\xd0\xdd\xca\x46\xd9\xfa\xc9\xd9\x32\x0f\xc5\x03\xcb\xef\x94\x2d\x4f\xf9\x17\x58\x2a\xe7\x73\x56\xe5\xe7\x53\xaa\x0d\x7e\x68\x63\x54\xb4\xcc\x05\x2c\x35\xe3\x55\x19\x33\xfb\x27\x2c\x89\x3d\xa3\x73\x2f\xe7\x2f\x19\xa5\x5c\xc1\xdb\x36\xce\xa3\x39\xdd\xe0\x5e\x9e\xf0\x86\x16\xff\x05\x6f\x14\x37\xa8\xaa\x50\xdd\x6e\xda\xff\x46\x54\x1b\x7c\x2d\xf7\x37\xa6\xbe\x3b\x69\x87\xb0\x8a\xa7\x6a\x7a\x4d\xea\x0a\x8d\xbe\x3d\x1e\xd6\xf3\x2c\x4a\xa9\xd5\x96\x60\xbd\x96\xb0\x7e\x65\x6c\xbd\x93\xb0\x16\xb3\x74\x91\xe4\xd1\x5c\x39\x8c\xe4\x3d\x29\x0f\xe1\xc3\xb3\xb5\xf5\x58\xb3\xf2\x58\x45\x1b\x5d\x37\xde\x45\xbb\xde\x3f\x39\x0b\x5a\xf3\xf7\xfd\x3f\xa2\xa9\x0b\xef\xf2\x36\x54\x4d\x8e\x65\x63\xc9\x50\x3d\xd9\x60\x8b\xfe\x9e\xb3\x52\x59\x8b\xfc\xbf\xd2\xbb\x5f\xff\x27\x1c\x53\xbf\xb6\x9e\x95\xf4\xc4\x08\xef\x73\x72\x0e\xde\x93\x59\x88\x3b\x0d\x40\xde\xd1\xbe\xb7\xf0\x1e\x5e\x81\x77\x75\x51\xf7\xce\x8d\x5e\xfb\xfa\x7e\xc8\xab\x3a\x50\x3b\xf2\xf8\x33\xe5\x43\x8c\x3e\xd5\x1c\x1a\xe6\x1d\xbd\x1f\xe0\x9d\xa8\xfd\xdb\x52\xbb\x06\xbe\x58\xf2\xf0\xa5\x2f\x46\xbf\xd1\xc1\x46\x3b\xff\xe0\x67\x43\xbe\x77\x8f\x24\x4c\x0f\x8b\x5b\x87\x95\xf6\xc4\x90\xc5\xeb\x8d\xbb\x22\x76\xc8\x90\xa5\x0a\x5b\xb2\x90\x2d\xf6\xc9\x61\x0f\xd9\x22\x8f\xa3\xb5\x80\x6c\x77\x5c\x68\x1f\xa5\xd9\x07\x64\x62\xf1\xf6\x7b\x94\x1e\xf7\x90\xe5\xf0\x4a\x59\xc0\xb3\x4c\xdd\x46\x20\xb5\x47\x78\xc9\x42\x5c\xa3\xf5\x90\x21\x97\x3d\xdf\x7b\x26\x67\x65\x5d\x47\xec\xef\x51\x42\x86\xce\xa9\x5a\xdb\xfa\x69\x91\x6c\xcd\x68\x20\xeb\x8d\xd7\x5b\xf4\x13\x28\x59\x9d\x3d\x64\x83\xfc\xba\x81\xad\x4c\x61\x9b\x07\x73\xb0\xcd\x05\x6c\xb1\x43\xeb\x1b\x74\xb0\x45\x9f\x8c\x83\x76\x8b\x5e\x96\xca\x20\x4f\x88\x11\x6d\x40\x63\xe7\x4c\xc2\xa3\xb2\x5e\x05\x70\x0a\xd3\x78\x11\x12\xdf\xb1\x76\x3f\x54\xfb\x43\xfb\x5a\xe3\x3f\xca\xbc\x2a\x3f\x47\x11\xaa\xbb\xd2\x3d\x8f\xbd\x12\x7f\x46\x82\x75\x30\xfd\x2c\xd9\xad\x56\xb5\x62\x05\xbb\x0e\x6d\x5c\x49\xcf\xa8\xda\xf1\x41\x66\xa1\x8a\x46\x24\x57\xe3\x7a\xcc\xe4\xb4\x86\x89\xb6\x63\x55\x1a\xbc\x2f\xb1\x84\xdd\x5f\x43\xab\x6c\x18\x7b\x62\xfe\x07\x88\x95\x00\x71\x92\x20\x1a\x55\x70\x28\x37\x62\x10\x7a\xd6\x90\x41\x10\xe4\xfc\x2f\xbb\xf3\x59\x97\x13\x3e\xeb\x1b\x08\xba\x22\x8f\x2f\x07\x10\x06\xa8\x60\x0a\xbf\xa4\xe7\x0b\x06\xb0\x0c\xde\x08\xa6\x8d\x56\xd6\xc3\xd4\xea\xa2\x67\x9c\x2d\x73\x51\x81\x18\x7c\x43\x16\xbe\x76\xd4\x85\xf2\xf9\xda\xab\xea\xdf\xb0\xcf\x61\x8f\x67\x33\xcd\x35\xd8\x63\x4b\x1e\xa3\xb8\x2c\xd1\xb9\x28\x0c\x73\x26\x13\x98\x99\x14\x63\x50\x7b\x74\xda\x82\x5c\x3f\xc9\x95\x6b\x21\x24\xc8\xcd\x98\x5a\x99\xad\x20\x70\x11\x06\x58\x1e\xac\xaa\xe7\x36\xb9\x99\x1d\xc5\x16\xa4\xaa\x31\xfa\xe3\xb7\x48\x0e\xac\xca\xfe\xc7\xdc\x49\xd5\x16\x0a\xc6\x71\xfc\xf4\xe2\x31\x55\xee\xd2\x97\xd9\x2a\xd1\x56\xed\x78\x28\x5a\xaf\x2d\x06\xc0\x97\xd9\x01\x64\x5b\x8c\x54\x48\xea\x6d\x35\xdd\x8d\xb2\x43\xac\x72\xea\xcb\x06\xe4\xb7\x5e\x57\x20\x7b\x6b\x07\x98\xca\x79\x51\xd5\x08\xf9\x5c\xbe\x1a\xcf\xd1\xd6\xda\x22\xe4\x8d\xb6\x90\xeb\xae\xd3\xb6\x9e\x4c\xe5\xfa\x42\x46\x43\x4e\x6d\xa9\x3c\xe4\xc4\x56\x55\x04\x39\x2b\x3c\xeb\x4f\xc8\xaf\xda\x79\xac\x1e\x6b\x88\x0e\x0e\xc2\x0a\x38\xe4\x19\x1c\x8c\x67\x15\x52\x78\xb0\x45\xf0\x0d\x0e\x56\x9f\x75\xb8\x6f\xee\x37\xd0\x33\xda\x03\x97\xe1\xe0\x63\x06\xc7\x9d\x18\x99\x3d\x8a\x9d\xf8\x0d\x8e\x7b\x01\x47\xd9\xa0\x31\x10\x9a\x41\x13\x1c\x15\xdb\x30\x53\x8e\xba\xb6\xe8\x3d\xc2\x91\xb4\x78\xda\x39\xc9\x3f\xc3\x15\x73\xc2\x9d\x35\x43\x4a\x30\xce\xae\xed\x23\xc8\x07\xf5\xe3\xc0\x3c\x61\xf1\x76\x58\x87\x65\x43\xb5\x2e\x03\xf8\xbe\x4d\x36\x1a\x4d\x35\xa2\xae\x41\xc6\x80\x72\x46\x5b\x39\xf8\x7e\xf5\x9c\xb4\xdd\x53\xef\x31\x00\x29\x57\x69\x58\x4f\x58\x8c\xee\x9f\xb4\xad\xe8\xea\xa2\x64\xf1\x9a\xf0\x87\x34\xc3\x4a\xab\x9f\x0b\x27\xff\xc2\xb8\x09\xc1\x7c\x04\x83\xc8\xf0\x41\x1d\xc2\xc7\x70\x8a\x37\x1b\xf8\x18\xc2\x0d\x0a\xd5\x7f\x8a\xca\x3a\x40\x41\x46\x97\x43\xb4\xe3\xb2\x41\xe7\x79\xec\x2d\x6c\xa7\xa1\x8f\x37\xdd\x42\x5d\x59\x57\x81\xde\xec\x40\x5b\xf4\xbd\xd7\xc6\x81\xd1\x85\x73\x0d\x98\x70\x67\x85\xef\xb1\x56\x5b\xfd\xe7\x04\x43\x2d\xdc\xe0\xdb\x58\x6d\x5c\x98\xf1\x65\x77\x2e\x0b\xf0\x93\xbe\x2f\xc7\x2f\xb8\x8b\x1b\x3b\xed\xbf\x03\x00\x40\xf1\xc7\xbb\x82\x0a\x00\x00"),
+		},
+		"/service_product.txt": &vfsgen۰CompressedFileInfo{
+			name:             "service_product.txt",
+			modTime:          time.Date(2026, 10, 19, 1, 59, 13, 896921508, time.UTC),
+			uncompressedSize: 8628,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x79\x5d\x7b\xe2\x38\xb2\xff\x7d\x7d\x0a\xfd\xef\xfe\xe7\xd9\x93\x99\xee\xe9\x99\xd9\xdd\x4b\x63\x20\x21\xc1\xe0\x46\x0e\xe1\xf4\xcd\x3e\xc2\xae\x18\x2d\xb2\xe4\x91\x64\x08\xf9\xf4\xe7\x29\xf9\x05\xd3\xc9\xd9\x0b\x50\xa9\x54\x96\xf5\x52\xf5\xab\x17\xff\xb2\x9a\x65\x6c\x83\x95\xf1\x52\x97\xf0\xf5\xeb\x97\x2f\x5f\x18\x47\x2b\xd1\xb1\xd8\x68\x8f\xda\x53\xf7\x24\x73\x64\xfc\x2c\x7d\x7e\x80\xdf\xce\xd2\x22\x7c\xfb\xc7\x3f\xd9\x54\x5a\xcc\xbd\xb1\x97\x20\x82\x16\xbe\xc5\x3b\xf6\x82\xfb\xbe\xfb\xfb\x9f\xff\xfc\xc2\xe6\x59\xda\xf7\xa3\xe9\xb7\x3f\xbf\x40\x34\x8d\x9a\x42\x7a\x96\xaa\xc6\x41\x34\x4d\x84\x16\x25\xda\xbe\xcb\x51\xbd\xf6\x2f\x6c\x59\x8b\xf5\x43\x96\xa5\x10\xad\x39\x44\xe9\x82\x75\xf2\x44\xc7\x10\x6d\x36\x0b\x0e\x11\x9f\x26\x10\xf1\x04\xa2\xfd\xc5\xb9\xd1\x0a\xd8\xee\xeb\x27\xbc\xdf\x20\xca\x73\x74\xee\x3a\xd5\x4d\xb7\x7b\x6d\xee\xe5\x09\x47\x5b\xa4\xd3\xb0\x46\x29\xb4\xfd\xd8\x42\x7b\x54\x4a\x96\xa8\x73\x64\x33\x5d\x4a\x8d\xdd\x50\xf2\x1d\xa2\xe2\xbe\x11\xb6\x60\x0f\xa6\x42\x88\x8a\x4a\x6a\x7a\xb0\x38\x09\x9d\x63\x31\x3e\xa4\x48\xda\xac\xd1\xe8\x88\x78\x55\xe6\x0c\x91\xf2\x68\x74\x2b\xd2\x9e\x78\xa4\x85\xba\x78\x99\xbb\x6e\x69\xba\xb0\x46\x16\x6c\x8a\xfb\xa6\x64\x53\xe1\xc5\x5e\x38\x84\x48\xbb\x33\xda\x1d\x44\xda\xcb\x93\xb4\x8d\x63\xaf\xc6\xb2\x7b\xe1\xf1\x2c\x2e\x0e\xa2\xba\x56\xc8\x0f\xc2\x22\x5b\xa4\x2c\x11\x52\x0d\x2b\xa8\x6b\x25\x73\xe1\xa5\xd1\x6c\x69\x44\xc1\x26\x42\xd1\x32\x6f\x47\x52\x6b\x3c\xe6\x81\xe4\x17\xe7\xb1\xfa\x6f\x36\xd3\x1e\x6d\x6d\xa5\xc3\x1b\xc9\x4f\xa6\x6d\x59\x2c\x35\xd6\x0b\xf5\xd9\x08\xed\x36\x16\xf9\x21\xcc\x74\xc6\x3d\x44\x56\xe4\x07\x2d\x21\xb2\x5e\xbe\x8a\x70\x05\x10\xb9\x1a\x35\x44\xce\xa1\x9f\xbd\xd5\xca\x58\x7a\x4b\x63\x05\x8b\x4d\x55\x35\xba\x9f\x72\xb8\xd6\xc6\x1f\x68\xfa\x40\x18\x2b\xbd\x08\xd7\xd6\x2f\xef\x24\x0e\x12\xa2\xf7\xc6\x22\x8b\xea\x7a\xd0\x72\xa3\x59\xcb\xe4\x5e\xe4\xc7\xab\xc0\xb0\xe2\xee\x44\x61\xb2\xb8\xbf\x5b\xa4\x6c\x99\x25\x30\x59\xac\xa6\x30\xd9\xc4\xc9\xef\x5f\xbe\xc0\x44\x90\x92\x48\x2d\x60\x22\x1c\x06\xdd\x9d\xbc\xfa\xba\x80\x89\x2c\x5f\x8d\xf1\x6c\x56\xd1\xe1\x67\xc6\x28\x07\x13\xa9\x0b\xb4\x0f\xcd\x1e\x26\xd2\xef\x9b\xfc\x88\x1e\x26\x4a\xe4\xc7\xc7\xa6\xda\x9b\xa9\x29\xa9\x57\x60\x7b\xe2\x0e\x26\x46\xc0\xa4\x29\xdf\xa5\x52\x02\xe2\x68\x39\x9d\x6d\x22\x88\xe3\xd4\x9a\xb7\x0b\xc4\x09\xa7\xdf\xef\x5f\xbe\x90\x51\x43\x4c\x77\xdc\x6d\x36\xde\x24\x10\x3f\xa7\x1c\xc2\x19\x07\x26\x91\x5e\x42\x2c\x8a\xe2\x02\xb1\x38\x62\xfa\x90\x42\x2c\x94\xdc\x5b\xbc\x7b\xc1\x3d\xd1\x2a\x95\xca\x78\x88\x85\xdd\x1b\x0d\xb1\x70\xde\xd4\xa6\x80\x18\x15\x5a\x2b\x20\x46\xed\xd7\xfc\x23\x0c\xc4\x07\xb4\xe6\x88\x18\x08\x7b\x49\x2f\x10\x1f\xac\xd1\xa6\xb4\xe2\x15\x62\x85\xc2\xa6\xc2\x39\x96\x1a\x25\xf3\xcb\x70\x5d\xb1\x32\x4d\xc1\xe2\xdf\x5a\x62\xae\x84\xc5\x9f\xd4\xb1\x1d\xb0\x46\xfb\xcf\x06\x52\xa1\x51\x41\x6c\xf6\x42\x79\xc6\xbd\x95\x47\x64\x4b\xe9\x3c\x6a\x92\x30\xf9\xd1\x1a\x91\x1f\xa6\x13\x88\x4d\x81\x6c\x83\x27\x89\x67\x88\x8d\x2a\xe6\x8d\x93\xb4\x3d\xa3\x94\xd8\x1b\x2b\xfc\x87\xde\xb0\x31\x53\x55\x68\x73\x1c\xf7\x1b\x2d\x4b\xe1\x91\xec\x03\x62\xa3\x5f\x55\x83\x3a\x47\x22\x35\xe6\xbe\x6f\xc3\xd9\x1b\xed\x1a\x05\x63\x58\x0d\x73\x04\x5c\xe9\xdb\x60\x09\xfd\x56\x9a\x6e\xbd\x4d\x7e\x20\x13\xbf\x3e\xd2\x58\x89\x96\x2d\x92\x28\x1d\x3a\xe9\x9a\xe8\x13\x6a\xa1\xe9\xad\xe7\xbd\xb9\x40\x6c\xcd\x0d\xf6\x11\xe3\x0c\xb1\x6d\xdc\x81\x60\x99\x06\x08\xc4\xec\xab\xa0\x15\x5f\xca\xb3\xd4\x6c\x37\x18\xab\x35\xff\x0e\x3b\xb8\x10\x96\xb4\xef\x0a\x24\xbd\x69\x3a\x8b\x19\xee\xf2\xc6\xd2\xd1\x0d\xcf\xc0\x74\xc5\xe9\x37\x74\xf9\x96\x0e\x79\x2a\xdc\x61\x6f\x84\x2d\x80\xc0\x8a\x75\x47\x42\x0f\x0e\xaa\x03\x53\xac\x95\xa1\xc6\x1d\xbd\xa9\xfb\x96\xf5\xa6\x3b\x8c\x30\x52\x3a\x2b\x14\x4c\x31\x98\x6b\xaf\x3d\xfd\x4c\xf0\x41\x1b\xa7\xda\x55\xc2\xfd\x05\x53\x93\x37\x2f\xf2\x28\x61\x6a\x8e\xcd\xb9\x25\x2a\x21\x35\xcb\x64\x85\x6c\xb1\xa0\xae\xd4\x86\x2d\xa7\xd1\xe0\xb1\xa6\xe6\x84\xb9\xf1\x30\xb5\xf2\x84\x30\xb5\xa6\xde\xa3\xb0\x8c\xf3\x07\x98\xda\x46\x16\x30\x6d\x0c\x8b\x91\x30\x4a\x92\x78\x63\x58\xb7\xae\x07\x14\xca\x1f\x60\x7a\xd1\xa2\x32\x30\xbb\x0b\x36\x3f\x97\x16\xcf\x42\xa9\xbe\xdf\x61\x8e\x83\x19\x4f\xb2\x14\x66\x2f\x1c\x66\x39\x12\x7a\xf8\xcb\x0d\x44\xcf\x94\x70\x5e\xe6\x3f\xa9\xfd\x27\x5c\x72\xe2\xb3\x8a\xcc\xb7\x45\x99\x00\x5c\x24\xdd\xf5\x39\xe6\x4d\x38\xce\xdb\xee\x00\x6a\xb3\x6a\x8f\x45\x81\x05\x6d\x71\x78\xb9\x2e\x6a\x23\xb5\x1f\x8e\x7e\x60\x8c\x7c\x42\x7f\x0f\x23\x9f\x70\x25\x83\x96\x95\x56\xd0\xfd\xcc\xf4\xc9\x5c\x60\xf6\x96\x1f\x84\x2e\x91\xfd\xd6\x05\x1c\xe1\x45\x23\xe6\xb7\xcf\x98\x7f\xff\xc0\xfc\xbc\xcf\xfe\xf8\xe5\x0f\x98\xbd\xd5\x16\x5b\xf5\x6f\xb1\xb6\x63\xd0\x36\xe7\x19\x6d\x18\xe6\x59\x4a\x3f\x36\x15\x58\x19\x0d\xa3\x50\x65\x9e\xa5\x53\x98\x3f\xa7\xe9\x8c\xc3\xfc\x25\x7b\x82\xb9\x70\x3e\x60\x39\x11\x99\x15\xf9\x71\x10\x95\x68\x2f\x2c\xb5\xf2\x6a\xd3\x73\xa9\x70\xd3\xe8\xd0\xfe\x20\xac\xbe\x0e\xb4\x0a\x70\xf7\xb5\x23\x2d\xb2\x5d\x96\xc0\x5c\xba\x03\x5e\x10\xe6\x4a\xea\x23\xcc\x95\x39\x93\xb0\x6a\x9c\x33\x5a\xe6\x2c\xc1\x42\x5e\xe7\x50\x17\x57\x5b\xda\x84\xb1\x5e\x6e\x8d\xcc\x31\x90\xb4\xb7\xd1\xad\xcd\x2d\x92\x9a\xce\x2d\xe2\x2a\xe2\xa1\xe5\x2f\x8b\x2c\x7e\x80\x16\xef\x58\x22\x8b\x42\x85\x25\xc0\xfd\x83\x71\x1e\xee\x57\x51\xc6\x26\xe6\x0d\xee\xb9\xb7\x28\x2a\xb4\x6c\x93\xf1\xe1\x48\x7a\x15\xb9\x47\x6b\xa5\x87\x7b\xe9\x97\x62\x4f\x0d\x0a\xb8\x57\xc2\x39\xda\xc4\x20\x6c\xa2\x03\x8a\x10\xe8\xb8\x81\xa3\x2f\xe7\x03\x5a\x64\xc9\x3c\x83\x7b\x53\x3a\xb8\x37\xa6\x54\xc8\x5a\x48\x9f\xe9\xa2\x67\xf4\x88\x15\x6c\xe3\xde\x8a\x57\xa1\x05\xb5\x17\x65\x4a\xb8\xb7\xa6\xa9\x5f\x48\xc1\xee\xc9\xe7\x1b\xab\xe1\x21\x6a\x3d\x60\xd7\x92\xeb\xf6\xae\x5f\x4a\xb8\x35\xfa\x1b\x33\xa6\xf0\x20\x0a\x63\xea\xf0\xaa\x10\x9e\xc1\x03\xaa\x9a\x11\xca\x0c\x72\xf2\x78\x92\xe1\xa8\x46\x08\x4a\xf1\xdc\x5d\xe4\x9c\x74\x9e\x80\xf6\xc1\x58\xf9\x6e\x34\x3c\x78\x5f\x77\x6b\x68\xaa\x4a\xea\x72\x2f\x6d\xc1\x66\x6f\x39\x62\xc1\x76\xac\x3b\x83\x87\x4b\x61\x05\x2c\x26\x09\x6b\xb1\x06\x16\x0b\x0e\x8b\xe5\x3a\x81\xc5\xd8\xda\x17\x6b\x0e\x8b\x74\x1b\xc1\x82\xcf\x66\xb0\xc8\x31\x17\xce\xc3\xa2\x40\x31\x5e\xc9\xa2\x40\xed\xc9\x76\xfb\x7e\xa9\xe5\x4d\x80\xb2\x20\x67\xf4\x36\x9d\xc0\x42\x3b\x59\x1e\xfc\x36\x81\xde\x0e\xb1\x60\x4b\x62\x39\xb6\x6e\xfc\x60\xbb\x34\xaa\xfe\xff\xe6\xbf\x58\x17\xe0\xb6\xfc\x0a\xb5\x67\x19\xe6\x07\x6d\x94\x29\x2f\x57\x29\xee\x85\x2e\x28\xc6\x6d\xe5\xc4\x5e\x2a\xe9\xdb\x71\xab\xd1\x7f\x8c\x0b\x86\x91\x7b\x2b\xea\x03\xc5\xb3\x3f\x0f\xb4\xa7\x90\x0b\xad\x3f\x72\x3f\x15\xed\x95\x64\x41\xc8\xc4\xbd\xb1\x08\x8f\x13\x72\x7a\x11\xef\x88\x59\x94\xc2\x63\x7c\xd7\x5e\xfa\x63\x7c\xc7\x3b\x8a\xec\xf3\x91\x9e\x87\x47\x51\xa1\xa3\xff\xd7\xe0\xc4\x1f\xc5\x49\x74\x21\x2e\xfb\x24\xac\xfd\xcf\xc3\x2c\x55\xc2\xbf\x1a\x5b\xb1\x59\x11\x6e\xe3\x46\x3e\xf8\xf6\x56\x43\x3f\x4e\x36\xba\xda\x47\xd4\x47\xa9\x1d\x3c\xa2\x6f\x0f\x91\x28\x7f\x81\x47\x69\x45\xf8\xeb\x37\x3e\xba\x22\x78\x34\xa6\xfa\x7f\x64\x83\xf0\xd8\xd4\x17\x8f\x76\x98\xad\xed\x52\x90\xf9\x94\xb0\x80\x6c\x4f\xc9\x5d\x32\x4f\xc3\xa1\xc0\x93\x20\x57\x20\x0d\x3c\x09\x5d\x2a\x84\x27\xb4\xd2\xf4\x4e\xfa\xda\x0b\x41\xca\x13\x3a\x6f\x51\xb1\x33\xee\x7b\x9d\x7e\x92\x7b\xb2\xce\x27\x79\x96\xb4\x13\xb2\xd0\x27\x6d\x3c\xa3\x50\xe0\xa9\xd9\x87\xcb\x42\x07\xc1\xab\x46\x25\xe9\x12\xa5\x25\x78\xf5\xfc\x63\x7f\xbb\x14\x94\xc4\x60\x4d\x24\x05\xa2\xab\x84\xc3\x52\xe6\xa8\xdd\xd5\xd3\xb7\x7d\xa9\xcb\x11\xa7\x3c\xf8\x33\xd2\xff\x47\xa5\xa3\x10\xf0\x2a\xe8\x91\xd7\x78\x9b\x80\x2d\x8d\x6f\x5c\x6f\x90\x6d\x67\xf6\x56\x63\x21\xbd\xb1\xb7\x32\x5c\x54\xe8\x65\x85\xb0\xbc\xe8\xbc\x1f\x4a\xa2\xc5\xb2\x5f\x73\xd2\x39\x92\x64\xb6\x89\x9f\x37\x90\xac\xb7\x33\xe9\xd9\x34\xf9\x01\xc9\x8d\xb7\xa5\x13\x08\x1e\x7f\xac\xd9\x44\xdf\x25\xe2\x2d\x10\x33\x2d\xf6\x0a\x03\xc9\xa5\x47\x68\x77\xd0\x26\x99\x8c\xa2\xe7\xb3\x19\x0c\x2f\x84\x6a\xb7\x12\x9d\x7a\x04\x34\x0b\x39\xe3\xc8\x94\xc3\x25\x8c\x19\x14\x97\x1a\x85\x63\xd6\xb0\xa4\x1b\x0e\x79\x9a\x44\x58\x29\xa6\x13\x48\x28\x19\x28\x68\xaf\xe2\x8d\xe7\x22\x3c\x7e\x11\x9a\xcd\xa6\x09\x87\x1b\x7f\x15\x3a\x9c\xae\x90\xc8\xc6\x09\x48\xd0\xe6\x8d\xed\x22\x9c\xcc\x0a\xed\x6a\x63\x7d\x67\x07\x34\xaa\xa4\x86\x04\x9d\x13\x25\x5d\x73\x8f\x67\x57\xce\x30\xb3\x17\x1f\xee\x9b\x98\x21\x1f\x26\xc2\xd5\xca\x48\x0f\x89\xd4\x72\xba\x5c\x45\x81\x78\x4e\x75\x5a\x40\x62\xf6\x62\xe7\xd1\x56\x90\x18\xa9\xe9\x07\x89\xd1\xa5\x31\xf4\xa4\xd1\xa5\x45\x45\x2d\x3d\x6c\x4c\x41\xdb\x6b\x94\x97\x2b\xf4\x2d\x91\x5b\xf1\x4a\xa4\xa6\xe7\x2e\xfc\xfb\xb2\xfd\x6f\xad\x1b\x56\xf7\x8b\xd5\x8e\x2d\x68\x16\xe7\xc6\x95\x83\xd5\x2a\x4b\x61\xf5\x1d\x56\x19\xed\x1d\x42\x4f\x94\xd2\x38\xb6\x34\xc3\xb6\x3a\xce\x0a\xfd\xd9\xd8\x23\x0b\xd9\xff\x3b\xf1\xf3\x79\x96\x16\x83\x14\xd6\xa8\xfd\x01\x1d\xac\xd0\xb9\x86\x1a\x7f\x37\x7d\x1a\x6b\xf6\x0a\x7d\x9b\x53\xaf\xd0\x53\xe4\x4d\x2d\x17\x56\xe8\x92\xed\x7a\x93\x20\x16\x5d\xdf\x88\x1a\x0e\xfc\xca\xe9\x3c\x48\x88\x00\x6f\x44\xf9\x74\x77\x2b\x6e\x11\x35\x51\x21\x44\x6a\x45\xb7\x42\xb7\xef\x7e\x11\x16\x47\x45\x83\x9f\x96\x1a\x46\xc7\x7e\xfa\x96\x17\xb2\x71\x62\xa1\x20\x9c\x8d\x38\x8b\x26\x51\x3a\xe6\x7c\xc4\xea\xff\x34\xc8\x08\x7b\x47\x12\x83\x63\xf9\xbc\x98\x70\x15\x0c\x11\x03\x27\x86\x1b\x0e\x2e\x5c\x54\x50\x17\x73\xed\x87\x90\x10\xed\x68\x8a\xb7\x9a\xd4\x6b\x85\x6f\x3e\x0f\xc9\xee\xb0\xcc\xb7\xc6\xb1\x0d\xd6\xc6\xc9\xa0\xca\xc3\x13\x52\x94\xc2\x0a\x16\xed\x60\x65\x0a\x84\x95\xf1\xb8\x37\xe6\x08\xab\x26\x57\x48\x88\xb4\x4a\x3a\x54\x85\x55\x53\x96\xe8\x1d\x5b\xa2\xb0\x7a\x64\x24\xeb\x3d\x65\x71\x8c\x7c\xa3\x28\x07\x64\x80\x75\x88\xcb\x64\x53\xc1\xfa\xf5\x95\x3c\xc9\xb7\x3f\xff\x08\x4b\xb0\x74\xc1\xeb\xba\x5f\xc2\xba\xc6\x71\x9a\x16\xba\xe1\x2e\xae\x9e\xae\x1f\x0d\x83\x51\xd1\x06\x8f\xa1\x33\x2b\x4a\x64\x43\xa9\x86\x38\x14\xf5\x06\xe2\x29\x09\x0d\x81\x7f\x20\xda\xf7\xb5\x24\x01\xc6\x56\x34\xca\x87\xee\x06\x9d\x6f\x5f\xcc\x67\x9b\xb6\x5d\xa4\xbc\x25\x12\x72\x66\x81\xe2\x0f\x6d\x8b\xc2\xe6\x87\x40\x66\xf8\xe6\xbb\x08\x2c\xf4\xb7\x49\xfb\xd0\x36\x5d\xb1\xae\xe2\xd7\x1f\x11\x71\x29\x51\x5d\x5b\x91\x2b\xfc\x4c\x59\xc8\x86\x85\xd4\x68\x1d\xac\x2d\xf1\xfb\xdd\xc3\xba\xf1\xca\x98\x63\xab\x16\x61\x5a\x58\xbf\x5f\x2a\xa1\xc9\x01\xa6\xd1\x35\xe7\x4b\xa3\x84\xca\x9f\xe9\x64\x07\x54\x6a\x49\x13\x0e\xe9\x18\xff\xd3\x17\x0e\xa9\xd0\xc6\x8a\x6a\xc0\xce\x54\xd4\x68\x2b\xb4\x25\x42\x2a\xac\xa8\xe4\xd1\x40\x8a\x36\x37\xfa\x2a\x82\x56\x41\x7a\x10\x7b\x4b\x4b\x36\x16\x52\x79\x77\x20\x38\x1f\xee\xa7\x0f\x92\xc6\x40\x94\x2a\x74\x47\x08\xf5\x38\xda\x15\xa4\xc6\xf9\x5f\x5a\x4d\x08\xf4\xab\x7c\x83\x94\xb2\x10\xb6\xf8\xde\x12\x49\x16\x41\x6a\x4d\x08\x1f\x52\x6b\x36\xc2\x53\x53\xa1\x3f\x60\xe3\xa0\xc5\xbd\xf0\x5f\xa2\x86\xb4\x51\x0e\xfb\x18\xa2\xf5\x7b\x08\x69\x63\xf1\x8e\x20\x0c\xbe\x67\x9c\xc3\xf7\x6d\xf6\x2b\xe1\xe9\xf7\xda\xd4\xe4\x3c\x37\x19\x6c\x44\x2d\x0b\x82\x42\x99\xc3\x06\x45\x5f\xbd\x22\x72\xbb\x8a\x61\x43\xf3\x38\x43\x8c\x82\x3d\x88\x4f\x9c\xfd\x06\x0b\xe9\x82\x05\x0b\x5d\xb4\xfd\x4a\x6a\x84\x0d\xbe\xaa\x36\x51\x1d\x91\xad\x1b\x0e\x6b\x63\x8b\x6c\x34\xb0\x83\x50\x10\xc7\xa0\x13\x1b\x74\x92\x9e\x72\x46\xb5\x6f\x70\x5e\xa1\x87\x8d\xa9\xd2\x60\x20\x1b\x53\x71\x7e\x40\xa5\x60\xd3\xe8\x02\xf3\x63\xdf\x8e\xcb\xa3\xfc\x1b\xf0\xbf\xff\xca\xff\x60\xeb\x34\xee\xd7\xca\xa3\x89\x7e\xdf\x17\xc0\xa3\x94\xb5\xae\x6d\x48\x67\x79\xbc\xbe\x26\x0d\x7c\xd1\xa3\x1f\x5f\xa4\x29\xa7\xea\x6d\x7a\x30\x1a\x81\x2f\x83\x13\xe1\xc9\x03\x84\x60\x62\x04\x06\x3c\x5d\xa4\x40\xfe\xa8\xcf\xb9\x80\x0c\x64\x94\x1d\x12\x99\xd1\x6e\xc5\x95\xb3\xbc\xdb\xa6\x2b\xe0\x59\xb4\x99\x47\xf1\x8c\x91\xa2\xf2\xec\x79\x35\x08\xbc\x44\x19\x70\x5a\xe5\xee\x1b\xe3\x17\x2b\xf2\xe6\x16\xc3\xb9\xa8\xf6\x02\x3a\x0b\xec\xce\x75\x94\x52\x77\x9c\x7b\x45\x15\x3a\xd6\x17\x7a\x3a\x6e\x86\x3a\x47\xed\x07\x5f\xd2\xb2\x87\xd8\x00\x86\xd0\xa9\xf3\x40\x43\xff\x83\x0a\x0c\x23\x7d\x1a\xc1\x51\x17\x55\x38\x28\xd4\x5e\x6a\x54\xec\x09\x2f\xee\x2a\xde\x31\xc7\xf5\xed\x7e\xc8\x9e\xee\x9e\xbb\xe6\x76\x27\x43\x33\x0e\xb2\x58\xc2\x53\x08\xaa\xb0\xd0\x11\xe5\xd1\x5c\x56\xb5\xc2\xe9\xa4\x23\x42\xb4\x4d\xd1\x1c\xdb\x60\x6e\x4e\x38\x02\x7a\x7e\xbc\xd4\x18\xf4\x71\xd2\x38\xa9\x09\x44\x78\x25\xac\x27\x0c\x09\x44\x88\x9f\x42\xcd\x18\x78\xb5\x0f\x46\xc8\xb5\x39\xef\x09\x58\xb8\x51\x16\xb8\xd1\xc2\x7e\x6f\xf6\x08\xbc\x16\xf6\x08\x21\xcc\xcd\xa8\x72\x08\xbc\x56\x8d\x3e\x02\xff\xab\x41\x7c\xc7\x3d\x2d\xec\x2f\xaa\x5d\x71\x2f\xec\x0a\x3d\xdb\xdd\xbd\x48\xfd\xed\x37\x68\x81\x9b\x5c\x47\xf7\x31\x83\x37\xd2\x23\x95\x8e\x79\x33\xf2\x01\xb7\x19\xcc\xed\xd0\x88\x39\x4e\x6d\x3e\x15\x58\xaf\x66\x9f\x0e\xe8\x0b\x29\x55\x38\xbf\xa6\xa6\x23\x26\x8b\xe7\x4d\x4d\x7a\xd0\xde\x7e\x1b\xd2\xf2\xc6\x96\x48\x15\x1c\x7e\x16\x65\x89\x96\x3d\x2f\x80\x53\x60\xde\xeb\x50\x36\xe1\xe3\x5b\xcb\xe2\xf4\xd7\x45\x4a\x0d\x59\xd0\x3a\xed\xed\x2b\x7b\xbe\xf9\x68\x95\x51\xe0\x2d\x9a\xa1\x8b\xa2\x8a\x29\xad\x25\x82\xd7\x28\x8e\x90\x61\x7b\x3c\x19\xba\x7e\x41\x8b\xef\x90\x1d\xa4\x86\x4c\x96\x68\x09\xaa\x32\xa9\x2f\xf7\x3c\x34\x75\x80\xc6\x4c\x9e\x8c\x92\xec\xa7\x4f\x4d\x74\xe3\x78\xb7\xef\xee\x9c\x96\xc1\x67\xd1\xb2\x17\xee\x7d\xf6\x5c\x09\x77\x88\x4d\x7d\xd5\x97\x9f\x04\x06\xb6\xa9\x72\xe1\x21\x33\x44\x5a\x2d\x0a\x03\x99\x15\xf8\x2a\x8f\x5d\x60\x3a\x18\xd4\x38\xb8\x09\xd9\x63\x76\x96\xce\x63\xd1\xa6\x87\x7d\x87\xea\x88\xd9\xd9\xe8\xe3\xe5\xb6\x04\xf5\x4c\x79\xd9\xb3\xde\x9b\x46\x17\x8b\xe9\xff\xa5\x1a\x9f\x09\x0c\x43\x72\x2e\xdb\x7f\xb6\x95\x05\x1a\xa2\x4f\x68\x9d\x50\x6c\x9c\xaa\x0c\xd5\xcb\x67\x6d\x85\x2c\xe0\xd9\xe6\x07\xaa\xd8\x52\x70\x19\x32\x40\x53\x34\x0a\xe1\xd9\xa1\xa5\x12\xce\x36\x81\x6d\xc2\x19\x1f\xdd\xfa\x76\x1d\x9c\x2c\x6c\xd3\x75\xfa\x0d\xb6\x9b\x14\xb6\x2d\x5a\x6f\x85\xd5\x94\x2c\xb7\x81\x46\xf8\x3f\x0b\x5b\xa0\x86\x6d\xbe\x73\xf6\x04\x01\xfd\xb7\xb2\xd4\xe8\x3d\xc2\x56\x5a\xdf\x08\xf5\x71\x2b\xfd\xc0\x4c\x9f\xa4\x35\x3a\x1c\x66\xc7\x93\xef\xb7\x31\xe4\x36\x54\x93\xe6\x1c\xb6\x6f\x2f\xc6\x1e\x1d\x8b\x17\x73\x0e\x2f\xb3\x89\x95\xf9\x11\x5e\x1e\x12\x78\x59\xce\xa6\xf0\xc2\xef\x17\xdd\xe4\x2f\xfc\x5f\xa4\xe1\x2f\xcf\x77\xe1\x5e\x5e\x70\xad\xd5\x65\x6a\xc6\xd5\xd9\xeb\x97\xb3\x40\x29\x49\x0b\x20\xf2\x11\xbd\x08\x85\x2d\xea\xa4\xab\xb1\xfc\x98\x6c\x55\x81\xe8\xfb\xe7\x05\x35\xad\xfb\x25\x55\x1c\xa4\xf8\x41\xa2\x2a\x02\x65\xe8\x13\xd5\xdf\xfe\x16\xe8\x3a\x78\x96\x81\xfa\xa9\x3a\x4d\x75\x57\x8b\xba\x70\x44\x75\xeb\x70\xfd\x94\xf6\xf8\x8e\x4d\x09\x2f\x52\x15\x73\x75\x81\x17\xa9\x37\xa6\xf1\x48\x04\xe7\x0f\x53\x6a\x5f\x70\x1f\x6e\xee\x45\xea\xc2\x9c\x1d\x8b\x67\x63\x33\xed\xb9\xad\x5e\xa6\x4a\x5c\x3e\x30\xaf\x92\xe5\x18\x07\xe8\xe8\x7b\x7a\xf7\xcb\xda\x96\x6c\xf7\xf5\x2b\xec\xa2\x24\x1d\x44\x76\x93\x24\x86\xdd\xdc\x22\xfe\xe3\x4f\xd8\x25\x4b\x36\x9d\xc0\xae\x75\x49\x94\x6a\xef\x08\xbe\xb8\x51\xc2\x4a\x47\x0f\xf7\x48\xb2\x43\xdd\x4f\x40\x30\x0a\xbb\x93\xce\xe1\x7f\x56\xdf\xe1\x07\x6d\x04\x2d\xfc\x10\xfb\xbd\x7c\x83\x1f\x58\xb0\xc8\xb7\x41\x74\x30\xcb\x1f\xb4\xc4\x56\x3b\xe0\x87\xa9\x11\x84\xaa\x0f\xa2\x2e\x40\xbc\x65\x4b\x0e\x7b\xe1\x0e\x9e\xbe\x20\xee\x9d\xae\xea\x02\xf2\xf6\xfb\x50\xdb\x0c\xa5\xa0\xae\xf6\x50\x08\x7b\x6c\xa5\x89\x72\x5e\x78\x28\xfe\xbd\x2f\xb4\x83\x42\xbb\x02\x0a\xe3\xe9\x3b\xe1\xa8\xfe\x82\xdd\x77\xb8\xb5\xcd\x0f\xe8\x7c\x5b\xa5\xc7\xaa\x2d\x93\x51\x78\x67\x6b\x51\x00\xbe\xc9\x0a\x4a\xbe\x8e\x52\x28\xc3\x44\xed\x3b\xe4\x72\x0d\x32\x55\x42\xa3\x1f\xdf\x8e\x6c\x4b\x02\x52\xa3\x6f\xbc\x54\x8e\x85\x0f\xa0\xb2\xbe\x9f\xad\xa2\xf6\x03\x09\xc8\x9a\x12\x71\x50\x72\xef\xdc\x81\x9a\xa6\xd6\x35\x28\x4a\x33\x49\xb6\x92\xb9\x35\xff\xea\x69\x2d\x47\xe4\x91\xbc\x5c\x25\x7d\xd5\x22\x6b\x45\x69\xfb\x1d\xb6\xc5\x7d\x20\x68\x7e\x03\x6d\x08\x84\xb5\x37\xb5\x2e\xc1\xd4\xa8\x1f\xa2\x09\x98\xb3\x8e\xc7\x69\x57\x8d\x56\x41\xfd\xda\x96\x26\xea\x43\x9d\x5c\xda\x42\xf0\x5f\x45\x9a\xc0\x5f\x21\x64\xb0\xa2\xa6\xd3\xb0\x7b\x15\xf6\xec\x5e\xf3\x3d\xf8\x76\x25\x5e\x87\x3d\x35\xf9\x5d\xdb\x6f\xf2\xb6\xdf\x82\x1e\x34\xa7\xb6\x34\x7d\xea\x02\x96\xae\xa5\x98\xf8\x84\x21\xc7\x3a\x55\xa2\xf1\x87\x02\x4e\x2e\xc4\xc4\x6d\xc3\x66\x6f\x1e\x75\x81\x05\xbc\xff\xba\xbe\x71\x5f\xef\xf3\x2c\xed\xc8\xff\x1d\x00\xf8\x61\xe6\xa1\xb4\x21\x00\x00"),
+		},
+		"/vendor.txt": &vfsgen۰CompressedFileInfo{
+			name:             "vendor.txt",
+			modTime:          time.Date(2026, 10, 19, 1, 59, 13, 896921508, time.UTC),
+			uncompressedSize: 9270,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\x7a\xc9\x72\xeb\x4a\xce\xe6\x1e\x4f\xc1\x55\x45\x77\xc4\x6f\x77\xc4\xbd\x7f\xfd\x5d\xcb\xa6\x28\xc9\xd6\xb1\x28\xf3\x88\xf4\x70\xef\x0e\x22\x61\x12\x57\xc9\x04\x4f\x66\x52\x16\xcf\xf3\xf4\x33\xf4\xaa\x77\xf5\x62\x7f\x20\x29\x4f\xa7\x6a\xe1\x04\x72\xa0\x72\xc2\xf0\x01\xe9\xdf\x76\x49\x45\x86\x8e\xd2\x8f\x96\x8f\x58\x13\xfc\x9e\x3d\xc3\xef\x99\xf4\xf0\x8f\xf3\x3f\x92\x8d\xad\xaf\x21\xbd\xbe\xbb\xde\x24\xa5\xbc\x84\x57\x74\x04\x69\x96\xaf\x20\xcd\xaa\xa4\xa4\x7a\x74\x1c\x26\x48\x97\x0b\x48\x97\x19\xa4\xcb\x6a\x9f\xee\x20\xdd\xdc\xdf\x56\x55\x91\x14\x4e\xfe\xa2\x3a\x40\xba\xcd\xd2\x6a\xb5\x85\x74\x5b\x41\xba\x7d\x80\x34\x4f\x6f\x92\x8a\xea\xce\x8a\x91\x76\x82\x34\xdf\x40\x9a\x57\x4b\x0c\xe8\x65\x0c\x1d\xa4\xbb\xdd\xdd\x0a\xd2\x22\x83\x74\xbf\xdf\x94\x90\x96\x0f\x25\xa4\xd5\xdf\xaa\xe4\x2a\xb9\x59\x64\x17\x7e\x8b\x07\x71\x18\xc4\x31\xf9\x24\xc3\xfe\xe0\xb8\x69\x69\xee\x2b\x03\xba\x41\xd8\x86\xb9\xfa\x24\xce\x34\xaf\xe2\xce\x90\x56\xab\x1d\xa4\xd5\x0d\xa4\xd5\x36\xee\xbc\x96\x3e\xd9\x72\xcf\x81\x1a\x48\xab\x0a\xd2\xc7\x1c\xd2\x47\xa5\xd5\x2a\xbb\x85\xf4\x59\xe7\x47\x1f\x1c\x42\x5a\xd7\x64\xc8\x61\xa0\xe6\xcb\xf2\x9b\x24\x1d\x1d\x79\x48\x9b\x9b\x11\x5d\x03\x69\x83\x43\xa0\x1a\xd2\xa6\x67\xbb\x74\xc2\xcd\xcc\x92\x83\xb4\x91\x03\x41\xda\x04\x87\x16\x52\x72\xd2\xf1\x89\x20\x6d\xc9\x51\x52\x4e\x3e\x50\xef\x21\x6d\xd9\x90\xae\x9c\xdd\x92\x5e\xc8\x7a\x52\x36\xc7\xd6\x52\x6c\xac\x45\x8e\x1c\xdb\x02\xeb\xac\x47\xec\x91\x21\xd5\x6f\xd8\x27\x2b\x43\x75\x70\x62\xb9\xf6\x90\x9a\x56\x20\x35\x7c\xc0\x03\x2a\x25\xfb\x88\xa3\x09\x90\x1a\x43\xad\x93\x4f\x57\x6a\x0c\xd9\xab\x85\xc3\xc6\xd0\xa4\x35\x8e\x5b\x34\xe4\x27\xab\xd5\xf9\xe8\xcc\xd0\xe1\xc7\xc6\xe3\xdc\x97\xa6\x78\x8c\xff\x11\xa5\x25\x79\xb8\x2e\xaf\xd3\x6b\xed\x62\xab\xbf\x1c\xae\x76\x90\xf6\xf8\x53\x2c\xa4\x16\x8d\xb4\xcf\x90\xda\x46\x4f\x25\x59\xd2\x61\x6c\x13\xbd\xf7\x03\xea\x2e\xed\xb4\x18\x3d\xa4\x03\xd6\x1d\x41\x3a\x0c\x46\x4b\x47\x86\xc7\xfe\x97\x79\x87\xc0\x96\xce\x90\xba\x1b\xd1\x4d\x40\xea\xb0\xee\x2c\x2b\x3d\x5a\xf6\xc9\x8e\xc2\xab\xb8\xa3\x87\xd4\xd5\x1d\xa4\x8e\x7c\x2d\x3d\xa4\xce\xe1\xf4\xb9\x2f\xb0\x97\x97\x70\x91\x72\x37\x1e\xf0\x53\xa7\xaf\xc9\x36\x90\xce\x1f\x7a\xb2\xc1\x31\x42\xea\x19\xed\x78\x86\xd4\x0f\x54\x87\x24\x93\x5e\xf5\xa6\xc6\xc0\x62\x7d\x6c\xb5\x90\xfa\x40\x8e\xfd\x11\x52\xaf\xbb\x09\x06\xbd\x7e\x05\x69\x70\xba\x91\x8f\x43\x0f\x01\xeb\xae\xc7\x10\x59\x3e\xb1\x40\x3a\x36\x2c\x99\x34\xba\xc5\x13\x4e\x08\xe9\x89\xdc\x94\x2c\xc9\x5a\xf6\x7a\x80\x27\x6e\xd9\x44\xc6\x10\xd9\xe4\x91\x5b\x48\x4f\x52\x47\x71\x39\xb3\x87\xf4\x2c\x96\x75\xd6\xf3\x2b\x4e\x90\xfe\x1c\xcd\xbb\x58\x2d\x56\x29\x2c\x2a\x58\xa0\x31\xe4\xd8\x22\x2c\xd0\x36\xa3\x43\xd5\x23\xaf\x95\x36\x90\x6d\x61\x81\xae\x16\x2d\xf9\xac\xa5\xc3\x7a\x6c\x74\xec\x39\x90\x83\x05\xb9\x23\x19\x9a\xde\x37\x91\x2c\xc9\x73\x6b\xe7\x13\x5c\xbc\x84\xa1\x79\xd7\xfa\x05\xb7\x2f\x22\x4a\x5d\x93\xdc\x4a\x4f\x49\x3a\x06\xe9\xe3\x51\xc1\x82\xc3\x89\x3d\x29\x7d\x45\xd7\x90\x85\x85\xc1\xfa\xb8\x90\x33\x2c\xcc\x48\x49\x26\x18\x22\x97\x29\x15\x84\x85\x1c\xea\xc8\xf6\x2d\x3a\x58\x88\xaf\x3b\x2d\x09\x16\x8e\xdb\x2e\x94\xdc\x5a\x65\x03\xfb\xee\x4d\x1e\x3f\xdf\xcc\xc2\x09\x36\x7a\x93\x0b\x27\x35\x36\xfa\x99\x84\x4e\x77\x34\xbe\xbc\xa0\x11\x58\x8c\x7e\x3a\xc8\x19\xb2\xbf\x2d\xbf\x8a\x5a\x76\x55\x74\x62\x75\x45\x6e\x10\x37\xaf\x3e\x4b\x21\x5b\x54\x90\x2d\x1f\x37\x90\x95\x39\x64\x78\x30\xa4\x4a\x07\x19\xd6\x81\x21\xc3\xa6\x99\x4a\x72\x27\x72\x90\xa1\xe1\x83\xa3\xab\x27\x3a\xbc\x1f\x8d\xb6\xe9\x95\x65\x68\x54\x8a\xd5\x76\xa9\x64\xbc\xcb\x5e\x86\x36\xfe\x96\xb3\xd4\x32\x25\x39\x19\x23\x36\x79\xb0\x7c\x22\xe7\xd5\xe0\x66\xe8\x03\x19\x43\x90\xe1\x58\x77\x02\x99\xda\xa5\x49\x89\x91\xd9\xec\x65\x64\xc3\x7d\x09\x19\x0d\x1d\x64\xe4\xd1\x06\x84\xac\x43\xb6\x97\xee\x8e\xea\x63\x52\x7c\xf0\xef\xcd\x4e\x8e\x44\x1f\x2b\xed\xc8\xb9\xa9\x98\x20\x63\xb2\x08\x19\xdb\x40\xf5\x7c\xc2\x57\x39\x5a\x6c\xa9\x8f\x1b\x61\xc7\xaa\xa5\xb3\x09\x80\x8c\x7d\x2d\x90\x71\x50\x21\xca\x0c\x3a\xb2\xe1\xeb\x01\x1a\x42\xbb\x70\xf2\xea\xd9\xb6\xb1\xe6\xfc\x2b\xbf\x04\x65\x05\x32\xc3\x83\xde\x1f\x64\x46\xc6\x66\xad\xdf\xcf\xec\x96\x55\xf7\x22\x4b\x0e\x21\x93\x03\x9a\x00\x99\xd4\x47\x27\x58\x77\xb3\x24\x67\xd2\x30\x5a\xc8\xc4\xb0\x4d\x6e\xd1\xb9\xa8\x39\x99\xf4\x35\x7a\x1d\x1c\xe5\xa2\xc5\x40\xca\x0f\xf8\x23\x12\x47\xde\xb3\xd8\xb7\x5f\xe8\x87\x71\x70\xf1\x40\x94\x8d\xda\x9a\x89\xa5\x3a\xf0\x09\x23\x77\xc6\xd8\x69\x5b\x37\x92\xad\xa7\xb7\x7d\x8b\xd5\x41\x4f\x2a\xd9\x99\xd8\xe0\xc4\x24\xa5\x98\x71\x96\xc1\xb7\x96\x28\x09\x68\xc9\x40\x26\x63\xdd\x45\xd3\x97\x45\xd3\x7b\xb9\x04\x47\x7e\x16\x26\x95\xe7\x1a\x4d\x52\x60\xe8\x20\x73\xf2\x9a\x15\x05\x64\x6e\xf4\xdd\xba\x52\x66\xf2\x01\xcd\xa3\x70\x4d\xbf\x5a\xa2\x6c\xec\x47\x33\x7a\xc8\xa6\x03\xb9\x42\x5e\xc9\xcd\xac\x60\x0f\xcb\xe4\x5b\xb2\x20\x67\x7d\x20\xb6\xb0\xbc\xda\xb2\x3d\xc2\x72\x79\xf5\xb4\xaf\x60\xb9\xca\x60\x89\xdd\x88\xb0\x44\x9b\xdc\x61\xcf\xd6\x1f\x27\x50\x13\x9d\x5c\x76\xa7\xf7\x17\xeb\x4b\xe9\x91\x2f\xfc\x0d\x59\x72\x68\x62\x45\x15\xa7\x4e\x72\x39\xb0\xa1\xd8\xd0\xe3\x19\x96\x54\xd1\x13\x25\x57\xc9\x92\xc6\xe0\xeb\x8e\xa2\x0c\x0d\x9d\xd8\x57\xb5\x29\x49\x7a\x03\x4b\x3a\xe8\xc5\x2d\xc9\x18\xf8\x32\xea\x28\x3d\x2c\xe9\xc4\x35\xfd\xe7\x6f\xb0\xe4\x79\x02\x58\x72\xcb\xb1\x98\xbd\x5d\x12\xaf\x2a\x90\xf3\xd1\xde\x51\x72\xd3\x1f\x6e\x63\xff\xd8\xc3\x92\x1d\xd5\x61\x7b\x7f\xb3\xc9\x60\x29\xf5\xf8\xc4\x47\x86\xa5\x1c\xc7\xd7\x99\x19\x0f\x46\x65\x9e\x6a\xf6\x71\x7b\x72\xa2\x5a\x02\x2c\xdd\x75\xb2\xa3\xb1\xc3\xd1\x27\x39\x1f\x9d\xe8\x62\xe2\x74\x47\x58\x3a\x9c\x2a\x52\x4a\xd8\x77\xe2\x75\xb4\x0c\x07\x42\x97\x94\xe5\xed\xbb\x02\x2d\xdd\x38\xe8\xb9\x8c\x02\xab\xf5\x06\x56\xdb\x74\x07\xab\x3c\x83\x55\xfe\x94\x66\xb0\x52\x74\xb3\xc2\xd6\x50\xb2\x9a\xe8\xc3\x00\xac\x30\x88\x85\x55\x84\x1b\xaa\xef\xab\xda\xf0\xe0\x09\x56\x71\x76\x58\x19\xf4\x81\x6b\x58\x99\x40\xc7\x58\x9e\x61\xd5\x1f\xa8\xa9\x3a\xf6\x33\x17\x2e\xdc\x04\xab\x9e\x9c\x6a\xc0\xaa\x1f\x8d\x8e\xb3\xb5\x34\x04\x2b\x1b\xc8\xa1\x9f\x3c\xac\xec\x49\x26\x5d\xef\x79\x82\xd5\x10\x47\xfe\x18\xd1\x6c\xe3\x21\xaf\x7e\x8c\x7c\x42\x43\xb6\xa6\xe4\x7f\xdc\x0f\x64\x6f\x7f\xff\xed\xf7\xff\x09\x2b\xc7\xb5\x8f\x43\xc7\x46\x1c\xc2\xea\x24\x46\xe7\x7b\xd5\xa6\x73\x70\x78\x2b\x43\x64\xa8\xff\xbc\xab\x73\x5c\xfd\xfa\xef\xb0\x7e\xb8\xbd\x87\xf5\xc3\xb7\x4d\xf2\xbc\xda\xdf\x3f\xc3\x1a\x6b\x3a\x88\x1c\x61\x8d\xae\xe4\xf0\x2f\xe2\xbc\x46\x1f\xbe\x40\xca\x35\x86\x27\x76\x04\x6b\xd2\xf9\x3f\x9a\xc9\x59\x72\x4a\x1c\x3a\xfe\xc0\x42\xc7\x59\x1a\xd6\xdc\x90\xae\x73\xa6\x67\x58\xb3\xa1\xfd\x68\x23\xfd\xc9\xc6\xe0\xd5\xfb\x0f\xb1\xa3\xd5\x44\x91\xbe\x98\x09\xd6\x46\xc4\x33\xe9\x71\xaf\x8d\xbc\xce\x86\x73\x6d\x46\x3d\x04\xae\x61\x6d\x26\x3f\x38\x9c\x60\x2d\xa6\x61\xdb\xfe\x9f\x4e\x7a\x82\xb5\xb8\xc0\x96\x42\x64\x1c\xc2\x5a\x7c\x8d\x3d\xac\x65\xb4\x8d\x9b\xde\xe8\xc7\xf9\xac\x1d\xd1\xa2\x5c\x46\x5a\x2c\x9e\x23\x2d\x9f\x36\x55\x76\x1b\x59\x75\x4f\x6b\x47\xbe\xab\xd4\x8b\x0a\xac\xc7\xbf\x38\x79\x26\xa7\xcd\xe3\x5f\x1c\xfc\xf8\x46\x93\x92\xd5\x2e\x7b\xb8\x59\xe6\x70\xb3\xde\xc0\xcd\xee\x01\x6e\x8a\x2a\x79\xe4\x86\xe4\x1d\x11\xdc\x94\x41\x85\x97\x1c\xdc\xa0\x31\xd8\x76\x91\x73\x8e\x42\x50\xfc\xaf\x3a\xfd\x5f\x70\xd1\xec\x64\x39\x59\xec\xb9\xf6\xb1\xc1\x4f\xfe\xdf\x78\xd9\x68\x3d\xd5\x12\xc6\x31\x81\x22\xe5\x13\xa3\x52\x5f\xe3\x40\xca\x04\x11\xb8\x21\xe7\x38\xc0\x0d\xb7\xa8\x4e\x3e\x32\xbd\xd8\x48\x3d\x69\x47\xb8\x1d\x0f\x4a\xb6\x18\x09\x21\xdc\x18\x35\xf7\x9f\x80\x77\x92\x7a\x2f\x35\x63\x20\x7f\xe9\x2c\xe7\x39\xe4\x46\xa2\x7d\xbf\x91\xd6\xc3\x8d\x48\x6b\x94\x77\x0d\x5a\x81\x1b\x87\x2f\x68\x51\xa9\x6d\x7c\xdc\xbf\xf2\x93\x91\x16\x6e\x1c\x91\x3d\x88\xa5\x99\x7b\xc5\xd3\x07\x28\xbf\x71\x34\x45\xc0\xf3\x01\x62\x54\xea\x9a\xb1\x0e\xfe\x62\xfd\x6f\xc6\x86\xe0\x46\x8f\x43\x9c\x85\xdb\xdf\x33\xb8\x4d\x67\xb5\xba\x2d\xe0\xb6\x58\xc1\x2d\x36\x22\x03\xdc\x22\x9f\x66\x3b\x73\x8b\xc7\xbf\xc3\x2d\xda\xd7\x0b\xae\x7e\x65\x6d\xf3\x1d\xab\xaf\x84\x5b\x1c\x6d\x94\xe1\x37\x93\x73\x4b\x5c\x91\x81\x5b\x2e\xd9\x70\xad\xdf\xf3\xf1\xed\x97\x78\x50\xbc\xa1\x54\x45\x4c\xe1\xd6\x55\xea\x3d\xfb\x80\x36\xc0\xad\x58\x9a\x5e\xd5\xae\xde\x8e\xf8\x4a\x7c\x21\x57\xbf\x2b\x22\xba\x1d\x0f\x1c\x30\xc0\xed\xd8\xf7\x6c\xdb\x83\xc2\xb5\x6d\x68\xae\xe1\x76\x6a\x3e\xa9\xd6\x66\x71\xab\x58\x99\x60\xb3\xc8\x61\xb3\x5b\xae\xb2\x0a\x36\xdf\xd9\x3e\xce\x0b\xd8\x94\x19\x6c\xca\xe5\xee\x57\xcd\xdd\x54\xf7\xff\xd2\xd4\x10\xc2\xa6\xb5\xaa\xe7\x7b\x42\x13\xb8\x27\xd8\xf4\xd8\x92\x4a\x9d\x32\xac\xd6\xcd\xc3\xc6\xaa\x12\xc7\xb3\xdd\xd8\x5a\xf4\x0b\x81\x8d\x6d\xb8\x95\x24\x55\x5f\x4c\xc9\x3c\xfb\xfb\x2d\x5d\x3a\xdf\x03\xd3\x8d\x6d\xc6\x79\xe0\xc7\xb5\xc1\xc6\xbe\x98\xf1\xac\x8e\x09\x36\x36\x0a\xca\xc6\x06\x32\xb1\x74\x3d\xd5\x33\x63\x29\x24\x1a\x3c\x68\x7c\xb6\xb1\x27\x12\xd8\x0c\xfe\x95\x43\xdd\xc1\xc6\x47\x0c\xbe\xf1\x81\x05\xbe\xad\x9d\xb4\xf0\x0d\xfb\x17\xf8\x46\xc6\x4c\x2f\x6c\xe1\x1b\xd9\x23\x5b\x0f\xdf\x28\x2c\x1c\x46\x6e\xb4\x3c\x90\x83\x6f\xe3\x30\x05\x72\x70\x97\x66\x2b\xb8\x43\xf2\xe4\x92\x37\xfc\x21\xce\xc3\x1d\x1a\x86\x3b\x0d\xe8\x0c\x0b\xdc\xe1\x10\xf8\x24\x70\x47\xaa\x4f\x5c\xc3\x1d\x39\x6d\xa6\x57\x12\x0b\x77\x34\xe9\x4f\xde\xd1\xe4\x15\x02\xc3\x9d\x34\x0c\x77\x62\x5f\x74\x33\x77\x62\x5b\x2d\xb8\xc6\x24\x67\x2b\x26\x20\xdc\x8d\x87\xb8\x2f\xf2\xca\x8e\x36\x8c\x70\x37\x49\x4d\x0e\xdf\x68\x92\x73\x40\xd8\xa6\xbb\xec\x3e\x7f\x3f\xd4\x6d\xba\x5b\x92\x3f\xc2\xf6\x06\xb6\x9b\xdd\x33\x6c\xf1\x10\xb0\x87\x2d\x5a\x26\xa7\xc4\xbf\x12\x0d\x33\x1b\x25\xf5\x0c\x5b\xc2\x26\xd0\x31\xd9\x93\x27\x74\x75\x37\x5f\xe2\x96\x6c\x2d\x8e\x94\x92\xd1\x52\x4e\x02\x5b\x3a\xf7\xe8\x8e\xb0\x55\x94\xbc\xcb\x4b\xd8\x32\x1d\xc8\x05\xd8\xf2\x0b\x79\xfe\x49\xb0\xe5\x56\x9e\xf0\x34\x33\xaf\x33\xd3\xd3\x57\x94\xae\x18\x46\x9d\xd9\x8c\x10\xb7\x1c\xa8\x1c\x88\x9a\x5f\x07\x9d\xe8\x49\x2d\xed\x7b\xc0\xaf\x4e\x4e\x01\x2d\x6c\xc5\xd1\x19\xb6\x12\x46\x0f\x5b\x39\x8b\x25\xd8\x8e\x31\xac\xda\x4e\xf6\xfc\x39\xda\xdf\x4e\x8e\x3d\xe4\x8b\x22\xb9\x9b\xc5\xfa\x38\x8b\xf5\x17\xd8\x91\xdf\x54\x57\xd9\x7d\x9e\xaf\xf6\xd9\xea\xd2\xb2\xa9\xf6\x2b\xc8\x8b\xcd\xd7\x25\xe5\x45\xf9\x11\x10\xe6\xfb\xc7\x5f\x75\x25\xc7\xe4\x1b\xa3\x85\x1c\x6b\x27\x3d\x35\x8c\x90\xa3\x66\x39\x72\x6c\x15\x7d\xe4\xc8\xe6\x2a\xc7\x73\x64\x56\x56\xe3\x10\x98\x71\xf9\xca\xb6\x6c\x63\xa5\x71\x8a\x5a\x73\x8d\xeb\x2c\xff\xeb\x04\x8e\x71\xb9\x80\x1c\x27\xb4\x57\xab\x65\x5e\x42\x7e\x81\x0f\x9f\x16\x56\xa7\x2f\x44\x90\xeb\xfc\x7f\xff\x02\xe4\x63\xd3\x0d\x86\x4b\xa7\x02\xfe\x37\xc7\x00\x39\x39\x3c\xce\x25\x2b\xa9\x47\x37\x7d\xa8\x66\x4e\x8e\x43\xb2\xdd\x6c\x37\x3b\xe5\xbd\xea\x59\x4e\x01\x37\xf6\x45\x22\x13\x71\x71\xce\x8b\x98\xd5\x99\x05\x28\xe7\xba\x43\x32\x49\x25\x47\x74\x74\xd2\xba\x93\x32\x38\x0c\xd4\x4e\x73\x6d\x50\x8c\x13\x39\xb5\x56\x10\x51\x5b\xc5\x47\xc8\xd9\xf2\x43\x61\x3f\x10\x44\xce\x0e\x67\xbc\x9d\xb3\x2a\x4c\x2e\x07\x54\xf7\x9e\xcb\x41\x02\x9f\x21\x97\x1a\x2d\x42\x2e\x6c\xf5\x0f\x72\x91\x46\x0f\x57\x5c\x48\x16\x38\x41\x2e\xd1\x07\xc4\x6d\x7f\x96\x8f\x5c\x82\x38\x31\xfa\xe5\x59\x8b\x88\x2b\x20\x1f\x4d\x60\x1d\x35\x73\xb5\x43\x5d\xdc\x68\xd9\xc2\x4e\x53\x69\xff\x26\x64\xdd\xa5\x37\xfb\x14\x76\x69\xf9\x9f\xea\xfc\x61\xb7\xca\x60\xb7\xae\x60\xb7\x55\xcb\x14\xe3\x94\x5d\x55\xc0\xee\x71\xb3\xdc\xa4\xb0\xc3\x96\xc5\xc3\x0e\xad\x18\xc2\x17\xd8\xd5\xeb\xaa\xf8\xb8\xc0\x1d\x29\xf4\x30\xca\x38\x81\x1d\x85\xab\x72\x97\x17\xca\xa4\xc3\xa0\x44\x61\x87\x12\x09\x3f\x95\x46\xe3\xb8\xa3\xb0\xf9\xae\x65\x89\x0e\x6d\xfb\x0e\xb0\x2f\xee\x6e\x47\xe1\x49\x97\x4f\x21\xda\x50\xa5\x84\x4e\x29\xc7\x6f\x65\x98\xa9\x23\x89\xa3\x54\x34\x3e\x8b\xce\x8e\xc2\x8c\x09\xde\x18\x0d\x30\x5e\xc8\x91\xad\x63\x5b\xd4\xf4\x0b\x36\x4a\x52\x53\x77\xd4\x4f\xef\x8a\xfb\xd6\xae\xf8\x74\xd0\x10\xf4\xab\x5a\xed\x68\xf4\x21\x2e\xe6\x1c\x6a\x0d\x17\x61\xa7\x26\xb2\x57\x07\xfe\x71\x28\x72\x64\x9c\xcb\xab\x37\xc0\xb4\x93\x9e\xed\xd8\xc3\x4e\x9c\x0a\x85\x12\x5d\xaa\x9c\xd4\x79\xee\xc6\x51\xe0\xbe\xd8\xf9\x98\x93\xbb\x7f\x4a\xcb\x02\xee\x0f\x7f\xd1\xec\x61\x7e\x51\xac\xfb\x83\x27\x77\xd2\xe0\xe3\xbe\x26\xb8\xaf\x83\x0c\xa3\x87\xfb\x23\xeb\x5f\xa3\xe7\xab\x70\x3a\xa9\xe8\x1c\x22\xa7\x37\xa0\xf4\x2e\x8f\x64\xbb\x4c\x8b\xc8\x44\x01\x9b\xd3\x76\x5a\xdd\xa5\x59\xa4\x7b\xf2\x61\x8a\x5c\xb9\xda\xcf\x74\x53\x94\x33\xf3\x50\xae\x66\x26\x60\x7d\x8c\xdc\x63\x3e\x77\x3d\x16\xbb\x48\x35\xd4\x8b\x14\x8d\x89\x4c\xbc\xba\x7b\x87\xb5\x21\xb8\x3f\x91\x33\x68\x9b\xc8\x78\x22\x28\x6e\x0b\x28\x36\xe9\x1a\x8a\xed\x12\x8a\x7d\x75\x03\x05\xb6\x22\x17\x39\x28\xd0\x18\x0a\xfe\xa2\x57\x5e\xeb\xbd\x16\x92\xa4\x26\xc8\x07\xba\x2d\xd0\xe2\x8c\x96\x0b\x4d\x3c\x71\x80\x02\x07\x75\xb8\xae\x25\x28\xd0\x61\x33\xd9\x99\x31\x86\x8c\x8f\x5c\xcf\x47\x81\x02\x83\x5e\x42\x81\x9a\xcc\xea\x39\x74\xef\x92\x98\x28\xe6\xe1\x9a\x3c\x14\x64\x6a\x81\x82\xd4\xc6\xa1\x52\x03\x45\x87\x35\x1b\x35\x35\x45\xc7\x1a\x3e\xbd\xd1\x0b\x0a\x7e\x97\x36\xb6\xed\xbb\xaf\x2b\x3a\x21\x1b\x05\xd5\x06\xac\x03\x14\x7c\xd5\x89\x21\x28\xb8\x0e\xa3\x23\x85\x5f\x85\x41\xb6\x49\x4c\x57\x41\xa1\xd6\xa6\x30\x7c\x26\x07\x85\x18\x0c\xec\x95\x4e\xb1\x50\x1b\x58\x88\x0b\x18\x33\xbf\x85\xf8\xf0\xc2\x67\x88\xc1\x78\x92\x13\xfa\xd1\xc5\xe4\xc9\x0c\xb6\x62\xf3\x72\x57\xce\xcc\x93\xca\x67\xe1\xb0\xed\xf1\x63\x69\x4e\x92\x1b\x27\xe3\xa0\xdc\xba\x2a\x96\xef\xa6\xac\x70\x31\x09\xfa\xd9\x0a\x15\x4e\x6a\xf2\x9f\x4c\x78\xe1\xa4\x55\x90\xa1\x4c\x4f\xa1\xa3\x31\xb2\x96\xe2\xe7\xe7\x5e\xce\x50\x44\x28\x55\xd1\x51\x95\xe8\x0c\xc5\x68\x3c\xcd\xf6\x9a\xa0\x18\x55\x73\x82\x38\x6c\xe7\xca\xba\x2a\x1a\x28\xa6\xd0\x89\x7d\x9f\x24\x89\x11\xcd\xac\xdd\xdf\xaf\xca\x3f\x4a\xf8\xbe\x4b\x8b\x58\xbc\xef\xe2\x7b\x91\x7d\x2c\xea\xfb\x88\x26\x26\x7a\xbe\x8f\x6c\xc3\x2f\x49\xde\x8b\x78\xed\xd3\xe5\x0c\xf2\xde\xea\x9b\x62\x05\xfb\x72\x03\xfb\x32\x68\xb6\x14\xf6\x78\x38\x70\xc8\xbf\xc3\x1e\x07\x6e\xfe\x37\xec\xd1\x71\x40\x0b\x7b\xf4\x43\x4c\x28\x28\xc6\x8c\x5a\x14\xb9\x77\x79\xd4\xca\xe3\x2e\x9b\x8f\x7f\xaf\x4a\xfc\xc2\xe4\xbe\x2c\x01\xf6\xd4\x24\xb7\x18\x94\x1e\xb0\x3e\x26\x9f\x3e\x6e\x0c\x5b\x52\xda\x47\xda\xb1\xfd\x94\x4f\xdf\x73\x2d\x1d\xec\xd5\x73\xbd\xe2\xf4\xb6\xf9\x04\x6d\xf3\x79\xcc\x0b\xc6\x78\x78\xaf\x69\xbd\x03\x35\x33\xe3\x83\xe8\xef\x49\x7d\x34\xfc\xf2\x32\x73\x0a\xdd\x3f\x23\xd8\xbd\x74\x0d\x25\x7f\x4b\xca\xba\x7b\x45\xf7\x13\xf6\x72\x1c\x61\x2f\x67\xb2\xb0\x1f\x0f\xd3\xd5\x16\x6d\x0b\xfb\xb1\x3e\x8e\x1e\xf6\x23\xff\xc5\x04\xfb\xd1\x36\x54\x1f\xa1\xfc\x0d\xca\x74\x61\x7f\x1e\x1a\x28\xd3\x02\xca\xb4\xba\x87\x72\xb1\xdd\xe4\x50\x66\xf7\x50\xae\x6e\x3f\x4b\x51\x79\xb3\x81\x32\x4f\xf5\x4f\xf3\x58\xe8\xbe\x44\x5f\x2d\x94\x79\xf6\x71\x24\x65\xb1\x29\x40\x53\x1f\x5f\x0d\xe1\x87\xc7\x2f\xab\x74\xbf\x4e\x23\x20\xea\x6e\x21\x5a\xa9\x12\x5f\x68\x47\x01\x4a\x15\xab\x12\x0d\x79\x7d\xfc\x88\x83\xb1\x3f\xa0\x96\x7e\xb4\x2d\x94\x68\x5b\xe9\xb5\x3e\x0c\xe2\xe4\x69\x9e\x10\x03\x99\x19\xbe\x95\x78\x62\x0b\x65\x8d\x1a\xd5\x97\x75\x67\x89\x1b\x72\x97\xf8\x9f\xeb\x4f\x4d\x7a\x0e\x2f\xa3\x3d\xbe\x67\x9d\xca\x9a\x63\x76\xe3\x2d\xbe\xfd\xe2\xa3\xde\x3a\xe7\x64\x48\xac\xa9\x98\x28\xeb\x78\x08\x97\x66\xc2\xe8\x01\x4b\xc2\x10\x0c\xcd\xbe\x79\xd6\x9b\x4b\xca\x0c\x4a\x6a\x5b\x72\x50\x92\x6d\x14\xda\x43\x49\x3f\x46\xb2\xda\xee\xa2\xfc\x97\x6a\x88\x38\x4c\xf7\xa5\xb2\xf2\x19\xdd\xbe\xe5\x16\xd5\xd4\x5d\x3d\x44\xf2\x59\x4a\x27\x28\x3b\x74\x03\x94\x1d\x19\xb3\xb1\xa9\x66\xd0\x23\xaf\x1d\x64\x7f\x76\x64\x93\x3d\x51\x8d\xb3\x72\x5d\xcf\xe2\x5e\x76\x32\x1b\xb3\x52\xc1\xb6\x81\x37\xf7\x77\x09\x2c\x97\xa3\x0f\xca\xeb\x4c\x05\xe1\x11\x4a\xfe\x31\x3a\x84\x52\x8d\x5e\x14\xde\xb2\x17\x09\x5d\x74\x1f\xa5\xc5\x61\x5e\x15\x94\xf2\xe2\xf4\xd7\xe4\x25\xb0\xde\xda\x9b\x5d\xb8\x95\xd1\x13\x44\x09\x7a\x62\xdb\x78\x28\xc5\xa2\xfb\x3e\x1e\xb4\xd1\x72\x3d\xff\x8e\x58\x89\x3d\x13\x94\x32\x74\x91\x1f\x5d\xfd\x0b\xd0\x2f\x87\x8e\x74\x25\x03\xd7\x74\x91\xbb\x81\x2f\xf9\xe5\x2f\xfe\xb7\x1c\x0c\xd7\x31\xa2\x2c\x07\x33\xda\x23\x94\x83\x84\x40\x6e\xbf\x86\xf2\xc7\xc8\x4d\x92\xc5\x57\xa6\x32\xa0\x6d\xd0\x35\x9f\xe4\x38\xa0\xdb\xd1\xaf\xbf\x17\xc5\xe2\x3a\x76\x3e\xca\xf9\xed\x4e\x02\xba\x98\x2a\x29\x03\xb1\xf5\x27\x3e\xc2\x05\x8d\x6a\x3e\x52\xf3\xad\xc9\x76\x9b\xc1\x9c\x66\xc9\xe2\x45\x8f\x2a\xf5\xa3\x85\x72\x1c\xd4\x0d\x28\x50\x9d\xf9\x13\x7b\x71\x0d\x94\xaf\x38\x8b\xca\x2b\x7b\x7f\x12\xae\x09\xca\x29\x42\xe1\x72\xea\xd1\x06\xaa\x95\x39\x88\xb9\x90\x2f\xa7\x73\x59\xd5\xd4\x0f\x46\x8d\x59\x03\xe5\x64\x67\x57\x17\xd7\x3e\xbd\x49\xcc\xe4\x73\xf4\x81\xdc\x57\x59\x57\x23\x55\x77\x50\x6d\x4a\xa8\xb6\x4b\xa8\x8a\xab\xed\x66\x77\x07\xd5\x7e\xb5\x5b\x5a\x0a\x50\x3d\x5e\x5e\x1f\xaa\x3f\x8a\xfb\xdf\xa1\xd2\x60\x03\x47\xa8\xd0\x90\x6d\xa0\x42\xdb\x1c\xc8\xb5\x50\xa1\x1c\x50\xa0\x42\xdf\xa3\xfd\x38\xd6\x8a\xb0\x2f\x07\x15\xa6\xb8\x62\xae\xc5\x88\x83\xea\x92\xdc\x38\x43\x45\xa6\xc1\x90\xdc\x5e\x27\x77\x6e\xf4\x3f\x27\xeb\x8f\xfc\x1f\x49\x7e\x9d\x64\x5c\x77\x52\x1f\x39\x29\x87\xeb\xe4\xdb\xb5\x0e\xa4\xf9\x44\xff\x9d\xbb\xd0\xde\xe8\x3d\x95\x51\x44\x91\xac\xb7\x9b\xbd\xd6\x0c\x3b\xd6\x65\x98\xb3\xd8\x2f\x1b\xaf\x68\x8e\x9b\x2a\x55\x75\x1b\xa0\x22\x87\x0d\xd7\x0c\x55\x47\x47\x32\x86\x26\x0f\x55\x27\xbd\xd7\xb1\xdc\x92\x7b\xdc\x65\xca\x74\x61\x66\x4c\x43\xde\xa0\xef\x94\x6d\x59\xc7\xd8\xe0\x44\xc9\x34\x68\xfa\xe7\xdd\x4f\x57\x1a\xcb\x57\x72\xe0\xf0\xe1\x05\x2a\x39\x8e\x1a\x76\x5c\x72\x7a\x33\x79\x28\x17\x50\x89\xfb\xf8\x50\x9c\xc5\x46\x9e\xe8\x00\x95\xf8\x8e\x0f\x08\x95\x43\x7a\xe1\xe3\x6c\x6c\x2a\xa7\xa6\xbd\x72\xdc\x28\xde\xac\x9c\x4c\x50\xe9\xc3\x84\x96\x5e\x83\x99\x6a\x74\x07\x31\x31\x36\xae\x5e\xd9\xeb\x03\x76\x3e\x07\x6b\xf3\xf7\x73\xdb\xdc\x04\x0f\xbb\xcd\x33\x3c\x1c\x88\xe0\xe1\xc0\x3f\x46\x0e\x0c\x0f\x73\xb6\xe0\xc1\x1e\xd4\xc3\x6f\x96\xf0\xa0\x8a\xa1\xe5\xcb\xa4\xa5\x86\xde\x0f\x56\x91\xf9\x1c\x80\x3f\x2e\x1c\xd7\x47\x78\xcc\xe3\x1e\x1f\xf5\xf1\xff\x11\x1b\xf5\xd6\x8f\xd1\xe3\xc3\x23\xda\xe5\x74\xfc\x04\xc6\x1f\xd1\xb6\xfa\x78\x9e\xcc\xd1\x6b\xf3\xe9\xdd\xe4\x11\x9d\x65\xdf\x5d\xd5\x51\x61\x23\x14\xbe\xbc\x19\x3e\xd6\xcf\xde\x9d\xe0\x91\x5a\xbc\xa4\xf2\x1e\xc9\xf5\x6c\x8c\x5e\xeb\x23\xb9\xf9\xf5\xe4\x4d\x04\x2f\x12\xf2\xc8\x18\x01\xe0\xff\x52\x8c\xa6\xca\x1b\x6b\x17\x0b\xf6\xc8\xad\xa5\x10\x08\x1e\xd9\x6a\xe1\xe2\x62\xd9\x8f\xa8\x5e\xe9\x1d\xc5\x3c\xf2\x4f\xdd\x8b\xd4\x68\x2a\xaa\x7f\x31\x15\x6f\xf3\xfc\xf3\xff\xfa\xd0\xfe\xf3\xff\x05\xbc\x8a\x4f\x24\xe9\x02\x9e\xd6\x55\x71\x99\xe8\x69\xff\xfd\x32\xee\xa9\xbc\xff\x0d\x9e\x34\xeb\x67\xdb\xf0\xf5\x99\xef\x09\x43\xdd\xcd\xff\x53\xf0\x44\xf7\xd6\x4c\x4b\x81\x27\x3a\x54\x8e\xd4\x88\x3e\xd1\xa1\x67\x0b\x4f\xc4\x4d\xff\xcf\xff\xaf\xaf\xba\xf0\x44\x3e\x1c\x70\x4a\xe6\xe0\x9f\x9c\x8f\x2d\x64\xcc\x4c\x9d\x4d\xe2\x83\x09\x1a\x78\xea\x38\x50\xa2\xde\xe2\x89\x5f\xb4\x30\x0d\x2b\xb1\x4d\x12\x61\x08\x3c\xc9\xeb\x4f\x9c\xcb\x4b\x90\xfb\xb6\xf9\xa7\xe9\x27\xc1\xf3\xf5\xbd\x6b\xe1\x39\xcd\x8b\x02\x9e\x35\x40\xfd\xc7\x7f\xc1\xf3\x5e\xb0\xf1\x30\xa7\xa4\x9f\x59\x6c\xdb\x23\x7f\x76\x55\xcf\x3c\x74\xb1\xb8\x16\xfd\x98\x03\xf6\x0c\xcf\x46\x55\x09\x9e\x2f\x19\xa9\xe7\x29\x86\xf0\xcf\xd3\xc5\x2e\xfc\x81\x3d\x76\x08\x7f\x10\x1a\xb6\x47\xf8\x43\xea\x20\xf0\x87\x8c\xb6\xfd\x19\x23\xfc\x3f\x35\xf3\x41\x0e\xfe\xac\x56\xf0\xa7\x42\xc1\x33\xfc\x89\x43\x27\x53\x93\xcc\x00\xd1\xc3\x9f\x74\x70\x08\x7f\xaa\x50\x75\xf8\x0a\x7f\x72\x1f\xeb\xd3\x99\x0c\x60\x26\x06\x01\xcf\xd5\xb6\x7c\x57\xb7\x7a\x7e\x9d\xab\x7f\x5e\x5b\xae\xa1\x41\x77\xec\xc2\xe7\x97\x6d\x6d\xf1\x01\xc3\x47\x83\x84\x2c\x2f\x81\xac\xe6\xbb\x47\x0f\xe4\x83\x78\x50\x94\xed\x06\x6c\x80\xce\xdc\x43\xdb\x58\xdf\x80\xbe\x0b\x5c\x0f\x06\xf8\x7a\x7b\xbf\x03\xde\x6b\xb2\x01\xb8\xc2\xba\x03\x7e\xf6\x97\x03\x66\x6b\xe5\xa4\x7b\xb0\x04\x86\x0f\xde\x77\x10\xcf\x28\x0c\x0d\xf4\x1c\xfa\x68\x55\xa0\x17\xdb\xca\x15\x9d\x63\xee\xf0\x7d\x29\x7a\xf1\x67\xb0\x6c\x49\xff\x3c\x58\x51\x23\x65\x83\x0c\x20\xfa\x7c\x93\x2e\x40\x5e\x6d\x7c\x42\x85\xe1\xa5\x8c\x91\xec\xd0\x0d\xf9\x14\xff\x43\x05\x06\x71\xe1\xb7\xbf\xc3\x8f\xa6\xc8\xe1\x47\x84\x2a\x2e\x3e\x3e\x9e\xbc\x02\xfe\xf7\x59\xfe\x7b\x00\xf8\xcd\x3b\x27\x36\x24\x00\x00"),
+		},
+	}
+	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/device.txt"].(os.FileInfo),
+		fs["/hw_family.txt"].(os.FileInfo),
+		fs["/hw_product.txt"].(os.FileInfo),
+		fs["/os_architecture.txt"].(os.FileInfo),
+		fs["/os_family.txt"].(os.FileInfo),
+		fs["/os_product.txt"].(os.FileInfo),
+		fs["/service_family.txt"].(os.FileInfo),
+		fs["/service_product.txt"].(os.FileInfo),
+		fs["/vendor.txt"].(os.FileInfo),
+	}
+
+	return fs
+}()
+
+type vfsgen۰FS map[string]interface{}
+
+func (fs vfsgen۰FS) Open(path string) (http.File, error) {
+	path = pathpkg.Clean("/" + path)
+	f, ok := fs[path]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+
+	switch f := f.(type) {
+	case *vfsgen۰CompressedFileInfo:
+		gr, err := gzip.NewReader(bytes.NewReader(f.compressedContent))
+		if err != nil {
+			// This should never happen because we generate the gzip bytes such that they are always valid.
+			panic("unexpected error reading own gzip compressed bytes: " + err.Error())
+		}
+		return &vfsgen۰CompressedFile{
+			vfsgen۰CompressedFileInfo: f,
+			gr:                        gr,
+		}, nil
+	case *vfsgen۰FileInfo:
+		return &vfsgen۰File{
+			vfsgen۰FileInfo: f,
+			Reader:          bytes.NewReader(f.content),
+		}, nil
+	case *vfsgen۰DirInfo:
+		return &vfsgen۰Dir{
+			vfsgen۰DirInfo: f,
+		}, nil
+	default:
+		// This should never happen because we generate only the above types.
+		panic(fmt.Sprintf("unexpected type %T", f))
+	}
+}
+
+// vfsgen۰CompressedFileInfo is a static definition of a gzip compressed file.
+type vfsgen۰CompressedFileInfo struct {
+	name              string
+	modTime           time.Time
+	compressedContent []byte
+	uncompressedSize  int64
+}
+
+func (f *vfsgen۰CompressedFileInfo) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, fmt.Errorf("cannot Readdir from file %s", f.name)
+}
+func (f *vfsgen۰CompressedFileInfo) Stat() (os.FileInfo, error) { return f, nil }
+
+func (f *vfsgen۰CompressedFileInfo) GzipBytes() []byte {
+	return f.compressedContent
+}
+
+func (f *vfsgen۰CompressedFileInfo) Name() string       { return f.name }
+func (f *vfsgen۰CompressedFileInfo) Size() int64        { return f.uncompressedSize }
+func (f *vfsgen۰CompressedFileInfo) Mode() os.FileMode  { return 0444 }
+func (f *vfsgen۰CompressedFileInfo) ModTime() time.Time { return f.modTime }
+func (f *vfsgen۰CompressedFileInfo) IsDir() bool        { return false }
+func (f *vfsgen۰CompressedFileInfo) Sys() interface{}   { return nil }
+
+// vfsgen۰CompressedFile is an opened compressedFile instance.
+type vfsgen۰CompressedFile struct {
+	*vfsgen۰CompressedFileInfo
+	gr      *gzip.Reader
+	grPos   int64 // Actual gr uncompressed position.
+	seekPos int64 // Seek uncompressed position.
+}
+
+func (f *vfsgen۰CompressedFile) Read(p []byte) (n int, err error) {
+	if f.grPos > f.seekPos {
+		// Rewind to beginning.
+		err = f.gr.Reset(bytes.NewReader(f.compressedContent))
+		if err != nil {
+			return 0, err
+		}
+		f.grPos = 0
+	}
+	if f.grPos < f.seekPos {
+		// Fast-forward.
+		_, err = io.CopyN(ioutil.Discard, f.gr, f.seekPos-f.grPos)
+		if err != nil {
+			return 0, err
+		}
+		f.grPos = f.seekPos
+	}
+	n, err = f.gr.Read(p)
+	f.grPos += int64(n)
+	f.seekPos = f.grPos
+	return n, err
+}
+func (f *vfsgen۰CompressedFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		f.seekPos = 0 + offset
+	case io.SeekCurrent:
+		f.seekPos += offset
+	case io.SeekEnd:
+		f.seekPos = f.uncompressedSize + offset
+	default:
+		panic(fmt.Errorf("invalid whence value: %v", whence))
+	}
+	return f.seekPos, nil
+}
+func (f *vfsgen۰CompressedFile) Close() error {
+	return f.gr.Close()
+}
+
+// vfsgen۰FileInfo is a static definition of an uncompressed file (because it's not worth gzip compressing).
+type vfsgen۰FileInfo struct {
+	name    string
+	modTime time.Time
+	content []byte
+}
+
+func (f *vfsgen۰FileInfo) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, fmt.Errorf("cannot Readdir from file %s", f.name)
+}
+func (f *vfsgen۰FileInfo) Stat() (os.FileInfo, error) { return f, nil }
+
+func (f *vfsgen۰FileInfo) NotWorthGzipCompressing() {}
+
+func (f *vfsgen۰FileInfo) Name() string       { return f.name }
+func (f *vfsgen۰FileInfo) Size() int64        { return int64(len(f.content)) }
+func (f *vfsgen۰FileInfo) Mode() os.FileMode  { return 0444 }
+func (f *vfsgen۰FileInfo) ModTime() time.Time { return f.modTime }
+func (f *vfsgen۰FileInfo) IsDir() bool        { return false }
+func (f *vfsgen۰FileInfo) Sys() interface{}   { return nil }
+
+// vfsgen۰File is an opened file instance.
+type vfsgen۰File struct {
+	*vfsgen۰FileInfo
+	*bytes.Reader
+}
+
+func (f *vfsgen۰File) Close() error {
+	return nil
+}
+
+// vfsgen۰DirInfo is a static definition of a directory.
+type vfsgen۰DirInfo struct {
+	name    string
+	modTime time.Time
+	entries []os.FileInfo
+}
+
+func (d *vfsgen۰DirInfo) Read([]byte) (int, error) {
+	return 0, fmt.Errorf("cannot Read from directory %s", d.name)
+}
+func (d *vfsgen۰DirInfo) Close() error               { return nil }
+func (d *vfsgen۰DirInfo) Stat() (os.FileInfo, error) { return d, nil }
+
+func (d *vfsgen۰DirInfo) Name() string       { return d.name }
+func (d *vfsgen۰DirInfo) Size() int64        { return 0 }
+func (d *vfsgen۰DirInfo) Mode() os.FileMode  { return 0755 | os.ModeDir }
+func (d *vfsgen۰DirInfo) ModTime() time.Time { return d.modTime }
+func (d *vfsgen۰DirInfo) IsDir() bool        { return true }
+func (d *vfsgen۰DirInfo) Sys() interface{}   { return nil }
+
+// vfsgen۰Dir is an opened dir instance.
+type vfsgen۰Dir struct {
+	*vfsgen۰DirInfo
+	pos int // Position within entries for Seek and Readdir.
+}
+
+func (d *vfsgen۰Dir) Seek(offset int64, whence int) (int64, error) {
+	if offset == 0 && whence == io.SeekStart {
+		d.pos = 0
+		return 0, nil
+	}
+	return 0, fmt.Errorf("unsupported Seek in directory %s", d.name)
+}
+
+func (d *vfsgen۰Dir) Readdir(count int) ([]os.FileInfo, error) {
+	if d.pos >= len(d.entries) && count > 0 {
+		return nil, io.EOF
+	}
+	if count <= 0 || count > len(d.entries)-d.pos {
+		count = len(d.entries) - d.pos
+	}
+	e := d.entries[d.pos : d.pos+count]
+	d.pos += count
+	return e, nil
+}
@@ -0,0 +1,266 @@
+// Package dhcp decodes raw DHCP messages and fingerprints the vendor class
+// identifier (option 60) against the Recog dhcp_vendor_class.xml database.
+package dhcp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+// MatchKey is the Recog match key for the DHCP vendor class database
+const MatchKey = "dhcp_vendor_class"
+
+// DHCP option codes decoded into named fields
+const (
+	OptionPad                  = 0
+	OptionHostname             = 12
+	OptionDomainName           = 15
+	OptionRequestedIP          = 50
+	OptionOverload             = 52
+	OptionMessageType          = 53
+	OptionParameterRequestList = 55
+	OptionMaxMessageSize       = 57
+	OptionVendorClass          = 60
+	OptionClientIdentifier     = 61
+	OptionUserClass            = 77
+	OptionClientFQDN           = 81
+	OptionEnd                  = 255
+)
+
+// bootpHeaderLength is the fixed BOOTP header size preceding the magic cookie
+const bootpHeaderLength = 236
+
+var magicCookie = []byte{99, 130, 83, 99}
+
+// Option is a single raw DHCP option
+type Option struct {
+	Code uint8
+	Data []byte
+}
+
+// Packet is a decoded DHCP message
+type Packet struct {
+	Op                   uint8
+	XID                  uint32
+	ClientIP             net.IP
+	YourIP               net.IP
+	ServerIP             net.IP
+	RelayIP              net.IP
+	ClientHardwareAddr   net.HardwareAddr
+	MessageType          uint8
+	VendorClass          string
+	Hostname             string
+	DomainName           string
+	ClientFQDN           string
+	ClientIdentifier     []byte
+	UserClass            []byte
+	RequestedIP          net.IP
+	MaxMessageSize       uint16
+	ParameterRequestList []uint8
+	Options              []*Option
+}
+
+// ParameterRequestListString returns the parameter request list as a comma separated
+// list of option codes, the form commonly used by DHCP fingerprint databases.
+func (p *Packet) ParameterRequestListString() string {
+	codes := make([]string, 0, len(p.ParameterRequestList))
+	for _, c := range p.ParameterRequestList {
+		codes = append(codes, strconv.Itoa(int(c)))
+	}
+	return strings.Join(codes, ",")
+}
+
+// Option returns the raw data of the first option with the given code
+func (p *Packet) Option(code uint8) ([]byte, bool) {
+	for _, opt := range p.Options {
+		if opt.Code == code {
+			return opt.Data, true
+		}
+	}
+	return nil, false
+}
+
+// Parse decodes a DHCP message starting at the BOOTP header (the UDP payload)
+func Parse(data []byte) (*Packet, error) {
+	if len(data) < bootpHeaderLength+len(magicCookie) {
+		return nil, fmt.Errorf("dhcp message is too short (%d bytes)", len(data))
+	}
+	if string(data[bootpHeaderLength:bootpHeaderLength+4]) != string(magicCookie) {
+		return nil, fmt.Errorf("dhcp magic cookie is missing")
+	}
+
+	p := &Packet{
+		Op:       data[0],
+		XID:      binary.BigEndian.Uint32(data[4:8]),
+		ClientIP: net.IP(data[12:16]),
+		YourIP:   net.IP(data[16:20]),
+		ServerIP: net.IP(data[20:24]),
+		RelayIP:  net.IP(data[24:28]),
+	}
+
+	// Only report the hardware address when its length is sane
+	hlen := int(data[2])
+	if hlen > 0 && hlen <= 16 {
+		p.ClientHardwareAddr = net.HardwareAddr(data[28 : 28+hlen])
+	}
+
+	opts, err := parseOptions(data[bootpHeaderLength+4:])
+	if err != nil {
+		return nil, err
+	}
+
+	// Option 52 moves additional options into the file and sname fields
+	for _, opt := range opts {
+		if opt.Code != OptionOverload || len(opt.Data) != 1 {
+			continue
+		}
+		if opt.Data[0]&1 != 0 {
+			more, err := parseOptions(data[108:236])
+			if err != nil {
+				return nil, fmt.Errorf("file field: %s", err)
+			}
+			opts = append(opts, more...)
+		}
+		if opt.Data[0]&2 != 0 {
+			more, err := parseOptions(data[44:108])
+			if err != nil {
+				return nil, fmt.Errorf("sname field: %s", err)
+			}
+			opts = append(opts, more...)
+		}
+		break
+	}
+
+	p.Options = opts
+	p.decodeOptions()
+	return p, nil
+}
+
+// parseOptions splits an option area into individual options. Repeated options are
+// concatenated as described in RFC 3396.
+func parseOptions(data []byte) ([]*Option, error) {
+	var opts []*Option
+	seen := make(map[uint8]*Option)
+
+	for i := 0; i < len(data); {
+		code := data[i]
+		if code == OptionEnd {
+			break
+		}
+		if code == OptionPad {
+			i++
+			continue
+		}
+		if i+1 >= len(data) {
+			return nil, fmt.Errorf("option %d is truncated", code)
+		}
+		olen := int(data[i+1])
+		if i+2+olen > len(data) {
+			return nil, fmt.Errorf("option %d length %d exceeds the packet", code, olen)
+		}
+		value := data[i+2 : i+2+olen]
+		i += 2 + olen
+
+		if opt, ok := seen[code]; ok {
+			opt.Data = append(opt.Data, value...)
+			continue
+		}
+		opt := &Option{Code: code, Data: append([]byte(nil), value...)}
+		seen[code] = opt
+		opts = append(opts, opt)
+	}
+
+	return opts, nil
+}
+
+// decodeOptions populates the named fields from the raw options
+func (p *Packet) decodeOptions() {
+	for _, opt := range p.Options {
+		switch opt.Code {
+		case OptionMessageType:
+			if len(opt.Data) == 1 {
+				p.MessageType = opt.Data[0]
+			}
+		case OptionVendorClass:
+			p.VendorClass = trimNul(opt.Data)
+		case OptionHostname:
+			p.Hostname = trimNul(opt.Data)
+		case OptionDomainName:
+			p.DomainName = trimNul(opt.Data)
+		case OptionParameterRequestList:
+			p.ParameterRequestList = opt.Data
+		case OptionClientIdentifier:
+			p.ClientIdentifier = opt.Data
+		case OptionUserClass:
+			p.UserClass = opt.Data
+		case OptionRequestedIP:
+			if len(opt.Data) == 4 {
+				p.RequestedIP = net.IP(opt.Data)
+			}
+		case OptionMaxMessageSize:
+			if len(opt.Data) == 2 {
+				p.MaxMessageSize = binary.BigEndian.Uint16(opt.Data)
+			}
+		case OptionClientFQDN:
+			// Flags and two deprecated RCODE bytes precede the name
+			if len(opt.Data) > 3 {
+				p.ClientFQDN = decodeFQDN(opt.Data[0], opt.Data[3:])
+			}
+		}
+	}
+}
+
+// decodeFQDN returns the client FQDN, which is wire encoded when the E flag is set
+func decodeFQDN(flags uint8, data []byte) string {
+	if flags&0x04 == 0 {
+		return trimNul(data)
+	}
+
+	var labels []string
+	for i := 0; i < len(data); {
+		l := int(data[i])
+		if l == 0 || i+1+l > len(data) {
+			break
+		}
+		labels = append(labels, string(data[i+1:i+1+l]))
+		i += 1 + l
+	}
+	return strings.Join(labels, ".")
+}
+
+// trimNul strips the trailing NUL bytes some clients include in string options
+func trimNul(data []byte) string {
+	return strings.TrimRight(string(data), "\x00")
+}
+
+// Result is a decoded DHCP message together with the vendor class matches
+type Result struct {
+	Packet  *Packet
+	Matches []*recog.FingerprintMatch
+}
+
+// Match decodes a DHCP message and matches its vendor class identifier against the
+// dhcp_vendor_class database in the provided FingerprintSet. Messages without a
+// vendor class are decoded but produce no matches.
+func Match(fs *recog.FingerprintSet, data []byte) (*Result, error) {
+	p, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{Packet: p}
+	if p.VendorClass == "" {
+		return res, nil
+	}
+
+	res.Matches, err = fs.MatchAll(MatchKey, p.VendorClass)
+	if err != nil {
+		return res, err
+	}
+	return res, nil
+}
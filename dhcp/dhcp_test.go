@@ -0,0 +1,99 @@
+package dhcp
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+func loadFixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile("testdata/" + name + ".hex")
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %s", name, err)
+	}
+	raw, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("bad fixture %s: %s", name, err)
+	}
+	return raw
+}
+
+func TestParse(t *testing.T) {
+	p, err := Parse(loadFixture(t, "discover_windows"))
+	if err != nil {
+		t.Fatalf("Parse() failed: %s", err)
+	}
+
+	if p.MessageType != 1 || p.VendorClass != "MSFT 5.0" || p.Hostname != "DESKTOP-4ABC123" {
+		t.Errorf("unexpected decoded fields: type=%d vendor=%q hostname=%q", p.MessageType, p.VendorClass, p.Hostname)
+	}
+	if p.ClientHardwareAddr.String() != "00:11:22:33:44:55" {
+		t.Errorf("unexpected client hardware address %s", p.ClientHardwareAddr)
+	}
+	if p.ParameterRequestListString() != "1,3,6,15,31,33,43,44,46,47,119,121,249,252" {
+		t.Errorf("unexpected parameter request list %s", p.ParameterRequestListString())
+	}
+	if p.ClientFQDN != "DESKTOP-4ABC123" || p.RequestedIP.String() != "192.168.1.100" {
+		t.Errorf("unexpected fqdn %q or requested ip %s", p.ClientFQDN, p.RequestedIP)
+	}
+}
+
+func TestParseOverload(t *testing.T) {
+	p, err := Parse(loadFixture(t, "inform_overload"))
+	if err != nil {
+		t.Fatalf("Parse() failed: %s", err)
+	}
+
+	if p.VendorClass != "Cisco Systems, Inc." || p.Hostname != "sw-01" {
+		t.Errorf("overloaded options were not decoded: vendor=%q hostname=%q", p.VendorClass, p.Hostname)
+	}
+	if p.ClientFQDN != "printer.example.com" {
+		t.Errorf("unexpected wire encoded fqdn %q", p.ClientFQDN)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := Parse([]byte{1, 1, 6, 0}); err == nil {
+		t.Errorf("Parse() accepted a truncated message")
+	}
+
+	data := loadFixture(t, "discover_windows")
+	data[bootpHeaderLength] = 0
+	if _, err := Parse(data); err == nil {
+		t.Errorf("Parse() accepted a message without the magic cookie")
+	}
+
+	data = loadFixture(t, "discover_windows")
+	if _, err := Parse(append(data[:bootpHeaderLength+4], OptionVendorClass, 40, 'M')); err == nil {
+		t.Errorf("Parse() accepted a truncated option")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	fset, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	res, err := Match(fset, loadFixture(t, "discover_windows"))
+	if err != nil {
+		t.Fatalf("Match() failed: %s", err)
+	}
+	if len(res.Matches) == 0 || res.Matches[0].Values["os.family"] != "Windows" {
+		t.Errorf("Match() did not identify the Windows vendor class: %v", res.Matches)
+	}
+
+	res, err = Match(fset, loadFixture(t, "request_android"))
+	if err != nil {
+		t.Fatalf("Match() failed: %s", err)
+	}
+	if res.Packet.Hostname != "Galaxy-S8" || res.Packet.MaxMessageSize != 1500 {
+		t.Errorf("unexpected decoded fields: hostname=%q max=%d", res.Packet.Hostname, res.Packet.MaxMessageSize)
+	}
+	if len(res.Matches) == 0 || res.Matches[0].Values["os.version"] != "7.1.1" {
+		t.Errorf("Match() did not identify the Android vendor class: %v", res.Matches)
+	}
+}
@@ -0,0 +1,216 @@
+package recog
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// minExplainLiteral is the shortest literal considered when comparing a pattern
+// with the input, since shorter ones match almost anything
+const minExplainLiteral = 3
+
+// Explanation describes how close a fingerprint came to matching an input
+type Explanation struct {
+	Fingerprint *Fingerprint
+	Matched     bool
+	// Prefix is the longest leading part of the pattern that matches the input,
+	// and Remaining is the element where matching stopped
+	Prefix    string
+	Remaining string
+	// Elements is the number of top-level pattern elements, of which Consumed
+	// matched. StoppedAt is the input offset where the matched prefix ends.
+	Elements  int
+	Consumed  int
+	StoppedAt int
+	// Literals lists the fixed strings of the pattern found in the input, and
+	// MissingLiterals those that were not
+	Literals        []string
+	MissingLiterals []string
+	// Errors holds the parameter extraction errors of a successful match
+	Errors []error
+	// Score ranks explanations from 0 to 1, with 1 for a match
+	Score float64
+}
+
+// Progress returns the fraction of the pattern elements that matched
+func (e *Explanation) Progress() float64 {
+	if e.Matched {
+		return 1
+	}
+	if e.Elements == 0 {
+		return 0
+	}
+	return float64(e.Consumed) / float64(e.Elements)
+}
+
+// LiteralScore returns the fraction of literal content found in the input
+func (e *Explanation) LiteralScore() float64 {
+	found, total := 0, 0
+	for _, l := range e.Literals {
+		found += len(l)
+		total += len(l)
+	}
+	for _, l := range e.MissingLiterals {
+		total += len(l)
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(found) / float64(total)
+}
+
+// Explain compares the fingerprint with an input, reporting how far the pattern
+// got if it does not match
+func (fp *Fingerprint) Explain(data string) *Explanation {
+	e := &Explanation{Fingerprint: fp}
+	if m := fp.Match(data); m != nil {
+		e.Matched = true
+		e.Prefix = fp.Pattern
+		e.Errors = m.Errors
+		e.Score = 1
+		return e
+	}
+
+	parsed, err := syntax.Parse(fp.Pattern, fp.syntaxFlags())
+	if err != nil {
+		return e
+	}
+
+	// Alternations are explained by whichever branch gets furthest
+	for _, elements := range patternBranches(parsed) {
+		c := explainPrefix(elements, data)
+		if e.Elements == 0 || c.Progress() > e.Progress() {
+			c.Fingerprint = fp
+			*e = *c
+		}
+	}
+
+	e.Literals, e.MissingLiterals = compareLiterals(parsed, data)
+	e.Score = (e.Progress() + e.LiteralScore()) / 2
+	return e
+}
+
+// patternBranches splits a pattern into lists of top-level elements, one per branch
+// of an outer alternation, looking through groups that wrap the whole pattern
+func patternBranches(re *syntax.Regexp) [][]*syntax.Regexp {
+	for (re.Op == syntax.OpCapture || re.Op == syntax.OpConcat) && len(re.Sub) == 1 {
+		re = re.Sub[0]
+	}
+	switch re.Op {
+	case syntax.OpConcat:
+		return [][]*syntax.Regexp{re.Sub}
+	case syntax.OpAlternate:
+		var res [][]*syntax.Regexp
+		for _, sub := range re.Sub {
+			res = append(res, patternBranches(sub)...)
+		}
+		return res
+	}
+	return [][]*syntax.Regexp{{re}}
+}
+
+// explainPrefix finds the longest run of leading elements that matches the input.
+// A longer prefix can only match where a shorter one does, so the search stops at
+// the first failure.
+func explainPrefix(elements []*syntax.Regexp, data string) *Explanation {
+	e := &Explanation{Elements: len(elements)}
+	if len(elements) > 0 {
+		e.Remaining = elements[0].String()
+	}
+	for k := 1; k <= len(elements); k++ {
+		prefix := &syntax.Regexp{Op: syntax.OpConcat, Sub: elements[:k]}
+		re, err := regexp.Compile(prefix.String())
+		if err != nil {
+			break
+		}
+		loc := re.FindStringIndex(data)
+		if loc == nil {
+			break
+		}
+		e.Consumed = k
+		e.Prefix = prefix.String()
+		e.StoppedAt = loc[1]
+		e.Remaining = ""
+		if k < len(elements) {
+			e.Remaining = elements[k].String()
+		}
+	}
+	return e
+}
+
+// compareLiterals splits the literal strings of a pattern into those found in the
+// input and those missing from it
+func compareLiterals(re *syntax.Regexp, data string) (found []string, missing []string) {
+	lower := strings.ToLower(data)
+	var walk func(*syntax.Regexp)
+	walk = func(re *syntax.Regexp) {
+		if re.Op == syntax.OpLiteral {
+			lit := string(re.Rune)
+			if utf8.RuneCountInString(lit) < minExplainLiteral {
+				return
+			}
+			if re.Flags&syntax.FoldCase != 0 {
+				if strings.Contains(lower, strings.ToLower(lit)) {
+					found = append(found, lit)
+					return
+				}
+			} else if strings.Contains(data, lit) {
+				found = append(found, lit)
+				return
+			}
+			missing = append(missing, lit)
+			return
+		}
+		for _, sub := range re.Sub {
+			walk(sub)
+		}
+	}
+	walk(re)
+	return found, missing
+}
+
+// Explain returns an explanation for every fingerprint in the database, closest
+// first, keeping the database order for ties
+func (fdb *FingerprintDB) Explain(data string) []*Explanation {
+	res := make([]*Explanation, 0, len(fdb.Fingerprints))
+	for i := range fdb.Fingerprints {
+		res = append(res, fdb.Fingerprints[i].Explain(data))
+	}
+	sortExplanations(res)
+	return res
+}
+
+// sortExplanations orders explanations by descending score
+func sortExplanations(res []*Explanation) {
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Score > res[j].Score
+	})
+}
+
+// Explain returns up to limit of the fingerprints closest to matching the input
+// for a match key, after the set's normalization and size limit are applied. A
+// limit of zero returns them all.
+func (fs *FingerprintSet) Explain(name string, data string, limit int) ([]*Explanation, error) {
+	fdbs, err := fs.databases(name)
+	if err != nil {
+		return nil, err
+	}
+	input, _, err := fs.limitString(data)
+	if err != nil {
+		return nil, err
+	}
+	input = fs.Normalize(name, input)
+
+	var res []*Explanation
+	for _, fdb := range fdbs {
+		res = append(res, fdb.Explain(input)...)
+	}
+	sortExplanations(res)
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
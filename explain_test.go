@@ -0,0 +1,77 @@
+package recog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	fset, err := LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	res, err := fset.Explain("http_header.server", "Microsoft-IIS/ten", 3)
+	if err != nil {
+		t.Fatalf("Explain() failed: %s", err)
+	}
+	if len(res) != 3 {
+		t.Fatalf("Explain() returned %d explanations", len(res))
+	}
+	for i, e := range res {
+		if e.Matched {
+			t.Errorf("Explain() reported a match for %s", e.Fingerprint.Pattern)
+		}
+		if i > 0 && e.Score > res[i-1].Score {
+			t.Errorf("Explain() did not sort by score")
+		}
+		if !strings.HasPrefix(e.Fingerprint.Description.Text, "Microsoft IIS") {
+			t.Errorf("Explain() returned an unrelated fingerprint: %s", e.Fingerprint.Description.Text)
+		}
+		if e.Consumed == 0 || e.Remaining == "" || e.StoppedAt < len("Microsoft-IIS") {
+			t.Errorf("Explain() did not report where %s stopped: %+v", e.Fingerprint.Pattern, e)
+		}
+		if len(e.Literals) == 0 {
+			t.Errorf("Explain() found no shared literals for %s", e.Fingerprint.Pattern)
+		}
+	}
+
+	// A matching input ranks its fingerprint first
+	res, err = fset.Explain("http_header.server", "Microsoft-IIS/10.0", 1)
+	if err != nil {
+		t.Fatalf("Explain() failed: %s", err)
+	}
+	if len(res) != 1 || !res[0].Matched || res[0].Score != 1 || res[0].Progress() != 1 {
+		t.Errorf("Explain() did not report the match: %+v", res[0])
+	}
+
+	if _, err := fset.Explain("missing", "x", 1); err == nil {
+		t.Errorf("Explain() accepted a missing database")
+	}
+}
+
+func TestExplainParamErrors(t *testing.T) {
+	fp := &Fingerprint{
+		Pattern: `^Widget/(\d+)$`,
+		Params: []*FingerprintParam{
+			{Position: "1", Name: "service.version"},
+			{Position: "2", Name: "service.build"},
+		},
+	}
+	if err := fp.Normalize(); err != nil {
+		t.Fatalf("Normalize() failed: %s", err)
+	}
+
+	e := fp.Explain("Widget/12")
+	if !e.Matched || len(e.Errors) != 1 {
+		t.Errorf("Explain() did not report the param error: %+v", e)
+	}
+
+	e = fp.Explain("Widget/beta")
+	if e.Matched || e.Consumed != 2 || e.StoppedAt != len("Widget/") || e.Remaining != `([0-9]+)` {
+		t.Errorf("Explain() reported the wrong stopping point: %+v", e)
+	}
+	if len(e.Literals) != 1 || e.Literals[0] != "Widget/" || e.LiteralScore() != 1 {
+		t.Errorf("Explain() reported the wrong literals: %+v", e)
+	}
+}
@@ -0,0 +1,244 @@
+package recog
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExportGraph is a match graph to serialize, named after the input it describes
+type ExportGraph struct {
+	Name  string
+	Nodes []*MatchNode
+	Edges []*MatchEdge
+}
+
+// GraphJSON is the JSON form of a match graph:
+//
+//	{
+//	  "name": "Apache/2.4.41 (Ubuntu)",
+//	  "nodes": [{
+//	    "id": "6f0c...", "database": "http_servers.xml", "match_key": "http_header.server",
+//	    "database_type": "service", "description": "Apache", "certainty": 0.85,
+//	    "values": {"service.product": "HTTPD", ...}
+//	  }],
+//	  "edges": [{"parent": "6f0c...", "child": "91ab...", "key": "apache.info", "value": "(Ubuntu)"}]
+//	}
+//
+// Certainty is omitted when the fingerprint does not set a numeric one, and
+// suppressed is only set for matches of suppressing fingerprints.
+type GraphJSON struct {
+	Name  string     `json:"name,omitempty"`
+	Nodes []NodeJSON `json:"nodes"`
+	Edges []EdgeJSON `json:"edges"`
+}
+
+// NodeJSON is the JSON form of a MatchNode
+type NodeJSON struct {
+	ID           string            `json:"id"`
+	Database     string            `json:"database"`
+	MatchKey     string            `json:"match_key,omitempty"`
+	DatabaseType string            `json:"database_type,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Certainty    *float64          `json:"certainty,omitempty"`
+	Suppressed   bool              `json:"suppressed,omitempty"`
+	Values       map[string]string `json:"values"`
+}
+
+// EdgeJSON is the JSON form of a MatchEdge
+type EdgeJSON struct {
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+	Key    string `json:"key,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+// nodeDescription returns the description of the fingerprint behind a node
+func nodeDescription(node *MatchNode) string {
+	if fp := node.Match.Fingerprint; fp != nil && fp.Description != nil {
+		return fp.Description.Text
+	}
+	return ""
+}
+
+// nodeCertainty returns the certainty of a node, if it is numeric
+func nodeCertainty(node *MatchNode) (float64, bool) {
+	c, err := strconv.ParseFloat(node.Match.Values["fp.certainty"], 64)
+	return c, err == nil
+}
+
+// NewGraphJSON converts a match graph to its JSON form
+func NewGraphJSON(g ExportGraph) *GraphJSON {
+	res := &GraphJSON{Name: g.Name, Nodes: make([]NodeJSON, 0, len(g.Nodes)), Edges: make([]EdgeJSON, 0, len(g.Edges))}
+	for _, node := range g.Nodes {
+		n := NodeJSON{
+			ID:          node.Id.String(),
+			Description: nodeDescription(node),
+			Suppressed:  node.Match.Suppressed,
+			Values:      node.Match.Values,
+		}
+		if fp := node.Match.Fingerprint; fp != nil && fp.DB != nil {
+			fdb := fp.DB
+			n.Database = fdb.Name
+			n.MatchKey = fdb.Matches
+			n.DatabaseType = fdb.DatabaseType
+		}
+		if c, ok := nodeCertainty(node); ok {
+			n.Certainty = &c
+		}
+		res.Nodes = append(res.Nodes, n)
+	}
+	for _, edge := range g.Edges {
+		res.Edges = append(res.Edges, EdgeJSON{Parent: edge.ParentId.String(), Child: edge.ChildId.String(), Key: edge.Key, Value: edge.Value})
+	}
+	return res
+}
+
+// WriteJSON writes each graph as a GraphJSON document on its own line
+func WriteJSON(w io.Writer, graphs ...ExportGraph) error {
+	enc := json.NewEncoder(w)
+	for _, g := range graphs {
+		if err := enc.Encode(NewGraphJSON(g)); err != nil {
+			return fmt.Errorf("failed to encode graph: %s", err)
+		}
+	}
+	return nil
+}
+
+// dotQuote quotes a string as a DOT identifier
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// WriteDOT writes each graph as a Graphviz digraph. Nodes are labeled with their
+// database, description and certainty, and edges with the routed value key.
+func WriteDOT(w io.Writer, graphs ...ExportGraph) error {
+	var b strings.Builder
+	for _, g := range graphs {
+		fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.Name))
+		b.WriteString("  node [shape=box];\n")
+		for _, n := range NewGraphJSON(g).Nodes {
+			label := n.Database + "\n" + n.Description
+			if n.Certainty != nil {
+				label += fmt.Sprintf("\ncertainty %.2f", *n.Certainty)
+			}
+			if n.Suppressed {
+				label += "\nsuppressed"
+			}
+			fmt.Fprintf(&b, "  %s [label=%s];\n", dotQuote(n.ID), dotQuote(label))
+		}
+		for _, edge := range g.Edges {
+			fmt.Fprintf(&b, "  %s -> %s", dotQuote(edge.ParentId.String()), dotQuote(edge.ChildId.String()))
+			if edge.Key != "" {
+				fmt.Fprintf(&b, " [label=%s]", dotQuote(edge.Key))
+			}
+			b.WriteString(";\n")
+		}
+		b.WriteString("}\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// graphMLKeys declares the attributes of GraphML nodes and edges
+var graphMLKeys = []graphMLKey{
+	{ID: "database", For: "node", Name: "database", Type: "string"},
+	{ID: "match_key", For: "node", Name: "match_key", Type: "string"},
+	{ID: "database_type", For: "node", Name: "database_type", Type: "string"},
+	{ID: "description", For: "node", Name: "description", Type: "string"},
+	{ID: "certainty", For: "node", Name: "certainty", Type: "double"},
+	{ID: "values", For: "node", Name: "values", Type: "string"},
+	{ID: "suppressed", For: "node", Name: "suppressed", Type: "boolean"},
+	{ID: "key", For: "edge", Name: "key", Type: "string"},
+	{ID: "value", For: "edge", Name: "value", Type: "string"},
+}
+
+type graphMLDocument struct {
+	XMLName xml.Name       `xml:"graphml"`
+	Xmlns   string         `xml:"xmlns,attr"`
+	Keys    []graphMLKey   `xml:"key"`
+	Graphs  []graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data,omitempty"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the graphs as a single GraphML document with one graph
+// element each. Node values are stored as a JSON object.
+func WriteGraphML(w io.Writer, graphs ...ExportGraph) error {
+	doc := graphMLDocument{Xmlns: "http://graphml.graphdrawing.org/xmlns", Keys: graphMLKeys}
+	for i, g := range graphs {
+		// Graph names are free text, so number the graphs instead
+		graph := graphMLGraph{ID: fmt.Sprintf("g%d", i), EdgeDefault: "directed"}
+		for _, n := range NewGraphJSON(g).Nodes {
+			values, err := json.Marshal(n.Values)
+			if err != nil {
+				return fmt.Errorf("failed to encode values: %s", err)
+			}
+			node := graphMLNode{ID: n.ID, Data: []graphMLData{
+				{Key: "database", Value: n.Database},
+				{Key: "match_key", Value: n.MatchKey},
+				{Key: "database_type", Value: n.DatabaseType},
+				{Key: "description", Value: n.Description},
+			}}
+			if n.Certainty != nil {
+				node.Data = append(node.Data, graphMLData{Key: "certainty", Value: strconv.FormatFloat(*n.Certainty, 'g', -1, 64)})
+			}
+			node.Data = append(node.Data, graphMLData{Key: "values", Value: string(values)})
+			if n.Suppressed {
+				node.Data = append(node.Data, graphMLData{Key: "suppressed", Value: "true"})
+			}
+			graph.Nodes = append(graph.Nodes, node)
+		}
+		for _, edge := range g.Edges {
+			e := graphMLEdge{Source: edge.ParentId.String(), Target: edge.ChildId.String()}
+			if edge.Key != "" {
+				e.Data = []graphMLData{{Key: "key", Value: edge.Key}, {Key: "value", Value: edge.Value}}
+			}
+			graph.Edges = append(graph.Edges, e)
+		}
+		doc.Graphs = append(doc.Graphs, graph)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode graphml: %s", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,179 @@
+package recog
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Fact is a consolidated value and the node it was taken from
+type Fact struct {
+	Key   string
+	Value string
+	Node  *MatchNode
+	// Certainty is the namespace certainty of the node, such as os.certainty for
+	// os.* keys, falling back to fp.certainty
+	Certainty float64
+	// Preference is the preference of the node's database
+	Preference float64
+	// Alternatives holds the other values found for the key, which lost to this one
+	Alternatives []*Fact
+}
+
+// FactSet maps each value key of a graph to its consolidated fact
+type FactSet map[string]*Fact
+
+// Values returns the fact values as a plain map
+func (fs FactSet) Values() map[string]string {
+	res := make(map[string]string, len(fs))
+	for k, f := range fs {
+		res[k] = f.Value
+	}
+	return res
+}
+
+// Keys returns the fact keys in sorted order
+func (fs FactSet) Keys() []string {
+	keys := make([]string, 0, len(fs))
+	for k := range fs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isFactMetadata reports whether a key describes a match rather than the target.
+// Certainty keys such as fp.certainty and os.certainty are reported through
+// Fact.Certainty instead.
+func isFactMetadata(key string) bool {
+	return key == "matched" || strings.HasSuffix(key, ".certainty")
+}
+
+// ResolveFacts consolidates the values of a traversal, see MatchGraph.Facts
+func ResolveFacts(nodes []*MatchNode, edges []*MatchEdge) FactSet {
+	g := &MatchGraph{Nodes: nodes, Edges: edges}
+	return g.Facts()
+}
+
+// Facts merges the values of every node into one fact per key. A node's values
+// refine those of its ancestors, so only the values no descendant refines are
+// considered, and of those the value is chosen by certainty, then database
+// preference, then the order of the nodes. Suppressed nodes contribute no facts.
+func (g *MatchGraph) Facts() FactSet {
+	ancestors := g.ancestors()
+	candidates := make(map[string][]*Fact)
+	for _, node := range g.Nodes {
+		// suppressed matches are known but must not be attributed
+		if node.Match.Suppressed {
+			continue
+		}
+		for k, v := range node.Match.Values {
+			if isFactMetadata(k) {
+				continue
+			}
+			candidates[k] = append(candidates[k], &Fact{
+				Key:        k,
+				Value:      v,
+				Node:       node,
+				Certainty:  nodeKeyCertainty(node, k),
+				Preference: nodePreference(node),
+			})
+		}
+	}
+
+	res := make(FactSet, len(candidates))
+	for k, facts := range candidates {
+		var best *Fact
+		for _, f := range facts {
+			if refined(f, facts, ancestors) {
+				continue
+			}
+			if best == nil || outranks(f, best) {
+				best = f
+			}
+		}
+		// every candidate is refined only in cyclic graphs not built by traversal
+		if best == nil {
+			best = facts[0]
+		}
+		seen := map[string]bool{best.Value: true}
+		for _, f := range facts {
+			if !seen[f.Value] {
+				seen[f.Value] = true
+				best.Alternatives = append(best.Alternatives, f)
+			}
+		}
+		res[k] = best
+	}
+	return res
+}
+
+// refined reports whether a descendant of the fact's node gives the key a value
+func refined(f *Fact, facts []*Fact, ancestors map[uuid.UUID]map[uuid.UUID]bool) bool {
+	for _, other := range facts {
+		if other != f && ancestors[other.Node.Id][f.Node.Id] {
+			return true
+		}
+	}
+	return false
+}
+
+// outranks reports whether fact a should be chosen over the unrelated fact b
+func outranks(a *Fact, b *Fact) bool {
+	if a.Certainty != b.Certainty {
+		return a.Certainty > b.Certainty
+	}
+	return a.Preference > b.Preference
+}
+
+// ancestors returns the set of ancestors of every node
+func (g *MatchGraph) ancestors() map[uuid.UUID]map[uuid.UUID]bool {
+	parents := make(map[uuid.UUID][]uuid.UUID)
+	for _, edge := range g.Edges {
+		parents[edge.ChildId] = append(parents[edge.ChildId], edge.ParentId)
+	}
+
+	res := make(map[uuid.UUID]map[uuid.UUID]bool, len(g.Nodes))
+	var visit func(id uuid.UUID) map[uuid.UUID]bool
+	visit = func(id uuid.UUID) map[uuid.UUID]bool {
+		if a, ok := res[id]; ok {
+			return a
+		}
+		a := make(map[uuid.UUID]bool)
+		// guard against cycles in graphs that were not built by traversal
+		res[id] = a
+		for _, p := range parents[id] {
+			a[p] = true
+			for pp := range visit(p) {
+				a[pp] = true
+			}
+		}
+		return a
+	}
+	for _, node := range g.Nodes {
+		visit(node.Id)
+	}
+	return res
+}
+
+// nodeKeyCertainty returns the certainty a node gives a key
+func nodeKeyCertainty(node *MatchNode, key string) float64 {
+	if i := strings.Index(key, "."); i > 0 {
+		if c, err := strconv.ParseFloat(node.Match.Values[key[:i]+".certainty"], 64); err == nil {
+			return c
+		}
+	}
+	c, _ := nodeCertainty(node)
+	return c
+}
+
+// nodePreference returns the preference of a node's database
+func nodePreference(node *MatchNode) float64 {
+	if fp := node.Match.Fingerprint; fp != nil && fp.DB != nil {
+		p, _ := strconv.ParseFloat(fp.DB.Preference, 64)
+		return p
+	}
+	return 0
+}
@@ -1,7 +1,9 @@
 package recog
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
@@ -11,6 +13,9 @@ import (
 	"regexp/syntax"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -46,12 +51,17 @@ type Fingerprint struct {
 	Certainty       string                  `xml:"certainty,attr,omitempty" json:"certainty,omitempty"`
 	PatternCompiled *regexp.Regexp          `xml:"-" json:"-"`
 	DB              *FingerprintDB          `xml:"-" json:"-"`
+	// Index is the position of the fingerprint within its database
+	Index int `xml:"-" json:"-"`
+	// Suppress marks a known proxy, honeypot or placeholder banner. Its match
+	// stops identification and is returned alone, marked as Suppressed.
+	Suppress bool `xml:"suppress,attr,omitempty" json:"suppress,omitempty"`
 }
 
 var flagsPattern = regexp.MustCompile("[|,]")
 
-// Normalize processes a fingerprint to make it easier to use
-func (fp *Fingerprint) Normalize() error {
+// syntaxFlags returns the regular expression flags for the fingerprint
+func (fp *Fingerprint) syntaxFlags() syntax.Flags {
 	// Recog uses PCRE so set the Perl compatibility flag here
 	flags := syntax.PerlX
 	flagStrings := flagsPattern.Split(fp.Flags, -1)
@@ -65,17 +75,21 @@ func (fp *Fingerprint) Normalize() error {
 		}
 	}
 
-	// Workaround for recog #209 (use of \u0000 in telnet_banners.xml)
-	fp.Pattern = strings.Replace(fp.Pattern, "\\u0000", "\\x00", -1)
-
 	// Using (?m) also implies (?s), set the option
 	// Note: Ruby does not support explicit '(?s)'
 	if strings.HasPrefix(fp.Pattern, "(?m)") {
 		flags |= syntax.MatchNL
 	}
+	return flags
+}
+
+// Normalize processes a fingerprint to make it easier to use
+func (fp *Fingerprint) Normalize() error {
+	// Workaround for recog #209 (use of \u0000 in telnet_banners.xml)
+	fp.Pattern = strings.Replace(fp.Pattern, "\\u0000", "\\x00", -1)
 
 	// Parse the regular expression
-	parsed, err := syntax.Parse(fp.Pattern, flags)
+	parsed, err := syntax.Parse(fp.Pattern, fp.syntaxFlags())
 	if err != nil {
 		return fmt.Errorf("bad regexp syntax [%s]: %s", fp.Pattern, err)
 	}
@@ -108,10 +122,35 @@ func (fp *Fingerprint) Match(data string) *FingerprintMatch {
 	if len(matches) == 0 {
 		return nil
 	}
+	return fp.newMatch(data, matches)
+}
 
+// MatchBytes matches a fingerprint against binary data without converting the
+// input to a string. Only the captured parameters are copied, byte for byte, and
+// the input is left for the caller to record.
+func (fp *Fingerprint) MatchBytes(data []byte) *FingerprintMatch {
+	loc := fp.PatternCompiled.FindSubmatchIndex(data)
+	if loc == nil {
+		return nil
+	}
+
+	// The full match at index zero is never used as a parameter
+	matches := make([]string, len(loc)/2)
+	for i := 1; i < len(matches); i++ {
+		if loc[2*i] >= 0 {
+			matches[i] = string(data[loc[2*i]:loc[2*i+1]])
+		}
+	}
+	return fp.newMatch("", matches)
+}
+
+// newMatch builds the match result from the regular expression submatches
+func (fp *Fingerprint) newMatch(data string, matches []string) *FingerprintMatch {
 	res := &FingerprintMatch{
 		Fingerprint: fp,
 		Values:      make(map[string]string),
+		Input:       data,
+		Suppressed:  fp.Suppress,
 	}
 
 	// Set the certainty if available
@@ -176,9 +215,10 @@ func (fp *Fingerprint) Match(data string) *FingerprintMatch {
 		res.Values[k] = strings.TrimSpace(nv)
 	}
 
-	// Remove temporary params (_tmp.00x) from results
+	// Remove temporary params (_tmp.00x) from results, and anything a suppressed
+	// match would attribute beyond its description and certainty
 	for k := range res.Values {
-		if strings.HasPrefix(k, "_tmp.") {
+		if strings.HasPrefix(k, "_tmp.") || (fp.Suppress && k != "matched" && !strings.HasPrefix(k, "fp.")) {
 			delete(res.Values, k)
 		}
 	}
@@ -192,35 +232,36 @@ var spacePat = regexp.MustCompile(`\s+`)
 func (fp *Fingerprint) VerifyExamples(fpath string) error {
 	for _, ex := range fp.Examples {
 
-		exampleData := ex.Text
+		exampleData := []byte(ex.Text)
 
 		datafile, found := ex.AttributeMap["_filename"]
 		if found {
 			datafilepath := filepath.Join(fpath, datafile)
-			str, err := os.ReadFile(datafilepath)
+			data, err := os.ReadFile(datafilepath)
 			if err != nil {
 				return fmt.Errorf("external example file: %s: %s (%s)", fp.PatternCompiled.String(), err, datafilepath)
 			}
-			exampleData = string(str)
+			exampleData = data
 		}
 
 		encodingType, found := ex.AttributeMap["_encoding"]
 		if found {
 			switch encodingType {
 			case "base64":
-				exampleData = spacePat.ReplaceAllString(exampleData, "")
-				data, err := base64.StdEncoding.DecodeString(exampleData)
+				encoded := spacePat.ReplaceAll(exampleData, nil)
+				data := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+				n, err := base64.StdEncoding.Decode(data, encoded)
 				if err != nil {
-					return fmt.Errorf("base64: %s: %s (%s)", fp.PatternCompiled.String(), err, exampleData)
+					return fmt.Errorf("base64: %s: %s (%s)", fp.PatternCompiled.String(), err, encoded)
 				}
-				exampleData = string(data)
+				exampleData = data[:n]
 			}
 		}
 
-		escapedData := strings.Replace(exampleData, "\n", "\\n", -1)
+		escapedData := strings.Replace(string(exampleData), "\n", "\\n", -1)
 		escapedData = strings.Replace(escapedData, "\r", "\\r", -1)
 
-		m := fp.Match(exampleData)
+		m := fp.MatchBytes(exampleData)
 		if m == nil {
 			return fmt.Errorf("failed to match '%s' (%s)", fp.PatternCompiled.String(), escapedData)
 		}
@@ -253,6 +294,59 @@ type FingerprintMatch struct {
 	Errors      []error
 	Values      map[string]string
 	Fingerprint *Fingerprint
+	// Input is the data as it was passed in for matching
+	Input string
+	// Normalized is the data that was matched, when a FingerprintSet changed it
+	Normalized string
+	// InputBytes and NormalizedBytes take the place of Input and Normalized for
+	// the binary match methods, sharing the data rather than copying it
+	InputBytes      []byte
+	NormalizedBytes []byte
+	// Truncated is set when the input was cut to the FingerprintSet MaxInputSize
+	Truncated bool
+	// Suppressed is set when the fingerprint is a suppressor, meaning the input is
+	// known but should not be attributed
+	Suppressed bool
+}
+
+// InputText returns the input as a string, converting binary input only when
+// it is asked for
+func (m *FingerprintMatch) InputText() string {
+	if m.Input == "" && m.InputBytes != nil {
+		return string(m.InputBytes)
+	}
+	return m.Input
+}
+
+// HexEncodeCaptures replaces parameters captured from the input that contain
+// non-printable bytes with their hex encoding. Values set by the fingerprint
+// itself are left alone.
+func (m *FingerprintMatch) HexEncodeCaptures() {
+	if m.Fingerprint == nil {
+		return
+	}
+	for _, p := range m.Fingerprint.Params {
+		if p.Position == "0" {
+			continue
+		}
+		if v, ok := m.Values[p.Name]; ok && !isPrintable(v) {
+			m.Values[p.Name] = hex.EncodeToString([]byte(v))
+		}
+	}
+}
+
+// isPrintable reports whether a string is valid UTF-8 made up of printable
+// characters and spaces
+func isPrintable(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) && r != '\t' {
+			return false
+		}
+	}
+	return true
 }
 
 // FingerprintDB represents a fingerprint database
@@ -265,6 +359,13 @@ type FingerprintDB struct {
 	Fingerprints []*Fingerprint `xml:"fingerprint,omitempty" json:"fingerprint,omitempty"`
 	Name         string         `xml:"-" json:"name,omitempty"`
 	Logger       *log.Logger    `json:"-"`
+
+	// HexEncodeCaptures hex-encodes non-printable parameters captured by the
+	// MatchFirstBytes and MatchAllBytes methods
+	HexEncodeCaptures bool `xml:"-" json:"-"`
+	// suppressors are the fingerprints with Suppress set, which are tried before
+	// the rest
+	suppressors []*Fingerprint
 }
 
 // DebugLogf writes an error to the debug log, if enabled
@@ -279,15 +380,39 @@ func (fdb *FingerprintDB) DebugLogf(format string, args ...interface{}) {
 
 // Normalize calls the Normalize function on each loaded Fingerprint
 func (fdb *FingerprintDB) Normalize() error {
-	for _, fp := range fdb.Fingerprints {
+	for i, fp := range fdb.Fingerprints {
 		err := fp.Normalize()
 		if err != nil {
 			fdb.DebugLogf("failed to normalize %s: %s", fdb.Name, err)
 			return err
 		}
 
-		// also set the db reference on each fingerprint
+		// also set the db reference and position on each fingerprint
 		fp.DB = fdb
+		fp.Index = i
+	}
+	fdb.indexSuppressors()
+	return nil
+}
+
+// indexSuppressors collects the suppressing fingerprints
+func (fdb *FingerprintDB) indexSuppressors() {
+	fdb.suppressors = nil
+	for _, fp := range fdb.Fingerprints {
+		if fp.Suppress {
+			fdb.suppressors = append(fdb.suppressors, fp)
+		}
+	}
+}
+
+// suppression returns the match of the first suppressing fingerprint, if any. The
+// data is only used for logging.
+func (fdb *FingerprintDB) suppression(data interface{}, match func(*Fingerprint) *FingerprintMatch) *FingerprintMatch {
+	for _, f := range fdb.suppressors {
+		if m := match(f); m != nil {
+			fdb.DebugLogf("FP-SUPPRESS %q by %#v", data, f.Pattern)
+			return m
+		}
 	}
 	return nil
 }
@@ -307,7 +432,13 @@ func (fdb *FingerprintDB) VerifyExamples(fpath string) error {
 
 // MatchFirst finds the first match for a given string
 func (fdb *FingerprintDB) MatchFirst(data string) *FingerprintMatch {
+	if m := fdb.suppression(data, func(fp *Fingerprint) *FingerprintMatch { return fp.Match(data) }); m != nil {
+		return m
+	}
 	for _, f := range fdb.Fingerprints {
+		if f.Suppress {
+			continue
+		}
 		if m := f.Match(data); m != nil {
 			desc := ""
 			if f.Description != nil {
@@ -321,10 +452,22 @@ func (fdb *FingerprintDB) MatchFirst(data string) *FingerprintMatch {
 	return nil
 }
 
+// MatchFirstBytes finds the first match for binary data
+func (fdb *FingerprintDB) MatchFirstBytes(data []byte) *FingerprintMatch {
+	m, _ := fdb.MatchFirstBytesContext(context.Background(), data)
+	return m
+}
+
 // MatchAll finds all matches for a given string
 func (fdb *FingerprintDB) MatchAll(data string) []*FingerprintMatch {
+	if m := fdb.suppression(data, func(fp *Fingerprint) *FingerprintMatch { return fp.Match(data) }); m != nil {
+		return []*FingerprintMatch{m}
+	}
 	ret := []*FingerprintMatch{}
 	for _, f := range fdb.Fingerprints {
+		if f.Suppress {
+			continue
+		}
 		if m := f.Match(data); m != nil {
 			desc := ""
 			if f.Description != nil {
@@ -340,6 +483,114 @@ func (fdb *FingerprintDB) MatchAll(data string) []*FingerprintMatch {
 	return ret
 }
 
+// MatchAllBytes finds all matches for binary data
+func (fdb *FingerprintDB) MatchAllBytes(data []byte) []*FingerprintMatch {
+	ms, _ := fdb.MatchAllBytesContext(context.Background(), data)
+	return ms
+}
+
+// setBytesInput records binary input on the matches of MatchBytes without copying
+// it. Normalized is nil when the data was matched as is.
+func setBytesInput(matches []*FingerprintMatch, data []byte, normalized []byte) {
+	for _, m := range matches {
+		m.InputBytes = data
+		m.NormalizedBytes = normalized
+	}
+}
+
+// MatchFirstContext finds the first match for a given string, checking the context
+// periodically while fingerprints are tried
+func (fdb *FingerprintDB) MatchFirstContext(ctx context.Context, data string) (*FingerprintMatch, error) {
+	ms, err := fdb.matchContext(ctx, data, true, func(fp *Fingerprint) *FingerprintMatch { return fp.Match(data) })
+	if len(ms) == 0 {
+		return nil, err
+	}
+	return ms[0], err
+}
+
+// MatchAllContext finds all matches for a given string, checking the context
+// periodically while fingerprints are tried. Matches found before the context is
+// done are returned along with the context error.
+func (fdb *FingerprintDB) MatchAllContext(ctx context.Context, data string) ([]*FingerprintMatch, error) {
+	return fdb.matchContext(ctx, data, false, func(fp *Fingerprint) *FingerprintMatch { return fp.Match(data) })
+}
+
+// MatchFirstBytesContext is the binary equivalent of MatchFirstContext
+func (fdb *FingerprintDB) MatchFirstBytesContext(ctx context.Context, data []byte) (*FingerprintMatch, error) {
+	ms, err := fdb.matchBytesContext(ctx, data, true)
+	if len(ms) == 0 {
+		return nil, err
+	}
+	return ms[0], err
+}
+
+// MatchAllBytesContext is the binary equivalent of MatchAllContext
+func (fdb *FingerprintDB) MatchAllBytesContext(ctx context.Context, data []byte) ([]*FingerprintMatch, error) {
+	return fdb.matchBytesContext(ctx, data, false)
+}
+
+// matchBytesContext matches binary data and records it as the input of the matches
+func (fdb *FingerprintDB) matchBytesContext(ctx context.Context, data []byte, first bool) ([]*FingerprintMatch, error) {
+	ms, err := fdb.matchContext(ctx, data, first, func(fp *Fingerprint) *FingerprintMatch { return fp.MatchBytes(data) })
+	setBytesInput(ms, data, nil)
+	if fdb.HexEncodeCaptures {
+		for _, m := range ms {
+			m.HexEncodeCaptures()
+		}
+	}
+	return ms, err
+}
+
+// contextCheckInterval is the number of fingerprints tried between checks of the
+// context and the clock, which would otherwise cost as much as a short match
+const contextCheckInterval = 16
+
+// matchContext tries each fingerprint in turn until the first match, if requested,
+// or until the context is done. A suppressing fingerprint is tried first and its
+// match is returned alone. The data is only used for logging.
+func (fdb *FingerprintDB) matchContext(ctx context.Context, data interface{}, first bool, match func(*Fingerprint) *FingerprintMatch) ([]*FingerprintMatch, error) {
+	if m := fdb.suppression(data, match); m != nil {
+		return []*FingerprintMatch{m}, nil
+	}
+	ret := []*FingerprintMatch{}
+	deadline, hasDeadline := ctx.Deadline()
+	// a context that can never be done, such as context.Background, is not checked
+	checked := ctx.Done() != nil || hasDeadline
+	tried := 0
+	for _, f := range fdb.Fingerprints {
+		if f.Suppress {
+			continue
+		}
+		if checked && tried%contextCheckInterval == 0 {
+			err := ctx.Err()
+			// The deadline timer may not have fired yet, so check the clock as well
+			if err == nil && hasDeadline && !time.Now().Before(deadline) {
+				err = context.DeadlineExceeded
+			}
+			if err != nil {
+				fdb.DebugLogf("FP-ABORT %q: %s", data, err)
+				return ret, err
+			}
+		}
+		tried++
+		if m := match(f); m != nil {
+			desc := ""
+			if f.Description != nil {
+				desc = f.Description.Text
+			}
+			fdb.DebugLogf("FP-MATCH %q to %#v (%s)", data, f.Pattern, desc)
+			ret = append(ret, m)
+			if first {
+				return ret, nil
+			}
+		}
+	}
+	if len(ret) == 0 {
+		fdb.DebugLogf("FP-FAIL %q", data)
+	}
+	return ret, nil
+}
+
 // LoadFingerprintDBFromFile parses a Recog XML file from disk and returns a FingerprintDB
 func LoadFingerprintDBFromFile(fpath string) (FingerprintDB, error) {
 	fdb := FingerprintDB{}
@@ -0,0 +1,410 @@
+// Package fusion combines the fingerprints of many observations of one host, such
+// as its SSH, HTTP, SNMP and SMB banners, into a single inventory. Each observation
+// is traversed and resolved to facts, which are then voted on per layer: the
+// operating system, the hardware, and the service on each port. Values that
+// disagree between observations are reported as contradictions.
+package fusion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	recog "github.com/runZeroInc/recog-go"
+	"github.com/runZeroInc/recog-go/conflict"
+)
+
+// Layers of a host inventory, named after the value namespaces they collect
+const (
+	LayerOS      = "os"
+	LayerHW      = "hw"
+	LayerService = "service"
+)
+
+// Observation is a single input collected from a host
+type Observation struct {
+	// Source names where the input came from, such as "ssh" or "snmp"
+	Source   string
+	MatchKey string
+	Input    string
+	// Port and Transport locate the service that produced the input. Port zero
+	// marks an observation of the host itself.
+	Port      int
+	Transport string
+}
+
+// Evidence is one observation's value for an attribute
+type Evidence struct {
+	Observation *Observation
+	Value       string
+	Certainty   float64
+	Fact        *recog.Fact
+}
+
+// Attribute is the verdict for one key of a layer
+type Attribute struct {
+	Key   string
+	Value string
+	// Confidence is the support for the value, discounted by the support for the
+	// values it was chosen over
+	Confidence float64
+	// Evidence supports the chosen value and Dissent holds the rest
+	Evidence []Evidence
+	Dissent  []Evidence
+}
+
+// Verdict is the combined view of one layer
+type Verdict struct {
+	Layer     string
+	Port      int
+	Transport string
+	// Attributes are keyed by value key, such as os.product
+	Attributes map[string]*Attribute
+	// Confidence is the mean confidence of the attributes
+	Confidence float64
+}
+
+// Value returns the chosen value of a key, or an empty string
+func (v *Verdict) Value(key string) string {
+	if v == nil {
+		return ""
+	}
+	if a, ok := v.Attributes[key]; ok {
+		return a.Value
+	}
+	return ""
+}
+
+// Values returns the chosen values as a plain map
+func (v *Verdict) Values() map[string]string {
+	res := make(map[string]string, len(v.Attributes))
+	for k, a := range v.Attributes {
+		res[k] = a.Value
+	}
+	return res
+}
+
+// Contradiction is an attribute with more than one value among the observations
+type Contradiction struct {
+	Layer     string
+	Port      int
+	Transport string
+	Key       string
+	// Kind tells values that cannot all be true from ones that refine each other
+	Kind conflict.Kind
+	// Chosen is the value of the verdict, and Evidence holds every value found
+	Chosen   string
+	Evidence []Evidence
+}
+
+// ObservationError is an observation that could not be matched
+type ObservationError struct {
+	Observation *Observation
+	Err         error
+}
+
+func (e *ObservationError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Observation.Source, e.Observation.MatchKey, e.Err)
+}
+
+// Result is the inventory of a host
+type Result struct {
+	OS       *Verdict
+	HW       *Verdict
+	Services []*Verdict
+	// Confidence is the mean confidence of the verdicts
+	Confidence     float64
+	Contradictions []Contradiction
+	Errors         []*ObservationError
+}
+
+// Options controls how observations are matched
+type Options struct {
+	Traverse recog.TraverseOptions
+	// Vocabulary classifies contradictions, defaulting to conflict.DefaultVocabulary
+	Vocabulary *conflict.Vocabulary
+}
+
+// Fuse matches every observation and combines the results. Observations that fail
+// to match are reported in Errors, while a context error stops fusion and is
+// returned.
+func Fuse(ctx context.Context, fs *recog.FingerprintSet, observations []Observation, opts Options) (*Result, error) {
+	res := &Result{}
+	vocab := opts.Vocabulary
+	if vocab == nil {
+		vocab = conflict.DefaultVocabulary()
+	}
+	layers := make(map[layerKey][]*record)
+
+	for i := range observations {
+		obs := &observations[i]
+		graph, err := recog.TraverseMatchGraph(ctx, fs, obs.MatchKey, obs.Input, opts.Traverse)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			res.Errors = append(res.Errors, &ObservationError{Observation: obs, Err: err})
+			continue
+		}
+
+		records := make(map[layerKey]*record)
+		for key, fact := range graph.Facts() {
+			lk, ok := observationLayer(obs, key)
+			if !ok {
+				continue
+			}
+			r, ok := records[lk]
+			if !ok {
+				r = &record{values: make(map[string]Evidence)}
+				records[lk] = r
+				layers[lk] = append(layers[lk], r)
+			}
+			r.values[key] = Evidence{Observation: obs, Value: fact.Value, Certainty: fact.Certainty, Fact: fact}
+			if fact.Certainty > r.certainty {
+				r.certainty = fact.Certainty
+			}
+		}
+	}
+
+	// Layers are decided in order so that confidences are summed in a fixed order
+	var verdicts []*Verdict
+	for _, lk := range sortedLayers(layers) {
+		v := decide(lk, layers[lk])
+		for _, key := range sortedKeys(v.Attributes) {
+			attr := v.Attributes[key]
+			v.Confidence += attr.Confidence
+			if len(attr.Dissent) > 0 {
+				evidence := append(append([]Evidence{}, attr.Evidence...), attr.Dissent...)
+				res.Contradictions = append(res.Contradictions, Contradiction{
+					Layer:     lk.layer,
+					Port:      lk.port,
+					Transport: lk.transport,
+					Key:       key,
+					Kind:      classify(vocab, key, evidence),
+					Chosen:    attr.Value,
+					Evidence:  evidence,
+				})
+			}
+		}
+		v.Confidence /= float64(len(v.Attributes))
+		verdicts = append(verdicts, v)
+
+		switch lk.layer {
+		case LayerOS:
+			res.OS = v
+		case LayerHW:
+			res.HW = v
+		default:
+			res.Services = append(res.Services, v)
+		}
+	}
+
+	for _, v := range verdicts {
+		res.Confidence += v.Confidence
+	}
+	if len(verdicts) > 0 {
+		res.Confidence /= float64(len(verdicts))
+	}
+
+	sort.Slice(res.Services, func(i, j int) bool {
+		a, b := res.Services[i], res.Services[j]
+		return layerKey{port: a.Port, transport: a.Transport}.less(layerKey{port: b.Port, transport: b.Transport})
+	})
+	sort.Slice(res.Contradictions, func(i, j int) bool {
+		a, b := res.Contradictions[i], res.Contradictions[j]
+		ak := layerKey{layer: a.Layer, port: a.Port, transport: a.Transport}
+		bk := layerKey{layer: b.Layer, port: b.Port, transport: b.Transport}
+		if ak != bk {
+			return ak.less(bk)
+		}
+		return a.Key < b.Key
+	})
+	return res, nil
+}
+
+// layerKey identifies a verdict
+type layerKey struct {
+	layer     string
+	port      int
+	transport string
+}
+
+// less orders layer keys by layer, port and transport
+func (lk layerKey) less(other layerKey) bool {
+	if lk.layer != other.layer {
+		return lk.layer < other.layer
+	}
+	if lk.port != other.port {
+		return lk.port < other.port
+	}
+	return lk.transport < other.transport
+}
+
+// sortedLayers returns the keys of the layers in order
+func sortedLayers(layers map[layerKey][]*record) []layerKey {
+	keys := make([]layerKey, 0, len(layers))
+	for lk := range layers {
+		keys = append(keys, lk)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j])
+	})
+	return keys
+}
+
+// observationLayer returns the verdict a value key of an observation belongs to.
+// Service values are kept per port, while the host layers are shared.
+func observationLayer(obs *Observation, key string) (layerKey, bool) {
+	i := strings.Index(key, ".")
+	if i < 0 {
+		return layerKey{}, false
+	}
+	switch ns := key[:i]; ns {
+	case LayerOS, LayerHW:
+		return layerKey{layer: ns}, true
+	case LayerService:
+		return layerKey{layer: ns, port: obs.Port, transport: obs.Transport}, true
+	}
+	return layerKey{}, false
+}
+
+// record holds the values one observation gives a layer
+type record struct {
+	values    map[string]Evidence
+	certainty float64
+}
+
+// cluster is a group of records that agree on every key they share
+type cluster struct {
+	values  map[string]Evidence
+	records []*record
+	support float64
+}
+
+// accepts reports whether a record agrees with the cluster
+func (c *cluster) accepts(r *record) bool {
+	for key, e := range r.values {
+		if ce, ok := c.values[key]; ok && normalize(ce.Value) != normalize(e.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// add merges a record into the cluster. Support is the probability that at least
+// one of the records is right, treating their certainties as independent.
+func (c *cluster) add(r *record) {
+	for key, e := range r.values {
+		if _, ok := c.values[key]; !ok {
+			c.values[key] = e
+		}
+	}
+	c.records = append(c.records, r)
+	c.support = 1 - (1-c.support)*(1-r.certainty)
+}
+
+// decide builds the verdict of a layer. Records are grouped into clusters that
+// agree with each other, most certain first, and the values of the best supported
+// cluster are chosen as a whole so that a verdict never mixes, say, a Linux family
+// with a Windows product. Values of the other clusters are reported as dissent.
+func decide(lk layerKey, records []*record) *Verdict {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].certainty > records[j].certainty
+	})
+	var clusters []*cluster
+	for _, r := range records {
+		var target *cluster
+		for _, c := range clusters {
+			if c.accepts(r) {
+				target = c
+				break
+			}
+		}
+		if target == nil {
+			target = &cluster{values: make(map[string]Evidence)}
+			clusters = append(clusters, target)
+		}
+		target.add(r)
+	}
+	best := clusters[0]
+	for _, c := range clusters[1:] {
+		if c.support > best.support {
+			best = c
+		}
+	}
+
+	v := &Verdict{Layer: lk.layer, Port: lk.port, Transport: lk.transport, Attributes: make(map[string]*Attribute)}
+	for key, chosen := range best.values {
+		attr := &Attribute{Key: key, Value: chosen.Value}
+		for _, r := range records {
+			if e, ok := r.values[key]; ok {
+				if normalize(e.Value) == normalize(chosen.Value) {
+					attr.Evidence = append(attr.Evidence, e)
+				} else {
+					attr.Dissent = append(attr.Dissent, e)
+				}
+			}
+		}
+		attr.Confidence = confidence(attr.Evidence, attr.Dissent)
+		v.Attributes[key] = attr
+	}
+	return v
+}
+
+// confidence returns the support for the chosen value, discounted by its share of
+// the support for every value. Dissenting values are each combined by noisy-OR.
+func confidence(evidence []Evidence, dissent []Evidence) float64 {
+	chosen := support(evidence)
+	total := chosen
+	byValue := make(map[string][]Evidence)
+	for _, e := range dissent {
+		byValue[normalize(e.Value)] = append(byValue[normalize(e.Value)], e)
+	}
+	for _, es := range byValue {
+		total += support(es)
+	}
+	if total == 0 {
+		return 0
+	}
+	return chosen * chosen / total
+}
+
+// support returns the probability that at least one piece of evidence is right
+func support(evidence []Evidence) float64 {
+	res := 0.0
+	for _, e := range evidence {
+		res = 1 - (1-res)*(1-e.Certainty)
+	}
+	return res
+}
+
+// normalize compares values without case or surrounding space
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// classify returns the kind of disagreement between the values of evidence
+func classify(vocab *conflict.Vocabulary, key string, evidence []Evidence) conflict.Kind {
+	var values []string
+	seen := make(map[string]bool)
+	for _, e := range evidence {
+		value, _ := vocab.Canonical(key, e.Value)
+		if !seen[normalize(value)] {
+			seen[normalize(value)] = true
+			values = append(values, value)
+		}
+	}
+	kind, _ := vocab.Classify(key, values...)
+	return kind
+}
+
+// sortedKeys returns the keys of a verdict's attributes in order
+func sortedKeys(attrs map[string]*Attribute) []string {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,127 @@
+package fusion
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+	"github.com/runZeroInc/recog-go/conflict"
+)
+
+func TestFuse(t *testing.T) {
+	fs, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("failed to load fingerprints: %s", err)
+	}
+	ssh := Observation{Source: "ssh", MatchKey: "ssh.banner", Input: "OpenSSH_8.9p1 Ubuntu-3ubuntu0.1", Port: 22, Transport: "tcp"}
+	http := Observation{Source: "http", MatchKey: "http_header.server", Input: "Apache/2.4.41 (Ubuntu)", Port: 80, Transport: "tcp"}
+	smb := Observation{Source: "smb", MatchKey: "smb.native_os", Input: "Windows Server 2008 R2 Standard 7601 Service Pack 1", Port: 445, Transport: "tcp"}
+
+	// Agreeing observations
+	res, err := Fuse(context.Background(), fs, []Observation{ssh, http}, Options{})
+	if err != nil {
+		t.Fatalf("fuse failed: %s", err)
+	}
+	if res.OS.Value("os.vendor") != "Ubuntu" || res.OS.Value("os.family") != "Linux" {
+		t.Errorf("unexpected os verdict: %v", res.OS.Values())
+	}
+	if n := len(res.OS.Attributes["os.family"].Evidence); n != 2 {
+		t.Errorf("expected 2 observations of os.family, got %d", n)
+	}
+	// Without dissent the confidence is the combined certainty of the evidence
+	family := res.OS.Attributes["os.family"]
+	if want := 1 - (1-family.Evidence[0].Certainty)*(1-family.Evidence[1].Certainty); math.Abs(family.Confidence-want) > 1e-9 {
+		t.Errorf("expected os.family confidence %f, got %f", want, family.Confidence)
+	}
+	if len(res.Contradictions) != 0 {
+		t.Errorf("expected no contradictions, got %v", res.Contradictions)
+	}
+	if len(res.Services) != 2 || res.Services[0].Port != 22 || res.Services[1].Port != 80 {
+		t.Fatalf("expected services on ports 22 and 80, got %v", res.Services)
+	}
+	if res.Services[0].Value("service.product") != "OpenSSH" || res.Services[1].Value("service.product") != "HTTPD" {
+		t.Errorf("unexpected services: %v %v", res.Services[0].Values(), res.Services[1].Values())
+	}
+	if res.Confidence <= 0 || res.Confidence > 1 {
+		t.Errorf("confidence out of range: %f", res.Confidence)
+	}
+
+	// A conflicting observation is chosen as a whole and the others dissent
+	res, err = Fuse(context.Background(), fs, []Observation{ssh, http, smb, {Source: "x", MatchKey: "missing", Input: "x"}}, Options{})
+	if err != nil {
+		t.Fatalf("fuse failed: %s", err)
+	}
+	if len(res.Errors) != 1 || res.Errors[0].Observation.MatchKey != "missing" {
+		t.Errorf("expected an error for the missing database, got %v", res.Errors)
+	}
+	if res.OS.Value("os.product") != "Windows Server 2008 R2" || res.OS.Value("os.vendor") != "Microsoft" {
+		t.Errorf("unexpected os verdict: %v", res.OS.Values())
+	}
+	if res.OS.Value("os.family") != "" {
+		t.Errorf("verdict mixes clusters: %v", res.OS.Values())
+	}
+	vendor := res.OS.Attributes["os.vendor"]
+	if len(vendor.Evidence) != 1 || len(vendor.Dissent) != 2 || vendor.Confidence >= 1 {
+		t.Errorf("unexpected os.vendor attribute: %+v", vendor)
+	}
+	found := false
+	for _, c := range res.Contradictions {
+		if c.Layer == LayerOS && c.Key == "os.vendor" {
+			found = true
+			if c.Chosen != "Microsoft" || len(c.Evidence) != 3 || c.Kind != conflict.Incompatible {
+				t.Errorf("unexpected contradiction: %+v", c)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected an os.vendor contradiction, got %v", res.Contradictions)
+	}
+
+	// Services sharing a port are told apart by transport, and the result does not
+	// depend on map order
+	var observations []Observation
+	for _, transport := range []string{"udp", "tcp"} {
+		for _, obs := range []Observation{ssh, http} {
+			obs.Port, obs.Transport = 22, transport
+			observations = append(observations, obs)
+		}
+	}
+	first, err := Fuse(context.Background(), fs, observations, Options{})
+	if err != nil {
+		t.Fatalf("fuse failed: %s", err)
+	}
+	if len(first.Services) != 2 || first.Services[0].Transport != "tcp" || first.Services[1].Transport != "udp" {
+		t.Fatalf("expected tcp and udp services on port 22, got %v", first.Services)
+	}
+	var transports []string
+	for _, c := range first.Contradictions {
+		if c.Layer == LayerService && c.Key == "service.product" {
+			transports = append(transports, c.Transport)
+		}
+	}
+	if len(transports) != 2 || transports[0] != "tcp" || transports[1] != "udp" {
+		t.Errorf("expected service.product contradictions for tcp then udp, got %v", transports)
+	}
+	for i := 0; i < 10; i++ {
+		res, err := Fuse(context.Background(), fs, observations, Options{})
+		if err != nil {
+			t.Fatalf("fuse failed: %s", err)
+		}
+		if res.Confidence != first.Confidence || len(res.Contradictions) != len(first.Contradictions) {
+			t.Fatalf("fusion is not deterministic: %f != %f", res.Confidence, first.Confidence)
+		}
+		for j, c := range res.Contradictions {
+			f := first.Contradictions[j]
+			if c.Layer != f.Layer || c.Port != f.Port || c.Transport != f.Transport || c.Key != f.Key {
+				t.Errorf("contradiction %d differs between runs: %+v != %+v", j, c, f)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Fuse(ctx, fs, []Observation{ssh}, Options{}); err == nil {
+		t.Errorf("expected an error from a cancelled context")
+	}
+}
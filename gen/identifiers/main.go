@@ -0,0 +1,90 @@
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/shurcooL/vfsgen"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+// identifierFiles maps value keys to the identifier list that holds their values,
+// following the layout of the identifiers directory of a Recog checkout
+var identifierFiles = map[string]string{
+	"os.device":       "device",
+	"hw.device":       "device",
+	"hw.family":       "hw_family",
+	"hw.product":      "hw_product",
+	"os.arch":         "os_architecture",
+	"os.family":       "os_family",
+	"os.product":      "os_product",
+	"service.family":  "service_family",
+	"service.product": "service_product",
+	"os.vendor":       "vendor",
+	"hw.vendor":       "vendor",
+	"service.vendor":  "vendor",
+}
+
+// main writes the fixed values assigned by the embedded fingerprints to identifier
+// lists and embeds them in the conflict package. It runs from the conflict
+// directory through go generate.
+func main() {
+	dir := "./identifiers"
+	if v := os.Getenv("RECOG_IDENTIFIERS"); v != "" {
+		dir = v
+	}
+
+	fset, err := recog.LoadFingerprints()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	lists := make(map[string]map[string]bool)
+	for _, fdbs := range fset.DatabasesByMatchKey {
+		for _, fdb := range fdbs {
+			for _, fp := range fdb.Fingerprints {
+				for _, param := range fp.Params {
+					name, ok := identifierFiles[param.Name]
+					value := strings.TrimSpace(param.Value)
+					if !ok || param.Position != "0" || value == "" || strings.Contains(value, "{") {
+						continue
+					}
+					if lists[name] == nil {
+						lists[name] = make(map[string]bool)
+					}
+					lists[name][value] = true
+				}
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Fatalln(err)
+	}
+	for name, values := range lists {
+		var lines []string
+		for v := range values {
+			lines = append(lines, v)
+		}
+		sort.Strings(lines)
+		data := []byte(strings.Join(lines, "\n") + "\n")
+		if err := ioutil.WriteFile(filepath.Join(dir, name+".txt"), data, 0644); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	err = vfsgen.Generate(http.Dir(dir), vfsgen.Options{
+		Filename:     "identifiers_vfsdata.go",
+		PackageName:  "conflict",
+		VariableName: "Identifiers",
+	})
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
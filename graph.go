@@ -1,6 +1,11 @@
 package recog
 
 import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+
 	"github.com/google/uuid"
 )
 
@@ -12,47 +17,348 @@ type MatchNode struct {
 type MatchEdge struct {
 	ParentId uuid.UUID
 	ChildId  uuid.UUID
+	// Key and Value are the extracted value of the parent match that was routed
+	// to the child, before any route transform
+	Key   string
+	Value string
+}
+
+// DefaultMaxDepth is the number of routed levels followed below the initial matches
+// when TraverseOptions does not set one
+const DefaultMaxDepth = 8
+
+// TraverseOptions controls how TraverseMatchGraph follows routes
+type TraverseOptions struct {
+	// MaxDepth limits the routed levels below the initial matches. Zero uses
+	// DefaultMaxDepth and a negative value is unlimited.
+	MaxDepth int
+	// Dedup matches each match key and input once, linking every parent that
+	// routes to it to the same nodes
+	Dedup bool
+	// StableIds derives node ids from their content with StableNodeId, so the
+	// same input always produces the same graph. Identical matches under the same
+	// parent are then merged into one node.
+	StableIds bool
+}
+
+// MatchNodeNamespace is the UUID namespace of stable node ids
+var MatchNodeNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/runZeroInc/recog-go/match-node"))
+
+// StableNodeId returns a version 5 UUID for the match of a fingerprint against an
+// input, beneath a parent node. Root nodes use uuid.Nil as their parent.
+func StableNodeId(parentId uuid.UUID, dbName string, index int, input string) uuid.UUID {
+	data := make([]byte, 0, len(parentId)+len(dbName)+len(input)+8)
+	data = append(data, parentId[:]...)
+	data = append(data, dbName...)
+	data = append(data, 0)
+	data = strconv.AppendInt(data, int64(index), 10)
+	data = append(data, 0)
+	data = append(data, input...)
+	return uuid.NewSHA1(MatchNodeNamespace, data)
+}
+
+// TruncationReason describes why traversal did not follow a route
+type TruncationReason string
+
+const (
+	// TruncatedDepth means the route was beyond MaxDepth
+	TruncatedDepth TruncationReason = "depth"
+	// TruncatedCycle means the match key and input were already being matched by
+	// an ancestor
+	TruncatedCycle TruncationReason = "cycle"
+)
+
+// Truncation records a route that traversal did not follow
+type Truncation struct {
+	ParentId uuid.UUID
+	MatchKey string
+	Input    string
+	Reason   TruncationReason
+}
+
+// MatchGraph is the result of a traversal
+type MatchGraph struct {
+	Nodes     []*MatchNode
+	Edges     []*MatchEdge
+	Truncated []Truncation
+}
+
+// Node returns the node with an id, or nil if it is not in the graph
+func (g *MatchGraph) Node(id uuid.UUID) *MatchNode {
+	for _, node := range g.Nodes {
+		if node.Id == id {
+			return node
+		}
+	}
+	return nil
+}
+
+// Roots returns the nodes matched directly against the input
+func (g *MatchGraph) Roots() []*MatchNode {
+	children := make(map[uuid.UUID]bool, len(g.Edges))
+	for _, edge := range g.Edges {
+		children[edge.ChildId] = true
+	}
+	var res []*MatchNode
+	for _, node := range g.Nodes {
+		if !children[node.Id] {
+			res = append(res, node)
+		}
+	}
+	return res
+}
+
+// Suppressed returns the nodes matched by suppressing fingerprints, whose routes
+// were not followed
+func (g *MatchGraph) Suppressed() []*MatchNode {
+	var res []*MatchNode
+	for _, node := range g.Nodes {
+		if node.Match.Suppressed {
+			res = append(res, node)
+		}
+	}
+	return res
+}
+
+// Children returns the nodes matched from the values of a node
+func (g *MatchGraph) Children(id uuid.UUID) []*MatchNode {
+	return g.ChildrenVia(id, "")
+}
+
+// ChildrenVia returns the nodes matched from one value key of a node, or from any
+// key if it is empty
+func (g *MatchGraph) ChildrenVia(id uuid.UUID, key string) []*MatchNode {
+	var res []*MatchNode
+	seen := make(map[uuid.UUID]bool)
+	for _, edge := range g.Edges {
+		if edge.ParentId != id || (key != "" && edge.Key != key) || seen[edge.ChildId] {
+			continue
+		}
+		seen[edge.ChildId] = true
+		if child := g.Node(edge.ChildId); child != nil {
+			res = append(res, child)
+		}
+	}
+	return res
+}
+
+// PathFromRoot returns the edges leading from a root to a node, which is empty for
+// a root and nil if the node is not in the graph. Nodes shared by deduplication
+// are reached through the first parent that routed to them.
+func (g *MatchGraph) PathFromRoot(id uuid.UUID) []*MatchEdge {
+	if g.Node(id) == nil {
+		return nil
+	}
+	parents := make(map[uuid.UUID]*MatchEdge, len(g.Edges))
+	for _, edge := range g.Edges {
+		if _, ok := parents[edge.ChildId]; !ok {
+			parents[edge.ChildId] = edge
+		}
+	}
+
+	path := []*MatchEdge{}
+	for edge, ok := parents[id]; ok && len(path) < len(g.Edges); edge, ok = parents[edge.ParentId] {
+		path = append(path, edge)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
 }
 
 func TraverseMatch(fpset *FingerprintSet, dbtype string, text string) ([]*MatchNode, []*MatchEdge, error) {
-	return traverseMatch(nil, fpset, dbtype, text)
+	return TraverseMatchContext(context.Background(), fpset, dbtype, text)
+}
+
+// TraverseMatchContext is TraverseMatch with cancellation. The set's MaxMatchTime
+// applies to the whole traversal, and the nodes found before the context is done
+// are returned along with the error.
+func TraverseMatchContext(ctx context.Context, fpset *FingerprintSet, dbtype string, text string) ([]*MatchNode, []*MatchEdge, error) {
+	graph, err := TraverseMatchGraph(ctx, fpset, dbtype, text, TraverseOptions{})
+	return graph.Nodes, graph.Edges, err
+}
+
+// TraverseMatchGraph matches text and follows the set's routes from the extracted
+// values, stopping at cycles, at suppressed matches and at the configured depth. The graph is returned
+// even on error, holding whatever was found before matching stopped.
+func TraverseMatchGraph(ctx context.Context, fpset *FingerprintSet, dbtype string, text string, opts TraverseOptions) (*MatchGraph, error) {
+	if opts.MaxDepth == 0 {
+		opts.MaxDepth = DefaultMaxDepth
+	}
+
+	bctx, cancel := fpset.budget(ctx)
+	defer cancel()
+
+	t := &traversal{
+		ctx:   bctx,
+		fpset: fpset,
+		opts:  opts,
+		graph: &MatchGraph{},
+		path:  make(map[traverseKey]bool),
+		seen:  make(map[traverseKey][]uuid.UUID),
+		nodes: make(map[uuid.UUID]bool),
+		edges: make(map[MatchEdge]bool),
+	}
+	_, err := t.visit(nil, routedValue{}, 0, dbtype, text)
+	return t.graph, budgetErr(ctx, err)
 }
 
-// Recursively match against the fingerprint database.
-func traverseMatch(parentId *uuid.UUID, fpset *FingerprintSet, dbtype string, text string) ([]*MatchNode, []*MatchEdge, error) {
-	var nodes []*MatchNode
-	var edges []*MatchEdge
+// traverseKey identifies the input of one match during traversal
+type traverseKey struct {
+	matchKey string
+	input    string
+}
+
+// routedValue is the parent value that led to a match
+type routedValue struct {
+	key   string
+	value string
+}
+
+// traversal holds the state of a single TraverseMatchGraph call
+type traversal struct {
+	ctx   context.Context
+	fpset *FingerprintSet
+	opts  TraverseOptions
+	graph *MatchGraph
+	// path holds the inputs being matched by the current node's ancestors
+	path map[traverseKey]bool
+	// seen holds the nodes created for each input when deduplicating
+	seen  map[traverseKey][]uuid.UUID
+	nodes map[uuid.UUID]bool
+	edges map[MatchEdge]bool
+}
+
+// visit matches an input and recursively follows the routes of each match,
+// returning the ids of the nodes created directly for the input
+func (t *traversal) visit(parentId *uuid.UUID, via routedValue, depth int, dbtype string, text string) ([]uuid.UUID, error) {
+	key := traverseKey{matchKey: dbtype, input: text}
+
+	if ids, ok := t.seen[key]; ok {
+		t.link(parentId, via, ids)
+		return ids, nil
+	}
 
 	// no matches? return nil now
-	fps, err := fpset.MatchAll(dbtype, text)
-	if err != nil {
-		return nil, nil, err
+	fps, err := t.fpset.matchString(t.ctx, dbtype, text, false)
+	if err != nil && !isContextErr(err) {
+		return nil, err
 	}
+	// Matches found before the context was done are still added below
+	stopErr := err
+
+	t.path[key] = true
+	defer delete(t.path, key)
 
 	// iterate over the matches and construct the graph from the results
+	var ids []uuid.UUID
 	for _, fpMatch := range fps {
-		node := &MatchNode{Id: uuid.New(), Match: fpMatch}
-		if parentId != nil {
-			edges = append(edges, &MatchEdge{ParentId: *parentId, ChildId: node.Id})
+		node := &MatchNode{Id: t.nodeId(parentId, fpMatch, text), Match: fpMatch}
+		// a stable id seen before is the same match of the same input under the
+		// same parent, reached by another route that still needs its edge
+		if t.nodes[node.Id] {
+			t.link(parentId, via, []uuid.UUID{node.Id})
+			ids = append(ids, node.Id)
+			continue
+		}
+		t.nodes[node.Id] = true
+		t.graph.Nodes = append(t.graph.Nodes, node)
+		t.link(parentId, via, []uuid.UUID{node.Id})
+		ids = append(ids, node.Id)
+
+		// suppressed input is known but not attributed, so stop here
+		if stopErr != nil || fpMatch.Suppressed {
+			continue
+		}
+		if err := t.follow(node, depth); err != nil {
+			return ids, err
 		}
+	}
+
+	if t.opts.Dedup && stopErr == nil {
+		t.seen[key] = ids
+	}
+	return ids, stopErr
+}
+
+// follow matches the routed values of a node, in a stable order
+func (t *traversal) follow(node *MatchNode, depth int) error {
+	values := node.Match.Values
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-		// add the node to the list of nodes
-		nodes = append(nodes, node)
+	for _, key := range keys {
+		for _, route := range t.fpset.routing().Routes(key) {
+			for _, input := range route.inputs(values[key]) {
+				for _, matchKey := range route.MatchKeys {
+					// routes may name databases that were not loaded
+					if _, ok := t.fpset.DatabasesByMatchKey[matchKey]; !ok {
+						continue
+					}
 
-		for key, value := range fpMatch.Values {
-			// recursively call traverseMatch for each match key
-			cfpNodes, cfpEdges, err := traverseMatch(&node.Id, fpset, key, value)
-			if err != nil {
-				// Don't log this warning for now, since this is somewhat expected
-				// slog.Warn(fmt.Sprintf("No such database with match key: %s", key))
-				continue
+					child := traverseKey{matchKey: matchKey, input: input}
+					if t.path[child] {
+						t.truncate(node.Id, child, TruncatedCycle)
+						continue
+					}
+					if t.opts.MaxDepth > 0 && depth >= t.opts.MaxDepth {
+						t.truncate(node.Id, child, TruncatedDepth)
+						continue
+					}
+
+					// other errors, such as oversized input, only skip the child
+					if _, err := t.visit(&node.Id, routedValue{key, values[key]}, depth+1, matchKey, input); isContextErr(err) {
+						return err
+					}
+				}
 			}
+		}
+	}
+	return nil
+}
+
+// nodeId returns the id of a new node
+func (t *traversal) nodeId(parentId *uuid.UUID, m *FingerprintMatch, text string) uuid.UUID {
+	if !t.opts.StableIds {
+		return uuid.New()
+	}
+	parent := uuid.Nil
+	if parentId != nil {
+		parent = *parentId
+	}
+	return StableNodeId(parent, m.Fingerprint.DB.Name, m.Fingerprint.Index, text)
+}
 
-			// append the nodes and edges to our list
-			nodes = append(nodes, cfpNodes...)
-			edges = append(edges, cfpEdges...)
+// link adds edges from a parent to child nodes, skipping edges that already exist
+// because a parent routed the same value to a deduplicated input twice
+func (t *traversal) link(parentId *uuid.UUID, via routedValue, ids []uuid.UUID) {
+	if parentId == nil {
+		return
+	}
+	for _, id := range ids {
+		edge := MatchEdge{ParentId: *parentId, ChildId: id, Key: via.key, Value: via.value}
+		if t.edges[edge] {
+			continue
 		}
+		t.edges[edge] = true
+		t.graph.Edges = append(t.graph.Edges, &edge)
 	}
+}
+
+// truncate records a route that was not followed
+func (t *traversal) truncate(parentId uuid.UUID, key traverseKey, reason TruncationReason) {
+	t.graph.Truncated = append(t.graph.Truncated, Truncation{
+		ParentId: parentId,
+		MatchKey: key.matchKey,
+		Input:    key.input,
+		Reason:   reason,
+	})
+}
 
-	return nodes, edges, nil
+// isContextErr reports whether matching stopped because the context was done
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
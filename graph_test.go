@@ -0,0 +1,425 @@
+package recog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTraverseMatchRouting(t *testing.T) {
+	fset, err := LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	banner := "Apache/2.0.44 (Mandrake Linux/11mdk) mod_perl/1.99_08 Perl/v5.8.0 mod_ssl/2.0.44 OpenSSL/0.9.7a"
+	nodes, edges, err := TraverseMatch(fset, "http_header.server", banner)
+	if err != nil {
+		t.Fatalf("TraverseMatch() failed: %s", err)
+	}
+
+	byDB := make(map[string][]*MatchNode)
+	for _, node := range nodes {
+		byDB[node.Match.Fingerprint.DB.Matches] = append(byDB[node.Match.Fingerprint.DB.Matches], node)
+	}
+	if len(byDB["http_header.server"]) == 0 || len(byDB["apache_os"]) == 0 {
+		t.Fatalf("TraverseMatch() did not route apache.info: %v", byDB)
+	}
+	var modules []string
+	for _, node := range byDB["apache_modules"] {
+		modules = append(modules, node.Match.Values["service.component.product"])
+	}
+	if !reflect.DeepEqual(modules, []string{"mod_perl", "Perl", "mod_ssl", "OpenSSL"}) {
+		t.Errorf("TraverseMatch() matched modules %v", modules)
+	}
+	if len(edges) != len(nodes)-len(byDB["http_header.server"]) {
+		t.Errorf("TraverseMatch() returned %d edges for %d nodes", len(edges), len(nodes))
+	}
+
+	// Caller routes are followed, and routes to databases that are not loaded are
+	// skipped
+	fset.AddRoutes(
+		Route{Key: "service.version", MatchKeys: []string{"no.such.database"}},
+		Route{Key: "service.product", MatchKeys: []string{"operating_system.name"}, Transform: func(string) []string {
+			return []string{"Windows Server 2008 R2"}
+		}},
+	)
+	nodes, _, err = TraverseMatch(fset, "http_header.server", "Microsoft-IIS/7.5")
+	if err != nil {
+		t.Fatalf("TraverseMatch() failed: %s", err)
+	}
+	found := false
+	for _, node := range nodes {
+		if node.Match.Values["os.product"] == "Windows Server 2008 R2" && node.Match.Fingerprint.DB.Matches == "operating_system.name" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("TraverseMatch() did not follow a custom route")
+	}
+}
+
+func TestRouteTransforms(t *testing.T) {
+	modules := SplitApacheModules("(Mandrake Linux/11mdk) mod_perl/1.99_08 (extra) PHP/4.3.1")
+	if !reflect.DeepEqual(modules, []string{"mod_perl/1.99_08", "PHP/4.3.1"}) {
+		t.Errorf("SplitApacheModules() returned %v", modules)
+	}
+
+	route := Route{Key: "tomcat.info", Transform: SplitOn(";")}
+	inputs := route.inputs("JSP 1.1; Servlet 2.2; ; AIX 5.3 ppc")
+	if !reflect.DeepEqual(inputs, []string{"JSP 1.1", "Servlet 2.2", "AIX 5.3 ppc"}) {
+		t.Errorf("SplitOn() returned %v", inputs)
+	}
+
+	if res := TrimAfter("/")("Windows Server 2008 R2/6.1 x86 java/1.5.0_22"); !reflect.DeepEqual(res, []string{"Windows Server 2008 R2"}) {
+		t.Errorf("TrimAfter() returned %v", res)
+	}
+}
+
+func TestTraverseMatchLimits(t *testing.T) {
+	fset, err := LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	// A route back to the input being matched is a cycle
+	fset.Routing = NewRoutingTable(Route{Key: "service.product", MatchKeys: []string{"http_header.server"}, Transform: func(string) []string {
+		return []string{"Apache/2.4.41"}
+	}})
+	graph, err := TraverseMatchGraph(context.Background(), fset, "http_header.server", "Apache/2.4.41", TraverseOptions{})
+	if err != nil {
+		t.Fatalf("TraverseMatchGraph() failed: %s", err)
+	}
+	if len(graph.Nodes) != 1 || len(graph.Truncated) != 1 || graph.Truncated[0].Reason != TruncatedCycle {
+		t.Errorf("TraverseMatchGraph() did not stop at the cycle: %d nodes, %+v", len(graph.Nodes), graph.Truncated)
+	}
+
+	// A route that never repeats is stopped by the depth limit
+	fset.Routing = NewRoutingTable(Route{Key: "service.version", MatchKeys: []string{"http_header.server"}, Transform: func(v string) []string {
+		return []string{"Apache/" + v + "1"}
+	}})
+	graph, err = TraverseMatchGraph(context.Background(), fset, "http_header.server", "Apache/2", TraverseOptions{MaxDepth: 3})
+	if err != nil {
+		t.Fatalf("TraverseMatchGraph() failed: %s", err)
+	}
+	// The major version fingerprint also matches the input but has nothing to route
+	if len(graph.Nodes) != 5 || len(graph.Edges) != 3 {
+		t.Errorf("TraverseMatchGraph() returned %d nodes and %d edges", len(graph.Nodes), len(graph.Edges))
+	}
+	if len(graph.Truncated) != 1 || graph.Truncated[0].Reason != TruncatedDepth || graph.Truncated[0].Input != "Apache/21111" {
+		t.Errorf("TraverseMatchGraph() did not report the depth limit: %+v", graph.Truncated)
+	}
+	if nodes, _, err := TraverseMatch(fset, "http_header.server", "Apache/2"); err != nil || len(nodes) != DefaultMaxDepth+2 {
+		t.Errorf("TraverseMatch() did not apply the default depth: %d nodes, %v", len(nodes), err)
+	}
+
+	// Equivalent routed inputs are matched once when deduplicating. Two IIS and two
+	// Windows fingerprints match, so each of the two routes adds four OS nodes
+	// without deduplication.
+	toWindows := func(string) []string { return []string{"Windows Server 2008 R2"} }
+	fset.Routing = NewRoutingTable(
+		Route{Key: "service.vendor", MatchKeys: []string{"operating_system.name"}, Transform: toWindows},
+		Route{Key: "service.family", MatchKeys: []string{"operating_system.name"}, Transform: toWindows},
+	)
+	for _, dedup := range []bool{false, true} {
+		graph, err = TraverseMatchGraph(context.Background(), fset, "http_header.server", "Microsoft-IIS/7.5", TraverseOptions{Dedup: dedup})
+		if err != nil {
+			t.Fatalf("TraverseMatchGraph() failed: %s", err)
+		}
+		os := 0
+		for _, node := range graph.Nodes {
+			if node.Match.Fingerprint.DB.Matches == "operating_system.name" {
+				os++
+			}
+		}
+		// Deduplicated nodes keep an edge for each route that reached them
+		wantNodes, wantEdges := 8, 8
+		if dedup {
+			wantNodes = 2
+		}
+		if os != wantNodes || len(graph.Edges) != wantEdges {
+			t.Errorf("TraverseMatchGraph(Dedup: %v) returned %d OS nodes and %d edges", dedup, os, len(graph.Edges))
+		}
+	}
+}
+
+func TestTraverseMatchStableIds(t *testing.T) {
+	banner := "Apache/2.0.44 (Mandrake Linux/11mdk) mod_perl/1.99_08 mod_ssl/2.0.44 OpenSSL/0.9.7a"
+	opts := TraverseOptions{StableIds: true}
+
+	var graphs []*MatchGraph
+	for i := 0; i < 2; i++ {
+		fset, err := LoadFingerprints()
+		if err != nil {
+			t.Fatalf("LoadFingerprints() failed: %s", err)
+		}
+		graph, err := TraverseMatchGraph(context.Background(), fset, "http_header.server", banner, opts)
+		if err != nil {
+			t.Fatalf("TraverseMatchGraph() failed: %s", err)
+		}
+		graphs = append(graphs, graph)
+	}
+
+	a, b := graphs[0], graphs[1]
+	if len(a.Nodes) < 3 || len(a.Nodes) != len(b.Nodes) || len(a.Edges) != len(b.Edges) {
+		t.Fatalf("TraverseMatchGraph() returned different graphs: %d and %d nodes", len(a.Nodes), len(b.Nodes))
+	}
+	ids := make(map[uuid.UUID]bool)
+	for i := range a.Nodes {
+		if a.Nodes[i].Id != b.Nodes[i].Id {
+			t.Errorf("node %d has ids %s and %s", i, a.Nodes[i].Id, b.Nodes[i].Id)
+		}
+		if a.Nodes[i].Id.Version() != 5 {
+			t.Errorf("node %d has a version %d id", i, a.Nodes[i].Id.Version())
+		}
+		ids[a.Nodes[i].Id] = true
+	}
+	if len(ids) != len(a.Nodes) {
+		t.Errorf("TraverseMatchGraph() reused an id: %d ids for %d nodes", len(ids), len(a.Nodes))
+	}
+	for i := range a.Edges {
+		if *a.Edges[i] != *b.Edges[i] {
+			t.Errorf("edge %d differs: %v and %v", i, a.Edges[i], b.Edges[i])
+		}
+	}
+
+	// Two keys routing the same value reach the same node, with an edge for each
+	fset, err := LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+	toWindows := func(string) []string { return []string{"Windows Server 2008 R2"} }
+	fset.Routing = NewRoutingTable(
+		Route{Key: "service.vendor", MatchKeys: []string{"operating_system.name"}, Transform: toWindows},
+		Route{Key: "service.family", MatchKeys: []string{"operating_system.name"}, Transform: toWindows},
+	)
+	graph, err := TraverseMatchGraph(context.Background(), fset, "http_header.server", "Microsoft-IIS/7.5", opts)
+	if err != nil {
+		t.Fatalf("TraverseMatchGraph() failed: %s", err)
+	}
+	os := 0
+	for _, node := range graph.Nodes {
+		if node.Match.Fingerprint.DB.Matches == "operating_system.name" {
+			os++
+		}
+	}
+	if os != 4 || len(graph.Edges) != 8 {
+		t.Errorf("TraverseMatchGraph() returned %d OS nodes and %d edges", os, len(graph.Edges))
+	}
+
+	root := a.Nodes[0]
+	want := StableNodeId(uuid.Nil, root.Match.Fingerprint.DB.Name, root.Match.Fingerprint.Index, banner)
+	if root.Id != want {
+		t.Errorf("root node has id %s, expected %s", root.Id, want)
+	}
+	if StableNodeId(root.Id, "apache_os.xml", 1, "x") == StableNodeId(uuid.Nil, "apache_os.xml", 1, "x") {
+		t.Errorf("StableNodeId() ignored the parent")
+	}
+}
+
+func TestGraphExport(t *testing.T) {
+	fset, err := LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+	banner := "Apache/2.0.44 (Mandrake Linux/11mdk) mod_ssl/2.0.44"
+	graph, err := TraverseMatchGraph(context.Background(), fset, "http_header.server", banner, TraverseOptions{StableIds: true})
+	if err != nil {
+		t.Fatalf("TraverseMatchGraph() failed: %s", err)
+	}
+	g := ExportGraph{Name: banner, Nodes: graph.Nodes, Edges: graph.Edges}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, g, g); err != nil {
+		t.Fatalf("WriteJSON() failed: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("WriteJSON() wrote %d lines for two graphs", len(lines))
+	}
+	var decoded GraphJSON
+	if err := json.Unmarshal([]byte(lines[0]), &decoded); err != nil {
+		t.Fatalf("WriteJSON() wrote invalid JSON: %s", err)
+	}
+	if decoded.Name != banner || len(decoded.Nodes) != len(graph.Nodes) || len(decoded.Edges) != len(graph.Edges) {
+		t.Errorf("WriteJSON() wrote the wrong graph: %+v", decoded)
+	}
+	root := decoded.Nodes[0]
+	if root.Database != "http_servers.xml" || root.MatchKey != "http_header.server" || root.DatabaseType != "service" ||
+		root.Description != "Apache" || root.Certainty == nil || *root.Certainty != 0.85 || root.Values["service.version"] != "2.0.44" {
+		t.Errorf("WriteJSON() wrote the wrong root node: %+v", root)
+	}
+	for _, e := range decoded.Edges {
+		if e.Parent != root.ID || e.Key != "apache.info" {
+			t.Errorf("WriteJSON() wrote the wrong edge: %+v", e)
+		}
+	}
+
+	buf.Reset()
+	if err := WriteDOT(&buf, g); err != nil {
+		t.Fatalf("WriteDOT() failed: %s", err)
+	}
+	dot := buf.String()
+	for _, want := range []string{
+		`digraph "Apache/2.0.44 (Mandrake Linux/11mdk) mod_ssl/2.0.44" {`,
+		`[label="http_servers.xml\nApache\ncertainty 0.85"];`,
+		`"` + root.ID + `" -> "`,
+		`[label="apache.info"];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("WriteDOT() output is missing %s:\n%s", want, dot)
+		}
+	}
+
+	buf.Reset()
+	if err := WriteGraphML(&buf, g, g); err != nil {
+		t.Fatalf("WriteGraphML() failed: %s", err)
+	}
+	var doc graphMLDocument
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("WriteGraphML() wrote invalid XML: %s", err)
+	}
+	if len(doc.Graphs) != 2 || len(doc.Graphs[1].Nodes) != len(graph.Nodes) || len(doc.Graphs[1].Edges) != len(graph.Edges) {
+		t.Fatalf("WriteGraphML() wrote the wrong graphs: %+v", doc.Graphs)
+	}
+	if e := doc.Graphs[0].Edges[0]; e.Source != root.ID || len(e.Data) != 2 || e.Data[0].Value != "apache.info" || e.Data[1].Value != "(Mandrake Linux/11mdk) mod_ssl/2.0.44" {
+		t.Errorf("WriteGraphML() wrote the wrong edge: %+v", e)
+	}
+}
+
+func TestMatchGraphHelpers(t *testing.T) {
+	fset, err := LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+	fset.AddRoutes(Route{Key: "service.component.product", MatchKeys: []string{"operating_system.name"}, Transform: func(string) []string {
+		return []string{"Windows Server 2008 R2"}
+	}})
+
+	info := "(Mandrake Linux/11mdk) mod_ssl/2.0.44"
+	graph, err := TraverseMatchGraph(context.Background(), fset, "http_header.server", "Apache/2.0.44 "+info, TraverseOptions{})
+	if err != nil {
+		t.Fatalf("TraverseMatchGraph() failed: %s", err)
+	}
+
+	roots := graph.Roots()
+	if len(roots) != 1 || roots[0].Match.Fingerprint.DB.Matches != "http_header.server" {
+		t.Fatalf("Roots() returned %v", roots)
+	}
+	root := roots[0]
+	if graph.Node(root.Id) != root || graph.Node(uuid.Nil) != nil {
+		t.Errorf("Node() did not look up nodes by id")
+	}
+
+	modules := graph.ChildrenVia(root.Id, "apache.info")
+	if len(modules) != len(graph.Children(root.Id)) || len(modules) < 2 {
+		t.Errorf("ChildrenVia() returned %d of %d children", len(modules), len(graph.Children(root.Id)))
+	}
+	if len(graph.ChildrenVia(root.Id, "service.version")) != 0 {
+		t.Errorf("ChildrenVia() returned children for an unrouted key")
+	}
+	for _, edge := range graph.Edges {
+		if edge.ParentId == root.Id && (edge.Key != "apache.info" || edge.Value != info) {
+			t.Errorf("edge from the root has key %q and value %q", edge.Key, edge.Value)
+		}
+	}
+
+	// The OS node was reached through a module
+	var os *MatchNode
+	for _, node := range graph.Nodes {
+		if node.Match.Fingerprint.DB.Matches == "operating_system.name" {
+			os = node
+			break
+		}
+	}
+	if os == nil {
+		t.Fatalf("TraverseMatchGraph() did not follow the module route")
+	}
+	path := graph.PathFromRoot(os.Id)
+	if len(path) != 2 || path[0].ParentId != root.Id || path[1].ChildId != os.Id {
+		t.Fatalf("PathFromRoot() returned %v", path)
+	}
+	if path[1].Key != "service.component.product" || path[1].Value != "mod_ssl" {
+		t.Errorf("PathFromRoot() ended with key %q and value %q", path[1].Key, path[1].Value)
+	}
+	if p := graph.PathFromRoot(root.Id); p == nil || len(p) != 0 {
+		t.Errorf("PathFromRoot() returned %v for a root", p)
+	}
+	if graph.PathFromRoot(uuid.Nil) != nil {
+		t.Errorf("PathFromRoot() returned a path for a missing node")
+	}
+}
+
+func TestMatchGraphFacts(t *testing.T) {
+	node := func(db *FingerprintDB, values map[string]string) *MatchNode {
+		fp := &Fingerprint{DB: db}
+		return &MatchNode{Id: uuid.New(), Match: &FingerprintMatch{Fingerprint: fp, Values: values}}
+	}
+	servers := &FingerprintDB{Name: "http_servers.xml", Preference: "0.90"}
+	oses := &FingerprintDB{Name: "apache_os.xml", Preference: "0.10"}
+	titles := &FingerprintDB{Name: "html_title.xml"}
+
+	root := node(servers, map[string]string{"matched": "Apache", "fp.certainty": "0.85", "service.product": "HTTPD", "os.family": "Unix", "apache.info": "(Mandrake Linux/11mdk)"})
+	os := node(oses, map[string]string{"matched": "Mandriva", "fp.certainty": "0.5", "os.family": "Linux", "os.vendor": "Mandriva"})
+	title := node(titles, map[string]string{"fp.certainty": "0.85", "service.product": "Apache HTTPD", "os.vendor": "Red Hat", "os.certainty": "0.4"})
+	other := node(oses, map[string]string{"fp.certainty": "0.85", "service.product": "Tomcat"})
+
+	graph := &MatchGraph{
+		Nodes: []*MatchNode{root, os, title, other},
+		Edges: []*MatchEdge{{ParentId: root.Id, ChildId: os.Id, Key: "apache.info", Value: "(Mandrake Linux/11mdk)"}},
+	}
+	facts := graph.Facts()
+
+	if _, ok := facts["matched"]; ok {
+		t.Errorf("Facts() included match metadata")
+	}
+	want := map[string]string{
+		"service.product": "HTTPD",
+		"os.family":       "Linux",
+		"os.vendor":       "Mandriva",
+		"apache.info":     "(Mandrake Linux/11mdk)",
+	}
+	if got := facts.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Facts() returned %v", got)
+	}
+
+	// The child refines its parent despite a lower certainty
+	if f := facts["os.family"]; f.Node != os || len(f.Alternatives) != 1 || f.Alternatives[0].Value != "Unix" {
+		t.Errorf("os.family came from %v with alternatives %v", f.Node.Match.Values, f.Alternatives)
+	}
+	// Unrelated nodes are chosen by the namespace certainty
+	if f := facts["os.vendor"]; f.Certainty != 0.5 || f.Alternatives[0].Certainty != 0.4 {
+		t.Errorf("os.vendor has certainty %f over %f", f.Certainty, f.Alternatives[0].Certainty)
+	}
+	// and then by database preference
+	if f := facts["service.product"]; f.Node != root || f.Preference != 0.9 || len(f.Alternatives) != 2 {
+		t.Errorf("service.product came from %v with alternatives %v", f.Node.Match.Values, f.Alternatives)
+	}
+
+	if !reflect.DeepEqual(ResolveFacts(graph.Nodes, graph.Edges).Keys(), facts.Keys()) {
+		t.Errorf("ResolveFacts() did not match Facts()")
+	}
+
+	// A refined value drops out before ranking, so the order of the nodes does not
+	// matter: the child refines its more certain parent but loses to another node
+	parent := node(servers, map[string]string{"fp.certainty": "0.9", "os.family": "Unix"})
+	child := node(oses, map[string]string{"fp.certainty": "0.5", "os.family": "Linux"})
+	unrelated := node(titles, map[string]string{"fp.certainty": "0.7", "os.family": "BSD"})
+	edges := []*MatchEdge{{ParentId: parent.Id, ChildId: child.Id}}
+	for _, order := range [][]*MatchNode{
+		{parent, child, unrelated},
+		{parent, unrelated, child},
+		{child, unrelated, parent},
+		{unrelated, child, parent},
+	} {
+		if f := ResolveFacts(order, edges)["os.family"]; f.Value != "BSD" || len(f.Alternatives) != 2 {
+			t.Errorf("os.family resolved to %q with alternatives %v", f.Value, f.Alternatives)
+		}
+	}
+}
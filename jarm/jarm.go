@@ -0,0 +1,218 @@
+// Package jarm computes JARM TLS server fingerprints from recorded ServerHello
+// responses and matches them against the Recog tls_jarm.xml database.
+//
+// The active JARM scanner (https://github.com/salesforce/jarm) sends ten
+// specially crafted ClientHello probes to a server and hashes the ten replies.
+// This package implements only the passive half of that process: given the raw
+// bytes returned for each probe (captured elsewhere, in probe order), it
+// reproduces the reference implementation's parsing and hashing exactly.
+package jarm
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+// ProbeCount is the number of ServerHello responses that make up a JARM fingerprint
+const ProbeCount = 10
+
+// MatchKey is the Recog match key for the JARM database
+const MatchKey = "tls.jarm"
+
+// emptyResponse is the component string used for missing or unparseable responses
+const emptyResponse = "|||"
+
+// cipherList is the ordered list of cipher suites used by the reference implementation
+// to derive the two hex digit cipher component of the fuzzy hash.
+var cipherList = []uint16{
+	0x0004, 0x0005, 0x0007, 0x000a, 0x0016, 0x002f, 0x0033, 0x0035, 0x0039, 0x003c,
+	0x003d, 0x0041, 0x0045, 0x0067, 0x006b, 0x0084, 0x0088, 0x009a, 0x009c, 0x009d,
+	0x009e, 0x009f, 0x00ba, 0x00be, 0x00c0, 0x00c4, 0xc007, 0xc008, 0xc009, 0xc00a,
+	0xc011, 0xc012, 0xc013, 0xc014, 0xc023, 0xc024, 0xc027, 0xc028, 0xc02b, 0xc02c,
+	0xc02f, 0xc030, 0xc060, 0xc061, 0xc072, 0xc073, 0xc076, 0xc077, 0xc09c, 0xc09d,
+	0xc09e, 0xc09f, 0xc0a0, 0xc0a1, 0xc0a2, 0xc0a3, 0xc0ac, 0xc0ad, 0xc0ae, 0xc0af,
+	0xcc13, 0xcc14, 0xcca8, 0xcca9, 0x1301, 0x1302, 0x1303, 0x1304, 0x1305,
+}
+
+// ParseServerHello converts a single raw probe response into its JARM component
+// string ("cipher|version|alpn|extensions"). A nil or empty response, a TLS alert,
+// or anything that is not a ServerHello yields "|||".
+func ParseServerHello(data []byte) string {
+	// TLS record type 22 (handshake) carrying handshake type 2 (server_hello)
+	if len(data) < 44 || data[0] != 22 || data[5] != 2 {
+		return emptyResponse
+	}
+
+	serverHelloLength := int(binary.BigEndian.Uint16(data[3:5]))
+	counter := int(data[43])
+	if len(data) < counter+46 {
+		return emptyResponse
+	}
+
+	// The selected cipher follows the session id and the version is the record version
+	selectedCipher := data[counter+44 : counter+46]
+	version := data[9:11]
+
+	return hex.EncodeToString(selectedCipher) + "|" + hex.EncodeToString(version) + "|" + extensionInfo(data, counter, serverHelloLength)
+}
+
+// extensionInfo returns the "alpn|extensions" portion of a component string,
+// mirroring the error handling of the reference implementation.
+func extensionInfo(data []byte, counter int, serverHelloLength int) string {
+	if len(data) < counter+53 {
+		return "|"
+	}
+	if data[counter+47] == 11 {
+		return "|"
+	}
+	if string(data[counter+50:counter+53]) == "\x0e\xac\x0b" || (len(data) >= 85 && string(data[82:85]) == "\x0f\xf0\x0b") {
+		return "|"
+	}
+	if counter+42 >= serverHelloLength {
+		return "|"
+	}
+
+	count := 49 + counter
+	length := int(binary.BigEndian.Uint16(data[counter+47 : counter+49]))
+	maximum := length + count - 1
+
+	var types [][]byte
+	var values [][]byte
+	for count < maximum {
+		if len(data) < count+4 {
+			return "|"
+		}
+		types = append(types, data[count:count+2])
+		extLength := int(binary.BigEndian.Uint16(data[count+2 : count+4]))
+		if extLength == 0 {
+			values = append(values, nil)
+			count += 4
+			continue
+		}
+		if len(data) < count+4+extLength {
+			return "|"
+		}
+		values = append(values, data[count+4:count+4+extLength])
+		count += extLength + 4
+	}
+
+	// Record the negotiated ALPN protocol, skipping the list and string length prefixes
+	alpn := ""
+	for i, t := range types {
+		if t[0] == 0x00 && t[1] == 0x10 {
+			if len(values[i]) > 3 {
+				alpn = string(values[i][3:])
+			}
+			break
+		}
+	}
+
+	exts := make([]string, 0, len(types))
+	for _, t := range types {
+		exts = append(exts, hex.EncodeToString(t))
+	}
+
+	return alpn + "|" + strings.Join(exts, "-")
+}
+
+// Raw returns the comma-separated raw JARM string for a complete set of probe
+// responses. Responses must be supplied in probe order; use nil for probes that
+// received no reply.
+func Raw(responses [][]byte) (string, error) {
+	if len(responses) != ProbeCount {
+		return "", fmt.Errorf("jarm requires %d responses, got %d", ProbeCount, len(responses))
+	}
+
+	components := make([]string, 0, ProbeCount)
+	for _, resp := range responses {
+		components = append(components, ParseServerHello(resp))
+	}
+	return strings.Join(components, ","), nil
+}
+
+// HashRaw converts a raw JARM string into the 62 character JARM fingerprint
+func HashRaw(raw string) (string, error) {
+	handshakes := strings.Split(raw, ",")
+	if len(handshakes) != ProbeCount {
+		return "", fmt.Errorf("jarm raw string has %d components, expected %d", len(handshakes), ProbeCount)
+	}
+
+	// A server that did not answer any probe hashes to all zeros
+	if raw == strings.TrimSuffix(strings.Repeat(emptyResponse+",", ProbeCount), ",") {
+		return strings.Repeat("0", 62), nil
+	}
+
+	fuzzy := strings.Builder{}
+	alpnsAndExts := strings.Builder{}
+	for _, handshake := range handshakes {
+		components := strings.Split(handshake, "|")
+		if len(components) != 4 {
+			return "", fmt.Errorf("jarm component %q is malformed", handshake)
+		}
+		fuzzy.WriteString(cipherBytes(components[0]))
+		fuzzy.WriteString(versionByte(components[1]))
+		alpnsAndExts.WriteString(components[2])
+		alpnsAndExts.WriteString(components[3])
+	}
+
+	sum := sha256.Sum256([]byte(alpnsAndExts.String()))
+	fuzzy.WriteString(hex.EncodeToString(sum[:])[:32])
+	return fuzzy.String(), nil
+}
+
+// cipherBytes maps a hex encoded cipher suite to its 1-based position in cipherList
+func cipherBytes(cipher string) string {
+	if cipher == "" {
+		return "00"
+	}
+
+	count := 1
+	for _, c := range cipherList {
+		if cipher == fmt.Sprintf("%04x", c) {
+			break
+		}
+		count++
+	}
+	return fmt.Sprintf("%02x", count)
+}
+
+// versionByte maps a hex encoded TLS version (0300-0304) to a single letter
+func versionByte(version string) string {
+	if version == "" {
+		return "0"
+	}
+	if len(version) != 4 || version[3] < '0' || version[3] > '5' {
+		return "0"
+	}
+	return string("abcdef"[version[3]-'0'])
+}
+
+// Fingerprint computes the JARM fingerprint for a complete set of probe responses
+func Fingerprint(responses [][]byte) (string, error) {
+	raw, err := Raw(responses)
+	if err != nil {
+		return "", err
+	}
+	return HashRaw(raw)
+}
+
+// Match computes the JARM fingerprint for a set of probe responses and matches it
+// against the tls.jarm database in the provided FingerprintSet. The computed hash
+// is returned along with any matches.
+func Match(fs *recog.FingerprintSet, responses [][]byte) (string, []*recog.FingerprintMatch, error) {
+	hash, err := Fingerprint(responses)
+	if err != nil {
+		return "", nil, err
+	}
+
+	matches, err := fs.MatchAll(MatchKey, hash)
+	if err != nil {
+		return hash, nil, err
+	}
+	return hash, matches, nil
+}
@@ -0,0 +1,105 @@
+package jarm
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+// loadResponses reads a fixture with one hex encoded probe response per line,
+// using an empty line for probes that received no reply.
+func loadResponses(t *testing.T, fpath string) [][]byte {
+	data, err := os.ReadFile(fpath)
+	if err != nil {
+		t.Fatalf("failed to read %s: %s", fpath, err)
+	}
+
+	var responses [][]byte
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		if line == "" {
+			responses = append(responses, nil)
+			continue
+		}
+		resp, err := hex.DecodeString(line)
+		if err != nil {
+			t.Fatalf("bad fixture line in %s: %s", fpath, err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestRaw(t *testing.T) {
+	responses := loadResponses(t, "testdata/serverhellos.hex")
+	raw, err := Raw(responses)
+	if err != nil {
+		t.Fatalf("Raw() failed: %s", err)
+	}
+
+	expected := "c02f|0303|h2|ff01-000b-0010-0017,c02f|0303|h2|ff01-000b-0010-0017,c030|0303||ff01-000b-0017,|||,|||," +
+		"1301|0303||002b-0033,1302|0303||0033-002b,009c|0303|http/1.1|ff01-0010,c013|0302||ff01-000b,c02f|0303||ff01-000b-0017"
+	if raw != expected {
+		t.Errorf("Raw() returned %q, expected %q", raw, expected)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	responses := loadResponses(t, "testdata/serverhellos.hex")
+	hash, err := Fingerprint(responses)
+	if err != nil {
+		t.Fatalf("Fingerprint() failed: %s", err)
+	}
+	if hash != "29d29d2ad00000041d42d13d21c29deb47d3e6cb0dbef45599a472849aee65" {
+		t.Errorf("Fingerprint() returned unexpected hash %s", hash)
+	}
+
+	hash, err = Fingerprint(make([][]byte, ProbeCount))
+	if err != nil {
+		t.Fatalf("Fingerprint() failed on empty responses: %s", err)
+	}
+	if hash != strings.Repeat("0", 62) {
+		t.Errorf("Fingerprint() returned %s for empty responses", hash)
+	}
+
+	if _, err := Fingerprint(responses[:3]); err == nil {
+		t.Errorf("Fingerprint() accepted an incomplete set of responses")
+	}
+}
+
+func TestParseServerHello(t *testing.T) {
+	if res := ParseServerHello([]byte{21, 3, 3, 0, 2, 2, 40}); res != "|||" {
+		t.Errorf("ParseServerHello() returned %q for an alert", res)
+	}
+	if res := ParseServerHello([]byte{22, 3, 3}); res != "|||" {
+		t.Errorf("ParseServerHello() returned %q for a truncated record", res)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	fdb, err := recog.LoadFingerprintDB("tls_jarm_test.xml", []byte(`<fingerprints matches="tls.jarm" protocol="tls" database_type="service">
+  <fingerprint pattern="^29d29d2ad00000041d42d13d21c29deb47d3e6cb0dbef45599a472849aee65$">
+    <description>Test server</description>
+    <param pos="0" name="service.product" value="TestServer"/>
+  </fingerprint>
+</fingerprints>`))
+	if err != nil {
+		t.Fatalf("LoadFingerprintDB() failed: %s", err)
+	}
+
+	fset := recog.NewFingerprintSet()
+	fset.DatabasesByMatchKey[MatchKey] = []*recog.FingerprintDB{&fdb}
+
+	hash, matches, err := Match(fset, loadResponses(t, "testdata/serverhellos.hex"))
+	if err != nil {
+		t.Fatalf("Match() failed: %s", err)
+	}
+	if hash == "" || len(matches) != 1 {
+		t.Fatalf("Match() returned %q with %d matches", hash, len(matches))
+	}
+	if matches[0].Values["service.product"] != "TestServer" {
+		t.Errorf("Match() returned unexpected values: %v", matches[0].Values)
+	}
+}
@@ -0,0 +1,287 @@
+// Package mdns decodes multicast DNS responses and fingerprints the TXT records
+// advertised for DNS-SD services against the Recog mdns_*_txt.xml databases.
+package mdns
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+// Recog match keys for DNS-SD TXT records
+const (
+	WorkstationMatchKey = "mdns.workstation.txt"
+	DeviceInfoMatchKey  = "mdns.device-info.txt"
+)
+
+// DNS record types used by DNS-SD
+const (
+	TypeA    = 1
+	TypePTR  = 12
+	TypeTXT  = 16
+	TypeAAAA = 28
+	TypeSRV  = 33
+)
+
+// serviceMatchKeys maps DNS-SD service types to the database for their TXT records
+var serviceMatchKeys = map[string]string{
+	"_workstation._tcp": WorkstationMatchKey,
+	"_device-info._tcp": DeviceInfoMatchKey,
+}
+
+// MatchKeyForService returns the match key for the TXT record of a DNS-SD service
+// type such as "_device-info._tcp", or an empty string if there is no database.
+func MatchKeyForService(service string) string {
+	return serviceMatchKeys[strings.ToLower(service)]
+}
+
+const (
+	headerLength = 12
+	flagResponse = 0x8000
+	// classMask removes the mDNS cache-flush and unicast-response bit
+	classMask = 0x7fff
+	// maxPointers bounds name decompression to reject pointer loops
+	maxPointers = 64
+)
+
+// Question is an entry in the question section
+type Question struct {
+	Name  string
+	Type  uint16
+	Class uint16
+}
+
+// Record is a resource record from the answer, authority or additional section
+type Record struct {
+	Name       string
+	Type       uint16
+	Class      uint16
+	CacheFlush bool
+	TTL        uint32
+	Data       []byte
+}
+
+// Message is a decoded DNS message. Records holds the answer, authority and
+// additional sections in order, since responders place DNS-SD data in any of them.
+type Message struct {
+	ID        uint16
+	Flags     uint16
+	Questions []Question
+	Records   []Record
+}
+
+// Response reports whether the message is a response
+func (m *Message) Response() bool {
+	return m.Flags&flagResponse != 0
+}
+
+// Parse decodes a DNS message as sent over mDNS
+func Parse(data []byte) (*Message, error) {
+	if len(data) < headerLength {
+		return nil, fmt.Errorf("message is too short: %d bytes", len(data))
+	}
+
+	m := &Message{
+		ID:    binary.BigEndian.Uint16(data[0:2]),
+		Flags: binary.BigEndian.Uint16(data[2:4]),
+	}
+	qdcount := int(binary.BigEndian.Uint16(data[4:6]))
+	rrcount := int(binary.BigEndian.Uint16(data[6:8])) + int(binary.BigEndian.Uint16(data[8:10])) + int(binary.BigEndian.Uint16(data[10:12]))
+
+	off := headerLength
+	for i := 0; i < qdcount; i++ {
+		name, n, err := readName(data, off)
+		if err != nil {
+			return nil, fmt.Errorf("question %d: %s", i, err)
+		}
+		off = n
+		if off+4 > len(data) {
+			return nil, fmt.Errorf("question %d is truncated", i)
+		}
+		m.Questions = append(m.Questions, Question{
+			Name:  name,
+			Type:  binary.BigEndian.Uint16(data[off : off+2]),
+			Class: binary.BigEndian.Uint16(data[off+2:off+4]) & classMask,
+		})
+		off += 4
+	}
+
+	for i := 0; i < rrcount; i++ {
+		name, n, err := readName(data, off)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %s", i, err)
+		}
+		off = n
+		if off+10 > len(data) {
+			return nil, fmt.Errorf("record %d is truncated", i)
+		}
+		class := binary.BigEndian.Uint16(data[off+2 : off+4])
+		rdlen := int(binary.BigEndian.Uint16(data[off+8 : off+10]))
+		rr := Record{
+			Name:       name,
+			Type:       binary.BigEndian.Uint16(data[off : off+2]),
+			Class:      class & classMask,
+			CacheFlush: class&^classMask != 0,
+			TTL:        binary.BigEndian.Uint32(data[off+4 : off+8]),
+		}
+		off += 10
+		if off+rdlen > len(data) {
+			return nil, fmt.Errorf("record %d data is truncated", i)
+		}
+		rr.Data = data[off : off+rdlen]
+		off += rdlen
+		m.Records = append(m.Records, rr)
+	}
+
+	return m, nil
+}
+
+// readName expands a possibly compressed domain name starting at off and returns
+// it along with the offset following the name in the original position
+func readName(data []byte, off int) (string, int, error) {
+	var labels []string
+	end := -1
+	pointers := 0
+
+	for {
+		if off >= len(data) {
+			return "", 0, fmt.Errorf("name is truncated")
+		}
+		l := int(data[off])
+		switch {
+		case l == 0:
+			if end < 0 {
+				end = off + 1
+			}
+			return strings.Join(labels, "."), end, nil
+		case l&0xc0 == 0xc0:
+			if off+1 >= len(data) {
+				return "", 0, fmt.Errorf("name pointer is truncated")
+			}
+			if pointers++; pointers > maxPointers {
+				return "", 0, fmt.Errorf("too many name pointers")
+			}
+			if end < 0 {
+				end = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(data[off:off+2]) & 0x3fff)
+		case l&0xc0 != 0:
+			return "", 0, fmt.Errorf("unsupported label type 0x%02x", l&0xc0)
+		default:
+			if off+1+l > len(data) {
+				return "", 0, fmt.Errorf("label is truncated")
+			}
+			labels = append(labels, string(data[off+1:off+1+l]))
+			off += 1 + l
+		}
+	}
+}
+
+// TXTRecord is a decoded DNS-SD TXT record
+type TXTRecord struct {
+	Name string
+	// Instance, Service and Domain split a name such as
+	// "host._device-info._tcp.local" into "host", "_device-info._tcp" and "local"
+	Instance string
+	Service  string
+	Domain   string
+	// Strings holds each character string of the record in order
+	Strings []string
+	// Values holds the key/value pairs, keyed by lower-cased key as keys are
+	// case-insensitive. Keys without "=" have an empty value.
+	Values map[string]string
+	// MatchKey and Matches are set when the service has a Recog database
+	MatchKey string
+	Matches  []*recog.FingerprintMatch
+}
+
+// TXTRecords decodes every TXT record in the message
+func (m *Message) TXTRecords() ([]*TXTRecord, error) {
+	var res []*TXTRecord
+	for _, rr := range m.Records {
+		if rr.Type != TypeTXT {
+			continue
+		}
+		strs, err := decodeTXT(rr.Data)
+		if err != nil {
+			return nil, fmt.Errorf("TXT record %s: %s", rr.Name, err)
+		}
+
+		txt := &TXTRecord{Name: rr.Name, Strings: strs, Values: make(map[string]string)}
+		txt.Instance, txt.Service, txt.Domain = splitServiceName(rr.Name)
+		for _, s := range strs {
+			key, value := s, ""
+			if i := strings.IndexByte(s, '='); i >= 0 {
+				key, value = s[:i], s[i+1:]
+			}
+			// The first occurrence of a key wins (RFC 6763 section 6.4)
+			key = strings.ToLower(key)
+			if _, ok := txt.Values[key]; key != "" && !ok {
+				txt.Values[key] = value
+			}
+		}
+		res = append(res, txt)
+	}
+	return res, nil
+}
+
+// decodeTXT splits TXT record data into its length-prefixed strings, dropping the
+// empty string that DNS-SD uses for a record with no keys
+func decodeTXT(data []byte) ([]string, error) {
+	var res []string
+	for off := 0; off < len(data); {
+		l := int(data[off])
+		if off+1+l > len(data) {
+			return nil, fmt.Errorf("string is truncated")
+		}
+		if l > 0 {
+			res = append(res, string(data[off+1:off+1+l]))
+		}
+		off += 1 + l
+	}
+	return res, nil
+}
+
+// splitServiceName finds the "_service._proto" label pair in a DNS-SD name
+func splitServiceName(name string) (string, string, string) {
+	labels := strings.Split(name, ".")
+	for i := 0; i+1 < len(labels); i++ {
+		proto := strings.ToLower(labels[i+1])
+		if strings.HasPrefix(labels[i], "_") && (proto == "_tcp" || proto == "_udp") {
+			return strings.Join(labels[:i], "."), labels[i] + "." + labels[i+1], strings.Join(labels[i+2:], ".")
+		}
+	}
+	return "", "", name
+}
+
+// Match decodes an mDNS packet and matches every string of the TXT records for
+// services with a Recog database, such as _workstation._tcp and _device-info._tcp.
+// Each string is matched in the "key=value" form the databases expect. All TXT
+// records are returned, including those for services without a database.
+func Match(fs *recog.FingerprintSet, data []byte) ([]*TXTRecord, error) {
+	m, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	records, err := m.TXTRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, txt := range records {
+		txt.MatchKey = MatchKeyForService(txt.Service)
+		if txt.MatchKey == "" {
+			continue
+		}
+		for _, s := range txt.Strings {
+			matches, err := fs.MatchAll(txt.MatchKey, s)
+			if err != nil {
+				return records, err
+			}
+			txt.Matches = append(txt.Matches, matches...)
+		}
+	}
+	return records, nil
+}
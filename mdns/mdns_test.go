@@ -0,0 +1,106 @@
+package mdns
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+func loadFixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile("testdata/" + name + ".hex")
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %s", name, err)
+	}
+	raw, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("bad fixture %s: %s", name, err)
+	}
+	return raw
+}
+
+func TestParse(t *testing.T) {
+	m, err := Parse(loadFixture(t, "macos_device_info"))
+	if err != nil {
+		t.Fatalf("Parse() failed: %s", err)
+	}
+	if !m.Response() || len(m.Records) != 3 {
+		t.Fatalf("Parse() returned unexpected message: %+v", m)
+	}
+
+	names := []string{"_device-info._tcp.local", "MacBook-Pro._device-info._tcp.local", "MacBook-Pro.local"}
+	for i, rr := range m.Records {
+		if rr.Name != names[i] {
+			t.Errorf("record %d has name %q, expected %q", i, rr.Name, names[i])
+		}
+	}
+	if rr := m.Records[1]; rr.Type != TypeTXT || rr.Class != 1 || !rr.CacheFlush {
+		t.Errorf("TXT record has unexpected type or class: %+v", rr)
+	}
+
+	records, err := m.TXTRecords()
+	if err != nil {
+		t.Fatalf("TXTRecords() failed: %s", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("TXTRecords() returned %d records", len(records))
+	}
+	txt := records[0]
+	if txt.Instance != "MacBook-Pro" || txt.Service != "_device-info._tcp" || txt.Domain != "local" {
+		t.Errorf("TXTRecords() split the name incorrectly: %q %q %q", txt.Instance, txt.Service, txt.Domain)
+	}
+	if txt.Values["model"] != "MacBookPro16,1" || txt.Values["ecolor"] != "157,157,160" {
+		t.Errorf("TXTRecords() returned unexpected values: %v", txt.Values)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	data := loadFixture(t, "macos_device_info")
+
+	if _, err := Parse(data[:len(data)-2]); err == nil {
+		t.Errorf("Parse() accepted truncated record data")
+	}
+
+	// Point the TXT record name at itself
+	loop := append([]byte(nil), data...)
+	loop[47], loop[48] = 0xc0, 47
+	if _, err := Parse(loop); err == nil {
+		t.Errorf("Parse() accepted a name pointer loop")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	fset, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	records, err := Match(fset, loadFixture(t, "macos_device_info"))
+	if err != nil {
+		t.Fatalf("Match() failed: %s", err)
+	}
+	txt := records[0]
+	if txt.MatchKey != DeviceInfoMatchKey || len(txt.Matches) != 2 {
+		t.Fatalf("Match() returned unexpected matches for %s: %v", txt.MatchKey, txt.Matches)
+	}
+	if txt.Matches[0].Values["hw.product"] != "MacBook Pro (16-inch, 2019)" || txt.Matches[1].Values["os.version"] != "12.0" {
+		t.Errorf("Match() did not identify the device: %v %v", txt.Matches[0].Values, txt.Matches[1].Values)
+	}
+
+	records, err = Match(fset, loadFixture(t, "avahi_workstation"))
+	if err != nil {
+		t.Fatalf("Match() failed: %s", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Match() returned %d records", len(records))
+	}
+	if records[0].MatchKey != WorkstationMatchKey || len(records[0].Matches) != 1 || records[0].Matches[0].Values["service.product"] != "Avahi" {
+		t.Errorf("Match() did not identify the workstation service: %+v", records[0])
+	}
+	other := records[1]
+	if other.MatchKey != "" || len(other.Matches) != 0 || other.Values["path"] != "/" || other.Values["txtvers"] != "" {
+		t.Errorf("Match() returned unexpected data for an unknown service: %+v", other)
+	}
+}
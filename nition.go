@@ -3,10 +3,14 @@ package recog
 //go:generate go run gen/vfsdata/main.go
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -14,36 +18,368 @@ import (
 // FingerprintSet is a collection of loaded Recog fingerprint databases
 type FingerprintSet struct {
 	DatabasesByMatchKey map[string][]*FingerprintDB
-	Logger              *log.Logger
+	// NormalizersByMatchKey holds the steps applied to input before it is matched
+	// against a match key. Keys without an entry use DefaultNormalizers.
+	NormalizersByMatchKey map[string][]Normalizer
+	DefaultNormalizers    []Normalizer
+	// HexEncodeCaptures hex-encodes non-printable parameters captured by the
+	// MatchFirstBytes and MatchAllBytes methods
+	HexEncodeCaptures bool
+	// MaxInputSize limits the bytes of input matched per call, with oversized
+	// input truncated or rejected according to OversizeInput. Zero is unlimited.
+	MaxInputSize  int
+	OversizeInput OversizePolicy
+	// MaxMatchTime limits the time spent in a single match or traversal call
+	MaxMatchTime time.Duration
+	// Routing decides which extracted values TraverseMatch matches further, with
+	// DefaultRoutes used when it is nil
+	Routing *RoutingTable
+	// Cache optionally stores results for repeated input, keyed by the input as it
+	// is matched after the size limit and normalization. It is purged when
+	// databases are loaded, and should be purged by the caller after changing the
+	// loaded databases in any other way.
+	Cache  *MatchCache
+	Logger *log.Logger
 }
 
+// OversizePolicy selects what happens to input longer than MaxInputSize
+type OversizePolicy int
+
+const (
+	// TruncateOversize matches the first MaxInputSize bytes and marks the matches
+	TruncateOversize OversizePolicy = iota
+	// RejectOversize returns ErrInputTooLarge without matching
+	RejectOversize
+)
+
+var (
+	// ErrInputTooLarge is returned for input over MaxInputSize with RejectOversize
+	ErrInputTooLarge = errors.New("input exceeds the maximum size")
+	// ErrMatchTimeout is returned when a call runs longer than MaxMatchTime
+	ErrMatchTimeout = errors.New("matching exceeded the maximum time")
+)
+
 // NewFingerprintSet returns an allocated FingerprintSet structure
 func NewFingerprintSet() *FingerprintSet {
 	fs := &FingerprintSet{}
 	fs.DatabasesByMatchKey = make(map[string][]*FingerprintDB)
+	fs.NormalizersByMatchKey = make(map[string][]Normalizer)
+	fs.Routing = DefaultRoutingTable()
 	return fs
 }
 
-// MatchFirst matches data to a given fingerprint database
-func (fs *FingerprintSet) MatchFirst(name string, data string) (*FingerprintMatch, error) {
-	if fdbs, ok := fs.DatabasesByMatchKey[name]; ok {
-		return fdbs[0].MatchFirst(data), nil
+// DebugLogf writes a message about the set to the debug log, if enabled
+func (fs *FingerprintSet) DebugLogf(format string, args ...interface{}) {
+	if fs.Logger == nil {
+		return
 	}
+	fs.Logger.Printf("[recog] "+strings.TrimSpace(format), args...)
+}
 
-	return nil, fmt.Errorf("database %s is missing", name)
+// AddRoutes extends the routing table used by TraverseMatch
+func (fs *FingerprintSet) AddRoutes(routes ...Route) {
+	if fs.Routing == nil {
+		fs.Routing = DefaultRoutingTable()
+	}
+	fs.Routing.Add(routes...)
 }
 
-// MatchAll matches data to a given fingerprint database
-func (fs *FingerprintSet) MatchAll(name string, data string) ([]*FingerprintMatch, error) {
+// routing returns the routing table, falling back to the defaults
+func (fs *FingerprintSet) routing() *RoutingTable {
+	if fs.Routing == nil {
+		return defaultRouting
+	}
+	return fs.Routing
+}
+
+// SetNormalizers configures the steps applied to input for a match key. Calling it
+// with no steps disables normalization for that key, even if defaults are set.
+func (fs *FingerprintSet) SetNormalizers(name string, steps ...Normalizer) {
+	if fs.NormalizersByMatchKey == nil {
+		fs.NormalizersByMatchKey = make(map[string][]Normalizer)
+	}
+	fs.NormalizersByMatchKey[name] = steps
+	fs.Cache.Purge()
+}
+
+// EnableCache caches up to size results, replacing any existing cache
+func (fs *FingerprintSet) EnableCache(size int) {
+	fs.Cache = NewMatchCache(size)
+}
+
+// Normalize returns the input as it will be matched against a match key
+func (fs *FingerprintSet) Normalize(name string, data string) string {
+	steps, ok := fs.NormalizersByMatchKey[name]
+	if !ok {
+		steps = fs.DefaultNormalizers
+	}
+	return NormalizeInput(data, steps)
+}
+
+// setInput records the input of a call on its matches, along with the data that
+// was matched when the input was truncated or normalized
+func setInput(matches []*FingerprintMatch, data string, normalized string) {
+	for _, m := range matches {
+		m.Input = data
+		m.Normalized = ""
+		if data != normalized {
+			m.Normalized = normalized
+		}
+	}
+}
+
+// databases returns the databases for a match key
+func (fs *FingerprintSet) databases(name string) ([]*FingerprintDB, error) {
 	if fdbs, ok := fs.DatabasesByMatchKey[name]; ok {
-		var matches []*FingerprintMatch
-		for _, fdb := range fdbs {
-			matches = append(matches, fdb.MatchAll(data)...)
+		return fdbs, nil
+	}
+	return nil, fmt.Errorf("database %s is missing", name)
+}
+
+// databasesByName returns the databases loaded from the file name. Databases are
+// listed under both their match key and their name, so the entries for name also
+// hold the databases whose match key happens to equal it.
+func (fs *FingerprintSet) databasesByName(name string) []*FingerprintDB {
+	var res []*FingerprintDB
+	for _, fdb := range fs.DatabasesByMatchKey[name] {
+		if fdb.Name == name {
+			res = append(res, fdb)
+		}
+	}
+	return res
+}
+
+// limitString applies MaxInputSize, truncating at a UTF-8 character boundary
+func (fs *FingerprintSet) limitString(data string) (string, bool, error) {
+	if fs.MaxInputSize <= 0 || len(data) <= fs.MaxInputSize {
+		return data, false, nil
+	}
+	if fs.OversizeInput == RejectOversize {
+		return "", false, ErrInputTooLarge
+	}
+	n := fs.MaxInputSize
+	for n > 0 && !utf8.RuneStart(data[n]) {
+		n--
+	}
+	return data[:n], true, nil
+}
+
+// limitBytes applies MaxInputSize to binary data
+func (fs *FingerprintSet) limitBytes(data []byte) ([]byte, bool, error) {
+	if fs.MaxInputSize <= 0 || len(data) <= fs.MaxInputSize {
+		return data, false, nil
+	}
+	if fs.OversizeInput == RejectOversize {
+		return nil, false, ErrInputTooLarge
+	}
+	return data[:fs.MaxInputSize], true, nil
+}
+
+// budget applies MaxMatchTime to a context. Without a limit the context is used
+// as is, so that matching without one allocates nothing.
+func (fs *FingerprintSet) budget(ctx context.Context) (context.Context, context.CancelFunc) {
+	if fs.MaxMatchTime <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, fs.MaxMatchTime)
+}
+
+// budgetErr reports ErrMatchTimeout when the MaxMatchTime deadline, rather than
+// the caller's context, stopped matching
+func budgetErr(parent context.Context, err error) error {
+	if err != nil && parent.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return ErrMatchTimeout
+	}
+	return err
+}
+
+// eachDatabase matches the input against the first database for a match key, or
+// all of them, stopping early if the context is done or a database suppresses the
+// input. The data is only used for logging.
+func eachDatabase(ctx context.Context, fdbs []*FingerprintDB, first bool, data interface{}, match func(*Fingerprint) *FingerprintMatch) ([]*FingerprintMatch, error) {
+	if first {
+		matches, err := fdbs[0].matchContext(ctx, data, true, match)
+		if err != nil || IsSuppressed(matches) {
+			return matches, err
+		}
+		// only the first database is used, but the suppressors of all of them apply
+		for _, fdb := range fdbs[1:] {
+			if m := fdb.suppression(data, match); m != nil {
+				return []*FingerprintMatch{m}, nil
+			}
 		}
 		return matches, nil
 	}
+	var matches []*FingerprintMatch
+	for _, fdb := range fdbs {
+		ms, err := fdb.matchContext(ctx, data, false, match)
+		if IsSuppressed(ms) {
+			return ms, err
+		}
+		matches = append(matches, ms...)
+		if err != nil {
+			return matches, err
+		}
+	}
+	return matches, nil
+}
 
-	return nil, fmt.Errorf("database %s is missing", name)
+// markTruncated records that matches were made against truncated input
+func markTruncated(matches []*FingerprintMatch, truncated bool) {
+	for _, m := range matches {
+		m.Truncated = truncated
+	}
+}
+
+// matchString applies the input limits and normalization for a match key and
+// matches the result. The time budget is applied by the caller.
+func (fs *FingerprintSet) matchString(ctx context.Context, name string, data string, first bool) ([]*FingerprintMatch, error) {
+	fdbs, err := fs.databases(name)
+	if err != nil {
+		return nil, err
+	}
+	input, truncated, err := fs.limitString(data)
+	if err != nil {
+		return nil, err
+	}
+	normalized := fs.Normalize(name, input)
+
+	// The cache is keyed by the input as matched, so changing the limits or the
+	// normalizers never returns a stale result
+	var key cacheKey
+	matches, cached := []*FingerprintMatch(nil), false
+	if fs.Cache != nil {
+		key = newCacheKey(name, []byte(normalized), first, false)
+		matches, cached = fs.Cache.get(key)
+	}
+	if !cached {
+		matches, err = eachDatabase(ctx, fdbs, first, normalized, func(fp *Fingerprint) *FingerprintMatch { return fp.Match(normalized) })
+		if err == nil {
+			fs.Cache.put(key, matches)
+		}
+	}
+	setInput(matches, data, normalized)
+	markTruncated(matches, truncated)
+	return matches, err
+}
+
+// matchBytes is the binary equivalent of matchString
+func (fs *FingerprintSet) matchBytes(ctx context.Context, name string, data []byte, first bool) ([]*FingerprintMatch, error) {
+	fdbs, err := fs.databases(name)
+	if err != nil {
+		return nil, err
+	}
+	input, truncated, err := fs.limitBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	normalized, changed := fs.normalizeBytes(name, input)
+
+	// Captures are cached before they are hex-encoded, so the setting can change
+	var key cacheKey
+	matches, cached := []*FingerprintMatch(nil), false
+	if fs.Cache != nil {
+		key = newCacheKey(name, normalized, first, true)
+		matches, cached = fs.Cache.get(key)
+	}
+	if !cached {
+		matches, err = eachDatabase(ctx, fdbs, first, normalized, func(fp *Fingerprint) *FingerprintMatch { return fp.MatchBytes(normalized) })
+		if err == nil {
+			fs.Cache.put(key, matches)
+		}
+	}
+	if changed || truncated {
+		setBytesInput(matches, data, normalized)
+	} else {
+		setBytesInput(matches, data, nil)
+	}
+	markTruncated(matches, truncated)
+	if fs.HexEncodeCaptures {
+		for _, m := range matches {
+			m.HexEncodeCaptures()
+		}
+	}
+	return matches, err
+}
+
+// firstMatch returns the only match of a MatchFirst call, if any
+func firstMatch(matches []*FingerprintMatch, err error) (*FingerprintMatch, error) {
+	if len(matches) == 0 {
+		return nil, err
+	}
+	return matches[0], err
+}
+
+// MatchFirst matches data to a given fingerprint database
+func (fs *FingerprintSet) MatchFirst(name string, data string) (*FingerprintMatch, error) {
+	return fs.MatchFirstContext(context.Background(), name, data)
+}
+
+// MatchFirstContext matches data to a given fingerprint database, stopping when
+// the context is done or MaxMatchTime has passed
+func (fs *FingerprintSet) MatchFirstContext(ctx context.Context, name string, data string) (*FingerprintMatch, error) {
+	bctx, cancel := fs.budget(ctx)
+	defer cancel()
+	m, err := firstMatch(fs.matchString(bctx, name, data, true))
+	return m, budgetErr(ctx, err)
+}
+
+// MatchAll matches data to a given fingerprint database
+func (fs *FingerprintSet) MatchAll(name string, data string) ([]*FingerprintMatch, error) {
+	return fs.MatchAllContext(context.Background(), name, data)
+}
+
+// MatchAllContext matches data to a given fingerprint database, stopping when the
+// context is done or MaxMatchTime has passed. Matches found before then are
+// returned along with the error.
+func (fs *FingerprintSet) MatchAllContext(ctx context.Context, name string, data string) ([]*FingerprintMatch, error) {
+	bctx, cancel := fs.budget(ctx)
+	defer cancel()
+	matches, err := fs.matchString(bctx, name, data, false)
+	return matches, budgetErr(ctx, err)
+}
+
+// normalizeBytes applies the normalizers for a match key to binary data, avoiding a
+// copy when none are configured
+func (fs *FingerprintSet) normalizeBytes(name string, data []byte) ([]byte, bool) {
+	steps, ok := fs.NormalizersByMatchKey[name]
+	if !ok {
+		steps = fs.DefaultNormalizers
+	}
+	if len(steps) == 0 {
+		return data, false
+	}
+	normalized := NormalizeInput(string(data), steps)
+	return []byte(normalized), normalized != string(data)
+}
+
+// MatchFirstBytes matches binary data to a given fingerprint database
+func (fs *FingerprintSet) MatchFirstBytes(name string, data []byte) (*FingerprintMatch, error) {
+	return fs.MatchFirstBytesContext(context.Background(), name, data)
+}
+
+// MatchFirstBytesContext matches binary data to a given fingerprint database,
+// stopping when the context is done or MaxMatchTime has passed
+func (fs *FingerprintSet) MatchFirstBytesContext(ctx context.Context, name string, data []byte) (*FingerprintMatch, error) {
+	bctx, cancel := fs.budget(ctx)
+	defer cancel()
+	m, err := firstMatch(fs.matchBytes(bctx, name, data, true))
+	return m, budgetErr(ctx, err)
+}
+
+// MatchAllBytes matches binary data to a given fingerprint database
+func (fs *FingerprintSet) MatchAllBytes(name string, data []byte) ([]*FingerprintMatch, error) {
+	return fs.MatchAllBytesContext(context.Background(), name, data)
+}
+
+// MatchAllBytesContext matches binary data to a given fingerprint database,
+// stopping when the context is done or MaxMatchTime has passed. Matches found
+// before then are returned along with the error.
+func (fs *FingerprintSet) MatchAllBytesContext(ctx context.Context, name string, data []byte) ([]*FingerprintMatch, error) {
+	bctx, cancel := fs.budget(ctx)
+	defer cancel()
+	matches, err := fs.matchBytes(bctx, name, data, false)
+	return matches, budgetErr(ctx, err)
 }
 
 // LoadFingerprints parses the embedded Recog XML databases, returning a FingerprintSet
@@ -56,13 +392,16 @@ func (fs *FingerprintSet) LoadFingerprintsDir(dname string) error {
 	return fs.LoadFingerprintsFromFS(http.Dir(dname))
 }
 
-// LoadFingerprintsFromFS parses an embedded Recog XML database, returning a FingerprintSet
+// LoadFingerprintsFromFS parses an embedded Recog XML database, returning a FingerprintSet.
+// A SuppressionsFile and CertaintyOverridesFile next to the databases are applied
+// once they are loaded.
 func (fs *FingerprintSet) LoadFingerprintsFromFS(efs http.FileSystem) error {
 	rootfs, err := efs.Open("/")
 	if err != nil {
 		return fmt.Errorf("failed to open root: %s", err.Error())
 	}
 	defer rootfs.Close()
+	defer fs.Cache.Purge()
 
 	files, err := rootfs.Readdir(65535)
 	if err != nil {
@@ -101,6 +440,12 @@ func (fs *FingerprintSet) LoadFingerprintsFromFS(efs http.FileSystem) error {
 		fs.DatabasesByMatchKey[fdb.Name] = append(fs.DatabasesByMatchKey[fdb.Name], &fdb)
 	}
 
+	if err := fs.loadSuppressionsFS(efs); err != nil {
+		return fmt.Errorf("failed to load %s: %s", SuppressionsFile, err)
+	}
+	if err := fs.loadCertaintyOverridesFS(efs); err != nil {
+		return fmt.Errorf("failed to load %s: %s", CertaintyOverridesFile, err)
+	}
 	return nil
 }
 
@@ -1,8 +1,12 @@
 package recog
 
 import (
+	"context"
 	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestLoad(t *testing.T) {
@@ -138,3 +142,321 @@ func TestX509Subjects(t *testing.T) {
 		t.Errorf("Failed to match 'iDRAC' expected product or vendor")
 	}
 }
+
+func TestMatchBytes(t *testing.T) {
+	fdb, err := LoadFingerprintDB("binary.xml", []byte(`<fingerprints matches="binary.banner">
+  <fingerprint pattern="^\x01\x02ID:(.{4}) NAME:(\S+)">
+    <description>Binary banner</description>
+    <param pos="0" name="hw.vendor" value="Example"/>
+    <param pos="1" name="hw.serial"/>
+    <param pos="2" name="hw.product"/>
+  </fingerprint>
+</fingerprints>`))
+	if err != nil {
+		t.Fatalf("LoadFingerprintDB() failed: %s", err)
+	}
+
+	data := []byte("\x01\x02ID:\x00\xff\x10A NAME:Widget")
+	m := fdb.MatchFirstBytes(data)
+	if m == nil {
+		t.Fatalf("MatchFirstBytes() failed to match")
+	}
+	if m.Values["hw.serial"] != "\x00\xff\x10A" || m.Values["hw.product"] != "Widget" {
+		t.Errorf("MatchFirstBytes() did not preserve the captured bytes: %q", m.Values)
+	}
+	if &m.InputBytes[0] != &data[0] || m.Input != "" || m.InputText() != string(data) {
+		t.Errorf("MatchFirstBytes() did not share the input: %q %q", m.InputBytes, m.Input)
+	}
+	if ms := fdb.MatchAllBytes(data); len(ms) != 1 || &ms[0].InputBytes[0] != &data[0] {
+		t.Errorf("MatchAllBytes() returned %d matches", len(ms))
+	}
+	if m := fdb.MatchFirst(string(data)); m == nil || m.Values["hw.serial"] != "\x00\xff\x10A" {
+		t.Errorf("MatchFirst() and MatchFirstBytes() disagree: %v", m)
+	}
+
+	fdb.HexEncodeCaptures = true
+	if m := fdb.MatchFirstBytes(data); m == nil || m.Values["hw.serial"] != "00ff1041" {
+		t.Errorf("FingerprintDB.MatchFirstBytes() did not hex-encode the binary capture: %v", m)
+	}
+	fdb.HexEncodeCaptures = false
+
+	fset := NewFingerprintSet()
+	fset.DatabasesByMatchKey[fdb.Matches] = []*FingerprintDB{&fdb}
+	fset.HexEncodeCaptures = true
+	m, err = fset.MatchFirstBytes("binary.banner", data)
+	if err != nil || m == nil {
+		t.Fatalf("MatchFirstBytes() failed: %v %s", m, err)
+	}
+	if m.Values["hw.serial"] != "00ff1041" || m.Values["hw.product"] != "Widget" || m.Values["hw.vendor"] != "Example" {
+		t.Errorf("MatchFirstBytes() did not hex-encode the binary capture: %q", m.Values)
+	}
+	if &m.InputBytes[0] != &data[0] || m.NormalizedBytes != nil {
+		t.Errorf("MatchFirstBytes() did not share the input: %q %q", m.InputBytes, m.NormalizedBytes)
+	}
+
+	fset.SetNormalizers("binary.banner", TrimCRLF)
+	ms, err := fset.MatchAllBytes("binary.banner", append(data, '\r', '\n'))
+	if err != nil || len(ms) != 1 || string(ms[0].NormalizedBytes) != string(data) {
+		t.Errorf("MatchAllBytes() did not normalize the input: %v %s", ms, err)
+	}
+
+	if _, err := fset.MatchAllBytes("missing", data); err == nil {
+		t.Errorf("MatchAllBytes() accepted a missing database")
+	}
+}
+
+func TestMatchLimits(t *testing.T) {
+	fset, err := LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	// A long tail after the header is cut off, but the match is still found
+	long := "Apache/2.4.41 (Ubuntu)" + strings.Repeat(" ", 1<<20)
+	fset.MaxInputSize = 64
+	m, err := fset.MatchFirst("http_header.server", long)
+	if err != nil || m == nil {
+		t.Fatalf("MatchFirst() failed on truncated input: %v %s", m, err)
+	}
+	if !m.Truncated || m.Input != long || len(m.Normalized) != 64 {
+		t.Errorf("MatchFirst() did not record the truncation: %v %d", m.Truncated, len(m.Normalized))
+	}
+	if m, _ := fset.MatchFirst("html_title.xml", "CloudKey"); m == nil || m.Truncated {
+		t.Errorf("MatchFirst() marked short input as truncated: %v", m)
+	}
+
+	// Truncation never splits a UTF-8 character
+	fset.MaxInputSize = 9
+	if m, _ := fset.MatchFirst("html_title.xml", "CloudKeyéé"); m == nil || m.Normalized != "CloudKey" {
+		t.Errorf("MatchFirst() split a UTF-8 character: %v", m)
+	}
+
+	fset.OversizeInput = RejectOversize
+	if _, err := fset.MatchAll("http_header.server", long); err != ErrInputTooLarge {
+		t.Errorf("MatchAll() did not reject oversized input: %v", err)
+	}
+	fset.MaxInputSize = 0
+
+	// Without a time limit the caller's context is used as is
+	if ctx, cancel := fset.budget(context.Background()); ctx != context.Background() {
+		t.Errorf("budget() wrapped a context without a time limit")
+	} else {
+		cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := fset.MatchAllContext(ctx, "html_title.xml", "CloudKey"); err != context.Canceled {
+		t.Errorf("MatchAllContext() ignored a cancelled context: %v", err)
+	}
+	if _, err := fset.MatchAllBytesContext(ctx, "html_title.xml", []byte("CloudKey")); err != context.Canceled {
+		t.Errorf("MatchAllBytesContext() ignored a cancelled context: %v", err)
+	}
+	if _, err := fset.DatabasesByMatchKey["html_title.xml"][0].MatchFirstBytesContext(ctx, []byte("CloudKey")); err != context.Canceled {
+		t.Errorf("FingerprintDB.MatchFirstBytesContext() ignored a cancelled context: %v", err)
+	}
+	if _, _, err := TraverseMatchContext(ctx, fset, "html_title.xml", "CloudKey"); err != context.Canceled {
+		t.Errorf("TraverseMatchContext() ignored a cancelled context: %v", err)
+	}
+
+	fset.MaxMatchTime = time.Nanosecond
+	if _, err := fset.MatchAll("html_title.xml", "CloudKey"); err != ErrMatchTimeout {
+		t.Errorf("MatchAll() did not enforce the time limit: %v", err)
+	}
+	if _, _, err := TraverseMatch(fset, "html_title.xml", "CloudKey"); err != ErrMatchTimeout {
+		t.Errorf("TraverseMatch() did not enforce the time limit: %v", err)
+	}
+
+	fset.MaxMatchTime = time.Minute
+	if nodes, _, err := TraverseMatch(fset, "html_title.xml", "CloudKey"); err != nil || len(nodes) == 0 {
+		t.Errorf("TraverseMatch() failed within the time limit: %v %s", nodes, err)
+	}
+}
+
+func TestMatchBatch(t *testing.T) {
+	fset, err := LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	inputs := []BatchItem{
+		{MatchKey: "hp_pjl_id.xml", Input: "Xerox ColorQube 8570DT"},
+		{MatchKey: "html_title.xml", Input: "CloudKey"},
+		{MatchKey: "html_title.xml", Input: "nothing to see here"},
+		{MatchKey: "missing", Input: "CloudKey"},
+	}
+	var items []BatchItem
+	for i := 0; i < 50; i++ {
+		for j, item := range inputs {
+			item.ID = i*len(inputs) + j
+			items = append(items, item)
+		}
+	}
+
+	results, stats := fset.MatchBatchSlice(context.Background(), items, BatchOptions{Workers: 4, First: true})
+	if len(results) != len(items) {
+		t.Fatalf("MatchBatchSlice() returned %d results for %d items", len(results), len(items))
+	}
+	for i, r := range results {
+		if r.Item.ID != i {
+			t.Fatalf("MatchBatchSlice() returned result %d out of order: %v", i, r.Item.ID)
+		}
+		switch i % len(inputs) {
+		case 0, 1:
+			if len(r.Matches) != 1 || r.Err != nil {
+				t.Errorf("item %d did not match: %v %v", i, r.Matches, r.Err)
+			}
+		case 2:
+			if r.Matched() || r.Err != nil {
+				t.Errorf("item %d matched unexpectedly: %v %v", i, r.Matches, r.Err)
+			}
+		case 3:
+			if r.Err == nil {
+				t.Errorf("item %d did not report the missing database", i)
+			}
+		}
+	}
+	if stats.Items != 200 || stats.Matched != 100 || stats.Errors != 50 || stats.ItemsPerSecond() <= 0 {
+		t.Errorf("MatchBatchSlice() returned unexpected stats: %+v", stats)
+	}
+
+	// Unordered traversal still delivers every item exactly once
+	ch := make(chan BatchItem)
+	go func() {
+		for _, item := range items {
+			ch <- item
+		}
+		close(ch)
+	}()
+	b := fset.MatchBatch(context.Background(), ch, BatchOptions{Workers: 3, Unordered: true, Traverse: true})
+	seen := make(map[interface{}]bool)
+	for r := range b.Results() {
+		if seen[r.Item.ID] {
+			t.Errorf("MatchBatch() returned item %v twice", r.Item.ID)
+		}
+		seen[r.Item.ID] = true
+		if r.Item.Input == "CloudKey" && r.Item.MatchKey == "html_title.xml" && len(r.Nodes) == 0 {
+			t.Errorf("MatchBatch() did not traverse item %v", r.Item.ID)
+		}
+	}
+	if len(seen) != len(items) || b.Stats().Items != int64(len(items)) {
+		t.Errorf("MatchBatch() returned %d of %d items", len(seen), len(items))
+	}
+
+	// A cancelled batch stops reading input and closes the results
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, _ = fset.MatchBatchSlice(ctx, items, BatchOptions{})
+	if len(results) == len(items) {
+		t.Errorf("MatchBatchSlice() ignored a cancelled context")
+	}
+
+	// An ordered batch stops reading input while an early item is slow, rather than
+	// buffering every later result
+	release := make(chan struct{})
+	fset.AddRoutes(Route{Key: "matched", MatchKeys: []string{"html_title.xml"}, Transform: func(string) []string {
+		<-release
+		return nil
+	}})
+	var fed int64
+	ch = make(chan BatchItem)
+	go func() {
+		defer close(ch)
+		ch <- BatchItem{ID: 0, MatchKey: "html_title.xml", Input: "CloudKey"}
+		for i := 1; i < 100; i++ {
+			ch <- BatchItem{ID: i, MatchKey: "html_title.xml", Input: "nothing to see here"}
+			atomic.AddInt64(&fed, 1)
+		}
+	}()
+	b = fset.MatchBatch(context.Background(), ch, BatchOptions{Workers: 2, Traverse: true})
+	time.Sleep(100 * time.Millisecond)
+	if n := atomic.LoadInt64(&fed); n > 2*reorderWindow {
+		t.Errorf("MatchBatch() read %d items past a slow one", n)
+	}
+	close(release)
+	next := 0
+	for r := range b.Results() {
+		if r.Item.ID != next {
+			t.Fatalf("MatchBatch() returned result %d out of order: %v", next, r.Item.ID)
+		}
+		next++
+	}
+	if next != 100 {
+		t.Errorf("MatchBatch() returned %d of 100 items", next)
+	}
+}
+
+func TestMatchCache(t *testing.T) {
+	fset, err := LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+	fset.EnableCache(2)
+
+	first, err := fset.MatchAll("http_header.server", "Apache/2.4.41 (Ubuntu)")
+	if err != nil || len(first) == 0 {
+		t.Fatalf("MatchAll() failed: %v %s", first, err)
+	}
+	// Modifying a result must not affect the cached copy
+	first[0].Values["service.version"] = "modified"
+
+	second, err := fset.MatchAll("http_header.server", "Apache/2.4.41 (Ubuntu)")
+	if err != nil || len(second) != len(first) {
+		t.Fatalf("MatchAll() from cache failed: %v %s", second, err)
+	}
+	if second[0].Values["service.version"] != "2.4.41" {
+		t.Errorf("MatchAll() returned a shared result: %v", second[0].Values)
+	}
+	if second[0].Fingerprint != first[0].Fingerprint {
+		t.Errorf("MatchAll() from cache returned a different fingerprint")
+	}
+
+	// Misses are cached too, and the oldest entry is evicted
+	if m, err := fset.MatchFirst("http_header.server", "no such server"); m != nil || err != nil {
+		t.Errorf("MatchFirst() matched unexpectedly: %v %s", m, err)
+	}
+	if _, err := fset.MatchFirst("http_header.server", "no such server"); err != nil {
+		t.Errorf("MatchFirst() failed: %s", err)
+	}
+	if _, err := fset.MatchFirstBytes("http_header.server", []byte("nginx/1.18.0")); err != nil {
+		t.Errorf("MatchFirstBytes() failed: %s", err)
+	}
+	stats := fset.Cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 3 || stats.Evictions != 1 || stats.Entries != 2 {
+		t.Errorf("Cache.Stats() returned %+v", stats)
+	}
+	if stats.HitRate() != 0.4 {
+		t.Errorf("CacheStats.HitRate() returned %f", stats.HitRate())
+	}
+
+	// Changing normalization and reloading both invalidate the cache
+	fset.SetNormalizers("http_header.server", TrimCRLF)
+	if n := fset.Cache.Stats().Entries; n != 0 {
+		t.Errorf("SetNormalizers() left %d cache entries", n)
+	}
+	fset.MatchAll("http_header.server", "Apache/2.4.41 (Ubuntu)")
+
+	// Inputs that are matched the same way share an entry but keep their own input
+	hits := fset.Cache.Stats().Hits
+	a, _ := fset.MatchFirst("http_header.server", "nginx/1.18.0\r\n")
+	b, _ := fset.MatchFirst("http_header.server", "nginx/1.18.0")
+	if a == nil || b == nil || fset.Cache.Stats().Hits != hits+1 {
+		t.Fatalf("MatchFirst() did not share a cache entry: %v %v", a, b)
+	}
+	if a.Input != "nginx/1.18.0\r\n" || a.Normalized != "nginx/1.18.0" || b.Input != "nginx/1.18.0" || b.Normalized != "" {
+		t.Errorf("MatchFirst() from cache returned the wrong input: %q %q %q %q", a.Input, a.Normalized, b.Input, b.Normalized)
+	}
+	// and settings changed after caching apply to the result
+	fset.MaxInputSize = 5
+	if m, _ := fset.MatchFirst("http_header.server", "nginx/1.18.0"); m == nil || !m.Truncated || m.Normalized != "nginx" {
+		t.Errorf("MatchFirst() from cache ignored the size limit: %+v", m)
+	}
+	fset.MaxInputSize = 0
+
+	if err := fset.LoadFingerprints(); err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+	if n := fset.Cache.Stats().Entries; n != 0 {
+		t.Errorf("LoadFingerprints() left %d cache entries", n)
+	}
+}
@@ -0,0 +1,102 @@
+package recog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Normalizer is a single step that cleans up input before it is matched
+type Normalizer func(string) string
+
+// TrimCRLF removes leading and trailing carriage returns and line feeds
+func TrimCRLF(s string) string {
+	return strings.Trim(s, "\r\n")
+}
+
+// StripNUL removes NUL bytes, often used as padding by embedded devices
+func StripNUL(s string) string {
+	return strings.Replace(s, "\x00", "", -1)
+}
+
+// DecodeLatin1 converts input that is not valid UTF-8 from ISO-8859-1, so that
+// bytes such as 0xE9 match patterns written with the equivalent character. Input
+// that is already valid UTF-8 is returned unchanged.
+func DecodeLatin1(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	runes := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		runes[i] = rune(s[i])
+	}
+	return string(runes)
+}
+
+// ReplaceInvalidUTF8 replaces each run of invalid UTF-8 bytes with U+FFFD
+func ReplaceInvalidUTF8(s string) string {
+	return strings.ToValidUTF8(s, "\uFFFD")
+}
+
+// ansiPattern matches CSI sequences such as colors and cursor movement, OSC
+// sequences such as window titles, and two byte escapes
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI removes ANSI terminal escape sequences
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// StripControl removes control characters other than tab, CR and LF. Invalid
+// UTF-8 bytes are left in place.
+func StripControl(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b.WriteByte(s[i])
+		} else if !unicode.IsControl(r) || r == '\t' || r == '\r' || r == '\n' {
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// normalizerNames maps configuration names to the built-in steps
+var normalizerNames = map[string]Normalizer{
+	"trim-crlf":     TrimCRLF,
+	"strip-nul":     StripNUL,
+	"latin1":        DecodeLatin1,
+	"valid-utf8":    ReplaceInvalidUTF8,
+	"strip-ansi":    StripANSI,
+	"strip-control": StripControl,
+}
+
+// LookupNormalizer returns the built-in step with the given name: trim-crlf,
+// strip-nul, latin1, valid-utf8, strip-ansi or strip-control
+func LookupNormalizer(name string) (Normalizer, error) {
+	if n, ok := normalizerNames[name]; ok {
+		return n, nil
+	}
+	return nil, fmt.Errorf("unknown normalizer %s", name)
+}
+
+// StandardNormalizers is a pipeline suitable for most raw banners. Escape
+// sequences are removed before control characters so their parameters do not
+// remain as text, and charset decoding runs before anything that inspects runes.
+var StandardNormalizers = []Normalizer{StripNUL, DecodeLatin1, StripANSI, StripControl, TrimCRLF}
+
+// NormalizeInput applies each step in order
+func NormalizeInput(s string, steps []Normalizer) string {
+	for _, step := range steps {
+		s = step(s)
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package recog
+
+import (
+	"testing"
+)
+
+func TestNormalizers(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       string
+		expected string
+	}{
+		{"trim-crlf", "\r\nSSH-2.0-OpenSSH_8.2p1\r\n", "SSH-2.0-OpenSSH_8.2p1"},
+		{"strip-nul", "RouterOS\x00\x00\x00", "RouterOS"},
+		{"latin1", "Caf\xe9 Server", "Café Server"},
+		{"latin1", "Café Server", "Café Server"},
+		{"valid-utf8", "bad\xff\xfebytes", "bad�bytes"},
+		{"strip-ansi", "\x1b[1;32mlogin:\x1b[0m \x1b]0;router\x07ok", "login: ok"},
+		{"strip-control", "a\x01b\x7fc\td\r\n\xff", "abc\td\r\n\xff"},
+	}
+
+	for _, c := range cases {
+		n, err := LookupNormalizer(c.name)
+		if err != nil {
+			t.Fatalf("LookupNormalizer(%s) failed: %s", c.name, err)
+		}
+		if out := n(c.in); out != c.expected {
+			t.Errorf("%s(%q) returned %q, expected %q", c.name, c.in, out, c.expected)
+		}
+	}
+
+	if _, err := LookupNormalizer("rot13"); err == nil {
+		t.Errorf("LookupNormalizer() accepted an unknown name")
+	}
+}
+
+func TestFingerprintSetNormalizers(t *testing.T) {
+	fset, err := LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	raw := "\x1b[0mXerox ColorQube 8570DT\x00\x00\r\n"
+	if m, _ := fset.MatchFirst("hp_pjl_id.xml", raw); m != nil && m.Normalized != "" {
+		t.Errorf("MatchFirst() normalized input without any normalizers configured")
+	}
+
+	fset.SetNormalizers("hp_pjl_id.xml", StandardNormalizers...)
+	m, err := fset.MatchFirst("hp_pjl_id.xml", raw)
+	if err != nil || m == nil || m.Values["os.product"] != "8570DT" {
+		t.Fatalf("MatchFirst() did not match normalized input: %v %s", m, err)
+	}
+	if m.Input != raw || m.Normalized != "Xerox ColorQube 8570DT" {
+		t.Errorf("MatchFirst() did not keep the original input: %q %q", m.Input, m.Normalized)
+	}
+
+	ms, err := fset.MatchAll("hp_pjl_id.xml", raw)
+	if err != nil || len(ms) == 0 || ms[0].Input != raw {
+		t.Errorf("MatchAll() did not match normalized input: %v %s", ms, err)
+	}
+
+	// Defaults apply to keys without their own steps, and an empty list disables them
+	fset.DefaultNormalizers = []Normalizer{TrimCRLF}
+	if m, _ := fset.MatchFirst("html_title.xml", "CloudKey\r\n"); m == nil || m.Input != "CloudKey\r\n" {
+		t.Errorf("MatchFirst() did not apply the default normalizers: %v", m)
+	}
+	fset.SetNormalizers("html_title.xml")
+	if m, _ := fset.MatchFirst("html_title.xml", "CloudKey"); m == nil || m.Normalized != "" {
+		t.Errorf("MatchFirst() reported normalization for unchanged input: %v", m)
+	}
+}
@@ -0,0 +1,135 @@
+package recog
+
+import (
+	"sort"
+	"strings"
+)
+
+// RouteTransform turns an extracted value into the inputs matched against a route's
+// match keys, returning nothing to skip the value
+type RouteTransform func(string) []string
+
+// Route sends the values extracted under a key to other match keys during
+// TraverseMatch
+type Route struct {
+	Key       string
+	MatchKeys []string
+	// Transform prepares the value for matching, or nil to match it unchanged
+	Transform RouteTransform
+}
+
+// inputs returns the non-empty inputs for a value
+func (r *Route) inputs(value string) []string {
+	if r.Transform == nil {
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+		return []string{value}
+	}
+	var res []string
+	for _, v := range r.Transform(value) {
+		if strings.TrimSpace(v) != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// RoutingTable holds the routes consulted by TraverseMatch, keyed by value key
+type RoutingTable struct {
+	routes map[string][]Route
+}
+
+// NewRoutingTable returns a table with the given routes
+func NewRoutingTable(routes ...Route) *RoutingTable {
+	t := &RoutingTable{routes: make(map[string][]Route)}
+	t.Add(routes...)
+	return t
+}
+
+// DefaultRoutes chain the standard recog keys to the databases that refine them
+var DefaultRoutes = []Route{
+	{Key: "apache.info", MatchKeys: []string{"apache_modules"}, Transform: SplitApacheModules},
+	{Key: "apache.info", MatchKeys: []string{"apache_os"}},
+	{Key: "tomcat.info", MatchKeys: []string{"operating_system.name", "architecture"}, Transform: SplitOn(";")},
+	{Key: "jetty.info", MatchKeys: []string{"operating_system.name"}, Transform: TrimAfter("/")},
+	{Key: "jetty.info", MatchKeys: []string{"architecture"}},
+	{Key: "mercur.os.info", MatchKeys: []string{"operating_system.name"}},
+	{Key: "postfix.os.info", MatchKeys: []string{"operating_system.name"}},
+}
+
+// defaultRouting is used by sets without a routing table
+var defaultRouting = DefaultRoutingTable()
+
+// DefaultRoutingTable returns a new table holding DefaultRoutes, which callers may
+// extend
+func DefaultRoutingTable() *RoutingTable {
+	return NewRoutingTable(DefaultRoutes...)
+}
+
+// Add appends routes to the table
+func (t *RoutingTable) Add(routes ...Route) {
+	for _, r := range routes {
+		t.routes[r.Key] = append(t.routes[r.Key], r)
+	}
+}
+
+// Remove deletes every route for a key
+func (t *RoutingTable) Remove(key string) {
+	delete(t.routes, key)
+}
+
+// Routes returns the routes for a value key
+func (t *RoutingTable) Routes(key string) []Route {
+	return t.routes[key]
+}
+
+// Keys returns the routed value keys in sorted order
+func (t *RoutingTable) Keys() []string {
+	keys := make([]string, 0, len(t.routes))
+	for k := range t.routes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SplitApacheModules splits the text after an Apache version, such as
+// "(Unix) mod_ssl/2.8.28 OpenSSL/0.9.7e-p1", into its modules. Parenthesized
+// operating system details are dropped.
+func SplitApacheModules(s string) []string {
+	var res []string
+	depth := 0
+	for _, f := range strings.Fields(s) {
+		if depth == 0 && !strings.HasPrefix(f, "(") {
+			res = append(res, f)
+			continue
+		}
+		depth += strings.Count(f, "(") - strings.Count(f, ")")
+		if depth < 0 {
+			depth = 0
+		}
+	}
+	return res
+}
+
+// SplitOn returns a transform that splits a value on a separator and trims each part
+func SplitOn(sep string) RouteTransform {
+	return func(s string) []string {
+		parts := strings.Split(s, sep)
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		return parts
+	}
+}
+
+// TrimAfter returns a transform that keeps the part of a value before a separator
+func TrimAfter(sep string) RouteTransform {
+	return func(s string) []string {
+		if i := strings.Index(s, sep); i >= 0 {
+			s = s[:i]
+		}
+		return []string{strings.TrimSpace(s)}
+	}
+}
@@ -0,0 +1,115 @@
+package scaninput
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/runZeroInc/recog-go/banners"
+)
+
+// masscanServices maps masscan banner service names to a match key and transform.
+// The "http" service holds the raw response headers and is handled separately.
+var masscanServices = map[string]struct {
+	MatchKey  string
+	Transform transform
+}{
+	"http.server": {"http_header.server", trimmed},
+	"title":       {"html_title", banners.CleanTitle},
+	"ssh":         {"ssh.banner", sshSoftware},
+	"ftp":         {"ftp.banner", banners.ReplyText},
+	"smtp":        {"smtp.banner", banners.ReplyText},
+	"pop3":        {"pop3.banner", pop3Text},
+	"imap4":       {"imap4.banner", imapText},
+	"telnet":      {"telnet_banners.xml", trimmed},
+}
+
+// masscanResult is a single host entry of masscan JSON output
+type masscanResult struct {
+	IP    string `json:"ip"`
+	Ports []struct {
+		Port    int    `json:"port"`
+		Proto   string `json:"proto"`
+		Service *struct {
+			Name   string `json:"name"`
+			Banner string `json:"banner"`
+		} `json:"service"`
+	} `json:"ports"`
+}
+
+// MasscanReader reads masscan JSON output. Both the -oJ array form, which writes
+// one object per line and is not always valid JSON, and the -oD form with one
+// object per line are accepted.
+type MasscanReader struct {
+	scanner *bufio.Scanner
+	line    int
+	queue
+}
+
+// NewMasscanReader returns a reader for masscan JSON output
+func NewMasscanReader(r io.Reader) *MasscanReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return &MasscanReader{scanner: scanner}
+}
+
+// Next returns the next record, reading more lines as needed
+func (m *MasscanReader) Next() (*Record, error) {
+	for {
+		if rec := m.pop(); rec != nil {
+			return rec, nil
+		}
+		if !m.scanner.Scan() {
+			if err := m.scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		m.line++
+
+		// Strip the array punctuation masscan wraps around each object
+		line := strings.TrimSpace(m.scanner.Text())
+		line = strings.TrimPrefix(line, "[")
+		line = strings.TrimSuffix(line, "]")
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), ","))
+		// Older versions end the array with an unquoted {finished: 1} object
+		if line == "" || strings.HasPrefix(line, "{finished:") {
+			continue
+		}
+
+		var res masscanResult
+		if err := json.Unmarshal([]byte(line), &res); err != nil {
+			return nil, fmt.Errorf("masscan line %d: %s", m.line, err)
+		}
+		m.add(&res)
+	}
+}
+
+// add queues a record for every banner in a host entry
+func (m *MasscanReader) add(res *masscanResult) {
+	for _, p := range res.Ports {
+		if p.Service == nil {
+			continue
+		}
+		base := Record{
+			Host:      res.IP,
+			Port:      p.Port,
+			Transport: p.Proto,
+			Field:     "service." + p.Service.Name,
+		}
+
+		if p.Service.Name == "http" {
+			m.pushHTTPResponse(base, p.Service.Banner)
+			continue
+		}
+		svc, ok := masscanServices[p.Service.Name]
+		if !ok {
+			continue
+		}
+		base.MatchKey = svc.MatchKey
+		base.Input = svc.Transform(p.Service.Banner)
+		m.push(&base)
+	}
+}
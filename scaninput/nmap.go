@@ -0,0 +1,163 @@
+package scaninput
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/runZeroInc/recog-go/banners"
+)
+
+// nmapBannerServices maps Nmap service names to the database for the output of
+// the banner script
+var nmapBannerServices = map[string]struct {
+	MatchKey  string
+	Transform transform
+}{
+	"ssh":    {"ssh.banner", sshSoftware},
+	"ftp":    {"ftp.banner", banners.ReplyText},
+	"smtp":   {"smtp.banner", banners.ReplyText},
+	"pop3":   {"pop3.banner", pop3Text},
+	"imap":   {"imap4.banner", imapText},
+	"telnet": {"telnet_banners.xml", trimmed},
+}
+
+var nmapEscapePat = regexp.MustCompile(`\\x[0-9A-Fa-f]{2}`)
+
+// unescapeNmap decodes the \xHH escapes the banner script writes for control
+// characters, such as the \x0D\x0A ending a greeting
+func unescapeNmap(s string) string {
+	return nmapEscapePat.ReplaceAllStringFunc(s, func(e string) string {
+		b, _ := strconv.ParseUint(e[2:], 16, 8)
+		return string([]byte{byte(b)})
+	})
+}
+
+// nmapHost is a host element of Nmap XML output
+type nmapHost struct {
+	Addresses []struct {
+		Addr     string `xml:"addr,attr"`
+		AddrType string `xml:"addrtype,attr"`
+	} `xml:"address"`
+	Ports []struct {
+		Protocol string `xml:"protocol,attr"`
+		PortID   string `xml:"portid,attr"`
+		State    struct {
+			State string `xml:"state,attr"`
+		} `xml:"state"`
+		Service struct {
+			Name string `xml:"name,attr"`
+		} `xml:"service"`
+		Scripts []nmapScript `xml:"script"`
+	} `xml:"ports>port"`
+}
+
+// nmapScript is the output of an NSE script
+type nmapScript struct {
+	ID     string `xml:"id,attr"`
+	Output string `xml:"output,attr"`
+	Elems  []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"elem"`
+}
+
+// elem returns the value of a structured script output element
+func (s *nmapScript) elem(key string) string {
+	for _, e := range s.Elems {
+		if e.Key == key {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+// NmapReader reads Nmap XML output one host at a time
+type NmapReader struct {
+	decoder *xml.Decoder
+	queue
+}
+
+// NewNmapReader returns a reader for Nmap XML output
+func NewNmapReader(r io.Reader) *NmapReader {
+	return &NmapReader{decoder: xml.NewDecoder(r)}
+}
+
+// Next returns the next record, decoding more hosts as needed
+func (n *NmapReader) Next() (*Record, error) {
+	for {
+		if rec := n.pop(); rec != nil {
+			return rec, nil
+		}
+
+		tok, err := n.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "host" {
+			continue
+		}
+
+		var host nmapHost
+		if err := n.decoder.DecodeElement(&host, &start); err != nil {
+			return nil, fmt.Errorf("nmap host: %s", err)
+		}
+		n.add(&host)
+	}
+}
+
+// add queues a record for every script output that feeds a database
+func (n *NmapReader) add(host *nmapHost) {
+	addr := ""
+	for _, a := range host.Addresses {
+		if a.AddrType == "ipv4" || a.AddrType == "ipv6" {
+			addr = a.Addr
+			break
+		}
+	}
+
+	for _, p := range host.Ports {
+		if p.State.State != "" && p.State.State != "open" {
+			continue
+		}
+		port, _ := strconv.Atoi(p.PortID)
+
+		for i := range p.Scripts {
+			s := &p.Scripts[i]
+			rec := Record{
+				Host:      addr,
+				Port:      port,
+				Transport: p.Protocol,
+				Field:     "script." + s.ID,
+			}
+
+			switch s.ID {
+			case "banner":
+				svc, ok := nmapBannerServices[p.Service.Name]
+				if !ok {
+					continue
+				}
+				rec.MatchKey = svc.MatchKey
+				rec.Input = svc.Transform(unescapeNmap(s.Output))
+				n.push(&rec)
+			case "http-server-header":
+				// Each distinct Server header is reported on its own line
+				for _, line := range strings.Split(s.Output, "\n") {
+					r := rec
+					r.MatchKey = "http_header.server"
+					r.Input = strings.TrimSpace(line)
+					n.push(&r)
+				}
+			case "http-title":
+				// Pages without a title only set the human-readable output
+				rec.MatchKey = "html_title"
+				rec.Input = banners.CleanTitle(s.elem("title"))
+				n.push(&rec)
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Package scaninput reads the output of network scanners and converts each
+// captured banner or header into a Recog match key and input. zgrab2 JSON,
+// masscan JSON and Nmap XML are supported. Readers stream their input and attach
+// the host and port each value was collected from.
+package scaninput
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/runZeroInc/recog-go/banners"
+)
+
+// Supported input formats
+const (
+	FormatZgrab2  = "zgrab2"
+	FormatMasscan = "masscan"
+	FormatNmap    = "nmap"
+)
+
+// Formats lists the formats accepted by NewReader
+var Formats = []string{FormatZgrab2, FormatMasscan, FormatNmap}
+
+// Record is a single value from a scan result, ready to be matched
+type Record struct {
+	Host      string `json:"host"`
+	Port      int    `json:"port,omitempty"`
+	Transport string `json:"transport,omitempty"`
+	// Field names the scanner field the input came from, such as
+	// "http.result.response.headers.server" or "script.banner"
+	Field    string `json:"field"`
+	MatchKey string `json:"match_key"`
+	Input    string `json:"input"`
+}
+
+// Reader returns records one at a time and io.EOF at the end of the input
+type Reader interface {
+	Next() (*Record, error)
+}
+
+// NewReader returns a reader for the named format
+func NewReader(format string, r io.Reader) (Reader, error) {
+	switch format {
+	case FormatZgrab2:
+		return NewZgrab2Reader(r), nil
+	case FormatMasscan:
+		return NewMasscanReader(r), nil
+	case FormatNmap:
+		return NewNmapReader(r), nil
+	}
+	return nil, fmt.Errorf("unsupported input format %q", format)
+}
+
+// queue buffers the records produced by one scanner result so a reader can return
+// them one at a time
+type queue struct {
+	records []*Record
+}
+
+func (q *queue) push(rec *Record) {
+	if rec.Input != "" {
+		q.records = append(q.records, rec)
+	}
+}
+
+func (q *queue) pop() *Record {
+	if len(q.records) == 0 {
+		return nil
+	}
+	rec := q.records[0]
+	q.records = q.records[1:]
+	return rec
+}
+
+// A transform converts a raw scanner value into the form a database expects and
+// returns an empty string if the value cannot be used
+type transform func(string) string
+
+// sshSoftware returns the software and comment of an SSH identification, or the
+// first line of a value that has no identification line
+func sshSoftware(s string) string {
+	if banners.SSHLine(s) == "" {
+		return banners.FirstLine(s)
+	}
+	return banners.SSHSoftware(s)
+}
+
+// pop3Text strips the status indicator from a POP3 greeting
+func pop3Text(s string) string {
+	line := banners.FirstLine(s)
+	for _, prefix := range []string{"+OK", "-ERR"} {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(line[len(prefix):])
+		}
+	}
+	return line
+}
+
+// imapText strips the untagged OK response from an IMAP greeting
+func imapText(s string) string {
+	line := banners.FirstLine(s)
+	if strings.HasPrefix(line, "* OK") {
+		return strings.TrimSpace(line[4:])
+	}
+	return line
+}
+
+// trimmed removes surrounding whitespace
+func trimmed(s string) string {
+	return strings.TrimSpace(s)
+}
+
+// httpHeaderMatchKeys maps HTTP header names to their match keys
+var httpHeaderMatchKeys = map[string]string{
+	"server":           "http_header.server",
+	"set-cookie":       "http_header.cookie",
+	"www-authenticate": "http_header.wwwauth",
+}
+
+// pushHTTPResponse adds a record for every matchable header of a raw HTTP response
+func (q *queue) pushHTTPResponse(base Record, raw string) {
+	headers := banners.Headers(raw)
+	for _, name := range []string{"server", "set-cookie", "www-authenticate"} {
+		for _, v := range headers[name] {
+			rec := base
+			rec.Field = base.Field + "." + name
+			rec.MatchKey = httpHeaderMatchKeys[name]
+			rec.Input = v
+			q.push(&rec)
+		}
+	}
+}
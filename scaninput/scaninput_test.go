@@ -0,0 +1,99 @@
+package scaninput
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+func readAll(t *testing.T, format string, name string) []Record {
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatalf("failed to open %s: %s", name, err)
+	}
+	defer f.Close()
+
+	r, err := NewReader(format, f)
+	if err != nil {
+		t.Fatalf("NewReader(%s) failed: %s", format, err)
+	}
+
+	var res []Record
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("%s: Next() failed: %s", format, err)
+		}
+		res = append(res, *rec)
+	}
+	return res
+}
+
+func TestReaders(t *testing.T) {
+	cases := []struct {
+		format   string
+		file     string
+		expected []Record
+	}{
+		{FormatZgrab2, "zgrab2.json", []Record{
+			{"192.0.2.10", 80, "tcp", "http.result.response.headers.server", "http_header.server", "nginx/1.18.0 (Ubuntu)"},
+			{"192.0.2.10", 80, "tcp", "http.result.response.headers.set_cookie", "http_header.cookie", "PHPSESSID=7f2c1d; path=/"},
+			{"192.0.2.10", 80, "tcp", "http.result.response.body", "html_title", "Welcome to nginx!"},
+			{"192.0.2.10", 22, "tcp", "ssh.result.server_id.raw", "ssh.banner", "OpenSSH_8.2p1 Ubuntu-4ubuntu0.5"},
+			{"192.0.2.11", 2121, "tcp", "alt-ftp.result.banner", "ftp.banner", "ProFTPD 1.3.5e Server (Debian) [::ffff:192.0.2.11]"},
+		}},
+		{FormatMasscan, "masscan.json", []Record{
+			{"192.0.2.20", 80, "tcp", "service.http.server", "http_header.server", "lighttpd/1.4.55"},
+			{"192.0.2.20", 80, "tcp", "service.http.www-authenticate", "http_header.wwwauth", `Basic realm="NETGEAR R7000"`},
+			{"192.0.2.20", 80, "tcp", "service.title", "html_title", "NETGEAR Router R7000"},
+			{"192.0.2.21", 22, "tcp", "service.ssh", "ssh.banner", "dropbear_2019.78"},
+		}},
+		{FormatNmap, "nmap.xml", []Record{
+			{"192.0.2.30", 22, "tcp", "script.banner", "ssh.banner", "OpenSSH_7.4"},
+			{"192.0.2.30", 25, "tcp", "script.banner", "smtp.banner", "mail.example.com ESMTP Postfix (Ubuntu)"},
+			{"192.0.2.30", 80, "tcp", "script.http-server-header", "http_header.server", "Apache/2.4.41 (Ubuntu)"},
+			{"192.0.2.30", 80, "tcp", "script.http-title", "html_title", "Apache2 Ubuntu Default Page: It works"},
+		}},
+	}
+
+	for _, c := range cases {
+		res := readAll(t, c.format, c.file)
+		if !reflect.DeepEqual(res, c.expected) {
+			t.Errorf("%s: unexpected records:\n%+v\nexpected:\n%+v", c.format, res, c.expected)
+		}
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	if _, err := NewReader("csv", nil); err == nil {
+		t.Errorf("NewReader() accepted an unsupported format")
+	}
+	r := NewMasscanReader(strings.NewReader(`{"ip": "192.0.2.1", "ports": [}`))
+	if _, err := r.Next(); err == nil {
+		t.Errorf("Next() accepted malformed masscan output")
+	}
+}
+
+func TestMatchRecords(t *testing.T) {
+	fset, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	for _, rec := range readAll(t, FormatZgrab2, "zgrab2.json") {
+		m, err := fset.MatchFirst(rec.MatchKey, rec.Input)
+		if err != nil {
+			t.Fatalf("MatchFirst(%s) failed: %s", rec.MatchKey, err)
+		}
+		if rec.MatchKey == "ssh.banner" && (m == nil || m.Values["service.product"] != "OpenSSH") {
+			t.Errorf("zgrab2 ssh banner did not match OpenSSH: %v", m)
+		}
+	}
+}
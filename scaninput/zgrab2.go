@@ -0,0 +1,172 @@
+package scaninput
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/runZeroInc/recog-go/banners"
+)
+
+// zgrab2Field maps a field path within a zgrab2 module result to a match key. The
+// first path element is the module protocol.
+type zgrab2Field struct {
+	Path      string
+	MatchKey  string
+	Transform transform
+}
+
+// zgrab2Fields lists the zgrab2 fields that feed recog databases
+var zgrab2Fields = []zgrab2Field{
+	{"http.result.response.headers.server", "http_header.server", trimmed},
+	{"http.result.response.headers.set_cookie", "http_header.cookie", trimmed},
+	{"http.result.response.headers.www_authenticate", "http_header.wwwauth", trimmed},
+	{"http.result.response.body", "html_title", banners.HTMLTitle},
+	{"ssh.result.server_id.raw", "ssh.banner", sshSoftware},
+	{"ftp.result.banner", "ftp.banner", banners.ReplyText},
+	{"smtp.result.banner", "smtp.banner", banners.ReplyText},
+	{"pop3.result.banner", "pop3.banner", pop3Text},
+	{"imap.result.banner", "imap4.banner", imapText},
+	{"telnet.result.banner", "telnet_banners.xml", trimmed},
+}
+
+// zgrab2Ports are the default ports of each module, used when a result does not
+// record the port it was collected from
+var zgrab2Ports = map[string]int{
+	"http":   80,
+	"ssh":    22,
+	"ftp":    21,
+	"smtp":   25,
+	"pop3":   110,
+	"imap":   143,
+	"telnet": 23,
+}
+
+// zgrab2Result is a single line of zgrab2 output
+type zgrab2Result struct {
+	IP     string                  `json:"ip"`
+	Domain string                  `json:"domain"`
+	Data   map[string]zgrab2Module `json:"data"`
+}
+
+// zgrab2Module is the output of one scan module
+type zgrab2Module struct {
+	Status   string      `json:"status"`
+	Protocol string      `json:"protocol"`
+	Port     int         `json:"port"`
+	Result   interface{} `json:"result"`
+}
+
+// Zgrab2Reader reads zgrab2 output, which is one JSON object per line
+type Zgrab2Reader struct {
+	scanner *bufio.Scanner
+	line    int
+	queue
+}
+
+// NewZgrab2Reader returns a reader for zgrab2 JSON lines
+func NewZgrab2Reader(r io.Reader) *Zgrab2Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	return &Zgrab2Reader{scanner: scanner}
+}
+
+// Next returns the next record, reading more lines as needed
+func (z *Zgrab2Reader) Next() (*Record, error) {
+	for {
+		if rec := z.pop(); rec != nil {
+			return rec, nil
+		}
+		if !z.scanner.Scan() {
+			if err := z.scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		z.line++
+
+		line := strings.TrimSpace(z.scanner.Text())
+		if line == "" {
+			continue
+		}
+		var res zgrab2Result
+		if err := json.Unmarshal([]byte(line), &res); err != nil {
+			return nil, fmt.Errorf("zgrab2 line %d: %s", z.line, err)
+		}
+		z.add(&res)
+	}
+}
+
+// add queues a record for every mapped field in every module of a result
+func (z *Zgrab2Reader) add(res *zgrab2Result) {
+	host := res.IP
+	if host == "" {
+		host = res.Domain
+	}
+
+	// Module names are chosen by the user, so visit them in a stable order
+	names := make([]string, 0, len(res.Data))
+	for name := range res.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		mod := res.Data[name]
+		proto := mod.Protocol
+		if proto == "" {
+			proto = name
+		}
+		port := mod.Port
+		if port == 0 {
+			port = zgrab2Ports[proto]
+		}
+		module := map[string]interface{}{"result": mod.Result}
+
+		for _, f := range zgrab2Fields {
+			path := strings.Split(f.Path, ".")
+			if path[0] != proto {
+				continue
+			}
+			for _, v := range lookup(module, path[1:]) {
+				z.push(&Record{
+					Host:      host,
+					Port:      port,
+					Transport: "tcp",
+					Field:     name + "." + strings.Join(path[1:], "."),
+					MatchKey:  f.MatchKey,
+					Input:     f.Transform(v),
+				})
+			}
+		}
+	}
+}
+
+// lookup follows a path through decoded JSON and returns the string values found,
+// flattening arrays such as multi-valued headers
+func lookup(v interface{}, path []string) []string {
+	if len(path) == 0 {
+		switch t := v.(type) {
+		case string:
+			return []string{t}
+		case []interface{}:
+			var res []string
+			for _, e := range t {
+				if s, ok := e.(string); ok {
+					res = append(res, s)
+				}
+			}
+			return res
+		}
+		return nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return lookup(m[path[0]], path[1:])
+}
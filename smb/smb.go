@@ -0,0 +1,191 @@
+// Package smb extracts the NativeOS and NativeLanMan strings from SMB1 Session
+// Setup AndX responses and fingerprints them against the Recog smb_native_os.xml
+// and smb_native_lm.xml databases.
+package smb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+// Recog match keys for the SMB databases
+const (
+	NativeOSMatchKey = "smb.native_os"
+	NativeLMMatchKey = "smb.native_lm"
+)
+
+// SMB1 header constants
+const (
+	headerLength            = 32
+	commandSessionSetupAndX = 0x73
+	flags2Unicode           = 0x8000
+)
+
+var protocolID = []byte{0xFF, 'S', 'M', 'B'}
+
+// SessionSetupResponse is a decoded SMB1 Session Setup AndX response
+type SessionSetupResponse struct {
+	Status           uint32
+	Flags2           uint16
+	Unicode          bool
+	ExtendedSecurity bool
+	UID              uint16
+	Action           uint16
+	SecurityBlob     []byte
+	NativeOS         string
+	NativeLanMan     string
+	PrimaryDomain    string
+}
+
+// ParseSessionSetupResponse decodes an SMB1 Session Setup AndX response. The data may
+// start with the SMB header or with a NetBIOS session service header.
+func ParseSessionSetupResponse(data []byte) (*SessionSetupResponse, error) {
+	// Skip the NetBIOS session message header when present
+	if len(data) >= 8 && data[0] == 0x00 && string(data[4:8]) == string(protocolID) {
+		data = data[4:]
+	}
+
+	if len(data) < headerLength+1 {
+		return nil, fmt.Errorf("smb message is too short (%d bytes)", len(data))
+	}
+	if string(data[0:4]) != string(protocolID) {
+		return nil, fmt.Errorf("smb protocol identifier is missing")
+	}
+	if data[4] != commandSessionSetupAndX {
+		return nil, fmt.Errorf("smb command 0x%02x is not session setup andx", data[4])
+	}
+
+	res := &SessionSetupResponse{
+		Status: binary.LittleEndian.Uint32(data[5:9]),
+		Flags2: binary.LittleEndian.Uint16(data[10:12]),
+		UID:    binary.LittleEndian.Uint16(data[28:30]),
+	}
+	res.Unicode = res.Flags2&flags2Unicode != 0
+
+	wordCount := int(data[headerLength])
+	wordsEnd := headerLength + 1 + wordCount*2
+	if len(data) < wordsEnd+2 {
+		return nil, fmt.Errorf("smb parameter block is truncated")
+	}
+	words := data[headerLength+1 : wordsEnd]
+
+	// Errors other than "more processing required" carry an empty response
+	switch wordCount {
+	case 0:
+		return nil, fmt.Errorf("smb session setup failed with status 0x%08x", res.Status)
+	case 3:
+		res.Action = binary.LittleEndian.Uint16(words[4:6])
+	case 4:
+		res.ExtendedSecurity = true
+		res.Action = binary.LittleEndian.Uint16(words[4:6])
+	default:
+		return nil, fmt.Errorf("smb session setup word count %d is not supported", wordCount)
+	}
+
+	byteCount := int(binary.LittleEndian.Uint16(data[wordsEnd : wordsEnd+2]))
+	offset := wordsEnd + 2
+	end := offset + byteCount
+	if end > len(data) {
+		// Tolerate captures that were truncated inside the string area
+		end = len(data)
+	}
+
+	if res.ExtendedSecurity {
+		blobLen := int(binary.LittleEndian.Uint16(words[6:8]))
+		if offset+blobLen > end {
+			return nil, fmt.Errorf("smb security blob length %d exceeds the data", blobLen)
+		}
+		res.SecurityBlob = data[offset : offset+blobLen]
+		offset += blobLen
+	}
+
+	// Unicode strings are aligned to a 16-bit boundary from the start of the SMB header
+	if res.Unicode && offset%2 == 1 {
+		offset++
+	}
+
+	fields := []*string{&res.NativeOS, &res.NativeLanMan, &res.PrimaryDomain}
+	for _, field := range fields {
+		if offset >= end {
+			break
+		}
+		var n int
+		if res.Unicode {
+			*field, n = readUnicodeString(data[offset:end])
+		} else {
+			*field, n = readOEMString(data[offset:end])
+		}
+		offset += n
+	}
+
+	return res, nil
+}
+
+// readUnicodeString decodes a NUL terminated UTF-16LE string, returning the string
+// and the number of bytes consumed including the terminator. A missing terminator
+// consumes the remainder of the data, which some servers emit for the final string.
+func readUnicodeString(data []byte) (string, int) {
+	var units []uint16
+	i := 0
+	for ; i+1 < len(data); i += 2 {
+		u := binary.LittleEndian.Uint16(data[i : i+2])
+		if u == 0 {
+			return string(utf16.Decode(units)), i + 2
+		}
+		units = append(units, u)
+	}
+	return string(utf16.Decode(units)), len(data)
+}
+
+// readOEMString decodes a NUL terminated OEM string, treating high bytes as Latin-1
+func readOEMString(data []byte) (string, int) {
+	n := len(data)
+	for i, b := range data {
+		if b == 0 {
+			data = data[:i]
+			n = i + 1
+			break
+		}
+	}
+
+	var sb strings.Builder
+	for _, b := range data {
+		sb.WriteRune(rune(b))
+	}
+	return sb.String(), n
+}
+
+// Result is a decoded response together with the matches for each native string
+type Result struct {
+	Response        *SessionSetupResponse
+	NativeOSMatches []*recog.FingerprintMatch
+	NativeLMMatches []*recog.FingerprintMatch
+}
+
+// Match decodes a Session Setup AndX response and matches its NativeOS and
+// NativeLanMan strings against the SMB databases in the provided FingerprintSet.
+func Match(fs *recog.FingerprintSet, data []byte) (*Result, error) {
+	resp, err := ParseSessionSetupResponse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{Response: resp}
+	if resp.NativeOS != "" {
+		res.NativeOSMatches, err = fs.MatchAll(NativeOSMatchKey, resp.NativeOS)
+		if err != nil {
+			return res, err
+		}
+	}
+	if resp.NativeLanMan != "" {
+		res.NativeLMMatches, err = fs.MatchAll(NativeLMMatchKey, resp.NativeLanMan)
+		if err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,94 @@
+package smb
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+func loadFixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile("testdata/" + name + ".hex")
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %s", name, err)
+	}
+	raw, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("bad fixture %s: %s", name, err)
+	}
+	return raw
+}
+
+func TestParseSessionSetupResponse(t *testing.T) {
+	cases := []struct {
+		fixture  string
+		unicode  bool
+		extended bool
+		os       string
+		lm       string
+		domain   string
+	}{
+		{"windows2003_unicode", true, false, "Windows Server 2003 3790 Service Pack 2", "Windows Server 2003 5.2", "WORKGROUP"},
+		{"samba_extended_security", true, true, "Unix", "Samba 3.6.9-151.el6_4.1", "WORKGROUP"},
+		{"windowsxp_oem", false, false, "Windows 5.1", "Windows 2000 LAN Manager", "MSHOME"},
+	}
+
+	for _, c := range cases {
+		resp, err := ParseSessionSetupResponse(loadFixture(t, c.fixture))
+		if err != nil {
+			t.Errorf("%s: ParseSessionSetupResponse() failed: %s", c.fixture, err)
+			continue
+		}
+		if resp.Unicode != c.unicode || resp.ExtendedSecurity != c.extended {
+			t.Errorf("%s: unexpected flags unicode=%v extended=%v", c.fixture, resp.Unicode, resp.ExtendedSecurity)
+		}
+		if resp.NativeOS != c.os || resp.NativeLanMan != c.lm || resp.PrimaryDomain != c.domain {
+			t.Errorf("%s: unexpected strings %q %q %q", c.fixture, resp.NativeOS, resp.NativeLanMan, resp.PrimaryDomain)
+		}
+	}
+}
+
+func TestParseSessionSetupResponseErrors(t *testing.T) {
+	data := loadFixture(t, "windowsxp_oem")
+
+	if _, err := ParseSessionSetupResponse(data[:20]); err == nil {
+		t.Errorf("ParseSessionSetupResponse() accepted a truncated message")
+	}
+
+	negotiate := append([]byte(nil), data...)
+	negotiate[8] = 0x72
+	if _, err := ParseSessionSetupResponse(negotiate); err == nil {
+		t.Errorf("ParseSessionSetupResponse() accepted a negotiate response")
+	}
+
+	blob := loadFixture(t, "samba_extended_security")
+	blob[headerLength+7] = 0xFF
+	if _, err := ParseSessionSetupResponse(blob); err == nil {
+		t.Errorf("ParseSessionSetupResponse() accepted an oversized security blob")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	fset, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	res, err := Match(fset, loadFixture(t, "windows2003_unicode"))
+	if err != nil {
+		t.Fatalf("Match() failed: %s", err)
+	}
+	if len(res.NativeOSMatches) == 0 || res.NativeOSMatches[0].Values["os.build"] != "3790" {
+		t.Errorf("Match() did not identify the native os: %v", res.NativeOSMatches)
+	}
+
+	res, err = Match(fset, loadFixture(t, "samba_extended_security"))
+	if err != nil {
+		t.Fatalf("Match() failed: %s", err)
+	}
+	if len(res.NativeLMMatches) == 0 || res.NativeLMMatches[0].Values["service.version"] != "3.6.9" {
+		t.Errorf("Match() did not identify the native lan manager: %v", res.NativeLMMatches)
+	}
+}
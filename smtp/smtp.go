@@ -0,0 +1,274 @@
+// Package smtp splits an SMTP client/server transcript into command and reply
+// exchanges, fingerprints each reply against the matching Recog smtp_*.xml
+// database, and merges the results into a single service identification.
+package smtp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+// BannerMatchKey is the Recog match key for SMTP greetings
+const BannerMatchKey = "smtp.banner"
+
+// commandDatabases maps client command verbs to the database that fingerprints the
+// server's reply. These databases do not declare a matches attribute and are
+// reachable by file name only.
+var commandDatabases = map[string]string{
+	"EHLO":  "smtp_ehlo.xml",
+	"HELO":  "smtp_ehlo.xml",
+	"HELP":  "smtp_help.xml",
+	"VRFY":  "smtp_vrfy.xml",
+	"EXPN":  "smtp_expn.xml",
+	"NOOP":  "smtp_noop.xml",
+	"QUIT":  "smtp_quit.xml",
+	"RSET":  "smtp_rset.xml",
+	"TURN":  "smtp_turn.xml",
+	"MAIL":  "smtp_mailfrom.xml",
+	"RCPT":  "smtp_rcptto.xml",
+	"DEBUG": "smtp_debug.xml",
+}
+
+// DatabaseForCommand returns the database used to fingerprint replies to a command
+// verb, or an empty string if the command has no database.
+func DatabaseForCommand(verb string) string {
+	return commandDatabases[strings.ToUpper(verb)]
+}
+
+// Reply is a complete, possibly multi-line, server reply
+type Reply struct {
+	Code  string
+	Lines []string
+}
+
+// Text returns the reply lines with the reply code and separator removed
+func (r *Reply) Text() []string {
+	res := make([]string, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		if len(line) >= 4 {
+			res = append(res, line[4:])
+		} else {
+			res = append(res, "")
+		}
+	}
+	return res
+}
+
+// Exchange is a client command and the reply it elicited. The server greeting is
+// represented by an exchange with an empty command.
+type Exchange struct {
+	Command string
+	Reply   *Reply
+}
+
+// Verb returns the upper-cased command verb, or an empty string for the greeting
+func (e *Exchange) Verb() string {
+	fields := strings.Fields(e.Command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+var replyLinePat = regexp.MustCompile(`^([2-5][0-9][0-9])([ -]|$)`)
+
+// transcriptLine is a single line of a transcript and its direction
+type transcriptLine struct {
+	client bool
+	text   string
+}
+
+// ParseTranscript reads a transcript in which every line starts with "C:" for data
+// sent by the client or "S:" for data sent by the server. Replies are paired with
+// commands in order, which also handles pipelined commands. Lines without a
+// direction prefix are ignored.
+func ParseTranscript(r io.Reader) ([]*Exchange, error) {
+	var lines []transcriptLine
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case strings.HasPrefix(line, "C:"):
+			lines = append(lines, transcriptLine{client: true, text: strings.TrimPrefix(line[2:], " ")})
+		case strings.HasPrefix(line, "S:"):
+			lines = append(lines, transcriptLine{text: strings.TrimPrefix(line[2:], " ")})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return pair(lines)
+}
+
+// pair assigns each complete server reply to the oldest client command that has
+// not yet been answered. The first reply without a command is the greeting, and
+// message content sent after a 354 reply to DATA is skipped.
+func pair(lines []transcriptLine) ([]*Exchange, error) {
+	var exchanges []*Exchange
+	var pending []string
+	var cur *Reply
+	greeted := false
+	inData := false
+
+	for _, line := range lines {
+		if line.client {
+			if inData {
+				// The lone dot ends the message and is answered like a command
+				if line.text == "." {
+					pending = append(pending, line.text)
+					inData = false
+				}
+				continue
+			}
+			pending = append(pending, line.text)
+			continue
+		}
+
+		m := replyLinePat.FindStringSubmatch(line.text)
+		if m == nil {
+			return nil, fmt.Errorf("invalid smtp reply line %q", line.text)
+		}
+		if cur == nil {
+			cur = &Reply{Code: m[1]}
+		} else if cur.Code != m[1] {
+			return nil, fmt.Errorf("smtp reply line %q does not continue reply %s", line.text, cur.Code)
+		}
+		cur.Lines = append(cur.Lines, line.text)
+
+		// A space (or nothing) after the code ends the reply
+		if m[2] == "-" {
+			continue
+		}
+
+		ex := &Exchange{Reply: cur}
+		switch {
+		case len(pending) > 0:
+			ex.Command = pending[0]
+			pending = pending[1:]
+		case greeted:
+			return nil, fmt.Errorf("smtp reply %s was not preceded by a command", cur.Code)
+		}
+		if ex.Verb() == "DATA" && cur.Code == "354" {
+			inData = true
+		}
+		greeted = true
+		exchanges = append(exchanges, ex)
+		cur = nil
+	}
+
+	if cur != nil {
+		return exchanges, fmt.Errorf("smtp reply %s is incomplete", cur.Code)
+	}
+	return exchanges, nil
+}
+
+// Evidence is a single fingerprint match along with the exchange that produced it
+type Evidence struct {
+	Command  string
+	Database string
+	Input    string
+	Match    *recog.FingerprintMatch
+}
+
+// Identification is the merged result of fingerprinting every exchange
+type Identification struct {
+	// Values holds the merged fingerprint values
+	Values map[string]string
+	// Sources records which piece of evidence supplied each merged value
+	Sources map[string]*Evidence
+	// Evidence lists every match in transcript order
+	Evidence []*Evidence
+}
+
+// Analyze fingerprints every exchange in a transcript and merges the matches into a
+// single identification. Greetings are matched against smtp.banner with the reply
+// code removed, and replies to other commands are matched one line at a time
+// against the database for their command. When matches disagree, values from the
+// database with the higher preference win, as described in smtp_banners.xml.
+func Analyze(fs *recog.FingerprintSet, exchanges []*Exchange) (*Identification, error) {
+	id := &Identification{
+		Values:  make(map[string]string),
+		Sources: make(map[string]*Evidence),
+	}
+
+	for _, ex := range exchanges {
+		if ex.Reply == nil {
+			continue
+		}
+
+		if ex.Command == "" {
+			// Only the first line of a multi-line greeting carries the banner
+			text := ex.Reply.Text()
+			if len(text) == 0 || strings.TrimSpace(text[0]) == "" {
+				continue
+			}
+			input := strings.TrimSpace(text[0])
+			m, err := fs.MatchFirst(BannerMatchKey, input)
+			if err != nil {
+				return nil, err
+			}
+			if m != nil {
+				id.Evidence = append(id.Evidence, &Evidence{Database: BannerMatchKey, Input: input, Match: m})
+			}
+			continue
+		}
+
+		db := DatabaseForCommand(ex.Verb())
+		if db == "" {
+			continue
+		}
+		for _, line := range ex.Reply.Lines {
+			m, err := fs.MatchFirst(db, line)
+			if err != nil {
+				return nil, err
+			}
+			if m != nil {
+				id.Evidence = append(id.Evidence, &Evidence{Command: ex.Command, Database: db, Input: line, Match: m})
+			}
+		}
+	}
+
+	id.merge()
+	return id, nil
+}
+
+// merge combines the evidence values, preferring databases with a higher preference
+// and, within a database, the earliest match in the transcript
+func (id *Identification) merge() {
+	ordered := make([]*Evidence, len(id.Evidence))
+	copy(ordered, id.Evidence)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return preference(ordered[i].Match) > preference(ordered[j].Match)
+	})
+
+	for _, ev := range ordered {
+		for k, v := range ev.Match.Values {
+			if _, ok := id.Values[k]; ok {
+				continue
+			}
+			id.Values[k] = v
+			id.Sources[k] = ev
+		}
+	}
+}
+
+// preference returns the preference of the database a match came from
+func preference(m *recog.FingerprintMatch) float64 {
+	if m.Fingerprint == nil || m.Fingerprint.DB == nil {
+		return 0
+	}
+	p, err := strconv.ParseFloat(m.Fingerprint.DB.Preference, 64)
+	if err != nil {
+		return 0
+	}
+	return p
+}
@@ -0,0 +1,102 @@
+package smtp
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+func loadTranscript(t *testing.T) []*Exchange {
+	fd, err := os.Open("testdata/session.txt")
+	if err != nil {
+		t.Fatalf("failed to open transcript: %s", err)
+	}
+	defer fd.Close()
+
+	exchanges, err := ParseTranscript(fd)
+	if err != nil {
+		t.Fatalf("ParseTranscript() failed: %s", err)
+	}
+	return exchanges
+}
+
+func TestParseTranscript(t *testing.T) {
+	exchanges := loadTranscript(t)
+
+	expected := []struct {
+		command string
+		code    string
+		lines   int
+	}{
+		{"", "220", 1},
+		{"EHLO client.example.com", "500", 1},
+		{"HELO client.example.com", "250", 3},
+		{"HELP", "214", 1},
+		{"MAIL FROM:<alice@example.com>", "250", 1},
+		{"RCPT TO:<bob@example.com>", "250", 1},
+		{"DATA", "354", 1},
+		{".", "250", 1},
+		{"QUIT", "221", 1},
+	}
+	if len(exchanges) != len(expected) {
+		t.Fatalf("ParseTranscript() returned %d exchanges, expected %d", len(exchanges), len(expected))
+	}
+	for i, e := range expected {
+		ex := exchanges[i]
+		if ex.Command != e.command || ex.Reply.Code != e.code || len(ex.Reply.Lines) != e.lines {
+			t.Errorf("exchange %d is %q/%s/%d, expected %q/%s/%d", i, ex.Command, ex.Reply.Code, len(ex.Reply.Lines), e.command, e.code, e.lines)
+		}
+	}
+}
+
+func TestParseTranscriptErrors(t *testing.T) {
+	cases := []string{
+		"S: 220 ready\nS: 250 unexpected\n",
+		"S: 220-ready\nS: 250 wrong code\n",
+		"S: 220-incomplete\n",
+		"S: hello\n",
+	}
+	for _, c := range cases {
+		if _, err := ParseTranscript(strings.NewReader(c)); err == nil {
+			t.Errorf("ParseTranscript() accepted %q", c)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	fset, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	id, err := Analyze(fset, loadTranscript(t))
+	if err != nil {
+		t.Fatalf("Analyze() failed: %s", err)
+	}
+
+	// The greeting, EHLO, HELP and QUIT replies each produce a match
+	databases := make(map[string]bool)
+	for _, ev := range id.Evidence {
+		databases[ev.Database] = true
+	}
+	for _, db := range []string{BannerMatchKey, "smtp_ehlo.xml", "smtp_help.xml", "smtp_quit.xml"} {
+		if !databases[db] {
+			t.Errorf("no evidence from %s: %v", db, databases)
+		}
+	}
+
+	// smtp_banners.xml has the highest preference and wins conflicting values
+	if id.Values["service.product"] != "Postfix" || id.Values["service.version"] != "3.1.4" {
+		t.Errorf("unexpected merged values: %v", id.Values)
+	}
+	if src := id.Sources["service.product"]; src == nil || src.Database != BannerMatchKey {
+		t.Errorf("service.product was not sourced from the banner: %#v", src)
+	}
+
+	// Values only present in lower preference databases are still merged
+	if id.Values["os.vendor"] != "Cisco" || id.Sources["os.vendor"].Command != "EHLO client.example.com" {
+		t.Errorf("os.vendor was not merged from the EHLO reply: %v", id.Values)
+	}
+}
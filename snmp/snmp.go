@@ -0,0 +1,301 @@
+// Package snmp fingerprints an SNMP agent from its sysDescr and sysObjectID
+// values. Both values are matched against their Recog databases and the results
+// are reconciled into a single answer, keeping the more specific value for each
+// field and reporting fields where the two databases disagree.
+package snmp
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	recog "github.com/runZeroInc/recog-go"
+	"github.com/runZeroInc/recog-go/conflict"
+)
+
+// Recog match keys for the SNMP system group
+const (
+	SysDescrMatchKey    = "snmp.sys_description"
+	SysObjectIDMatchKey = "snmp.sys_object_id"
+)
+
+// Sources recorded for each reconciled value
+const (
+	SourceSysDescr    = "sysDescr"
+	SourceSysObjectID = "sysObjectID"
+	SourceBoth        = "both"
+)
+
+// berTagOID is the universal tag of an encoded OBJECT IDENTIFIER
+const berTagOID = 0x06
+
+// ParseObjectID returns the dotted form of a sysObjectID given either as dotted
+// text, with or without a leading dot, or BER encoded. BER input may be a complete
+// OBJECT IDENTIFIER element or just its contents. Input that reads as dotted text
+// is always treated as text.
+func ParseObjectID(data []byte) (string, error) {
+	if s, ok := dottedOID(string(data)); ok {
+		return s, nil
+	}
+
+	// Strip the tag and length of a complete element
+	if len(data) >= 2 && data[0] == berTagOID {
+		if content, err := elementContents(data); err == nil {
+			data = content
+		}
+	}
+	return decodeOID(data)
+}
+
+// dottedOID validates and normalizes an OID in dotted text form
+func dottedOID(s string) (string, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), ".")
+	arcs := strings.Split(s, ".")
+	if len(arcs) < 2 {
+		return "", false
+	}
+	for _, arc := range arcs {
+		if arc == "" {
+			return "", false
+		}
+		if _, err := strconv.ParseUint(arc, 10, 64); err != nil {
+			return "", false
+		}
+	}
+	return s, true
+}
+
+// elementContents returns the contents of a single BER element, rejecting input
+// with trailing data or a length that runs past the end
+func elementContents(data []byte) ([]byte, error) {
+	n := int(data[1])
+	off := 2
+	if n&0x80 != 0 {
+		size := n & 0x7f
+		if size == 0 || size > 4 || len(data) < 2+size {
+			return nil, fmt.Errorf("invalid length encoding")
+		}
+		n = 0
+		for _, b := range data[2 : 2+size] {
+			n = n<<8 | int(b)
+		}
+		off += size
+	}
+	if off+n != len(data) {
+		return nil, fmt.Errorf("element length %d does not match %d bytes of content", n, len(data)-off)
+	}
+	return data[off:], nil
+}
+
+// decodeOID decodes the contents of a BER OBJECT IDENTIFIER
+func decodeOID(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", fmt.Errorf("empty object identifier")
+	}
+
+	var arcs []uint64
+	var v uint64
+	pending := false
+	for _, b := range data {
+		if v > (1<<57)-1 {
+			return "", fmt.Errorf("object identifier arc overflows 64 bits")
+		}
+		v = v<<7 | uint64(b&0x7f)
+		pending = true
+		if b&0x80 != 0 {
+			continue
+		}
+
+		// The first subidentifier packs the first two arcs as X*40+Y
+		if len(arcs) == 0 {
+			switch {
+			case v < 40:
+				arcs = append(arcs, 0, v)
+			case v < 80:
+				arcs = append(arcs, 1, v-40)
+			default:
+				arcs = append(arcs, 2, v-80)
+			}
+		} else {
+			arcs = append(arcs, v)
+		}
+		v = 0
+		pending = false
+	}
+	if pending {
+		return "", fmt.Errorf("object identifier ends inside a subidentifier")
+	}
+
+	parts := make([]string, len(arcs))
+	for i, arc := range arcs {
+		parts[i] = strconv.FormatUint(arc, 10)
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// Conflict is a field that the two databases set to incompatible values
+type Conflict struct {
+	Key         string
+	SysDescr    string
+	SysObjectID string
+	// Chosen is the value kept in the reconciled result
+	Chosen string
+}
+
+// Result is the reconciled identification of an SNMP agent
+type Result struct {
+	ObjectID         string
+	SysDescrMatch    *recog.FingerprintMatch
+	SysObjectIDMatch *recog.FingerprintMatch
+	// Combined is set when the sysObjectID match used the OID and sysDescr together
+	Combined bool
+	// Values holds the reconciled fingerprint values
+	Values map[string]string
+	// Sources records which match supplied each value, or SourceBoth if they agreed
+	Sources   map[string]string
+	Conflicts []Conflict
+}
+
+// Identify matches sysDescr and sysObjectID against their databases and reconciles
+// the two matches. Several sysObjectID fingerprints expect the OID followed by a
+// space and the sysDescr, so that combined form is tried before the bare OID. The
+// sysObjectID may be dotted text or BER encoded; either value may be empty.
+func Identify(fs *recog.FingerprintSet, sysDescr string, sysObjectID []byte) (*Result, error) {
+	res := &Result{}
+	sysDescr = strings.TrimSpace(sysDescr)
+
+	if len(sysObjectID) > 0 {
+		oid, err := ParseObjectID(sysObjectID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sysObjectID: %s", err)
+		}
+		res.ObjectID = oid
+	}
+
+	if sysDescr != "" {
+		m, err := fs.MatchFirst(SysDescrMatchKey, sysDescr)
+		if err != nil {
+			return nil, err
+		}
+		res.SysDescrMatch = m
+	}
+
+	if res.ObjectID != "" {
+		inputs := []string{res.ObjectID}
+		if sysDescr != "" {
+			inputs = []string{res.ObjectID + " " + sysDescr, res.ObjectID}
+		}
+		for i, input := range inputs {
+			m, err := fs.MatchFirst(SysObjectIDMatchKey, input)
+			if err != nil {
+				return nil, err
+			}
+			if m != nil {
+				res.SysObjectIDMatch = m
+				res.Combined = len(inputs) > 1 && i == 0
+				break
+			}
+		}
+	}
+
+	res.reconcile()
+	return res, nil
+}
+
+// reconcile merges the two matches. A value that contains the words of the other,
+// such as "Windows Server 2008 R2" and "Windows", is treated as more specific and
+// kept, while versions such as "12.1" and "1" conflict.
+// Otherwise the values conflict. A combined sysObjectID match saw both values and
+// wins; if not, the match with more fields in the same namespace (os, hw, service)
+// wins, with sysDescr winning ties. Match metadata is taken from sysDescr and is
+// never reported as a conflict.
+func (r *Result) reconcile() {
+	r.Values = make(map[string]string)
+	r.Sources = make(map[string]string)
+
+	var descr, objid map[string]string
+	if r.SysDescrMatch != nil {
+		descr = r.SysDescrMatch.Values
+	}
+	if r.SysObjectIDMatch != nil {
+		objid = r.SysObjectIDMatch.Values
+	}
+
+	for k, v := range descr {
+		if _, ok := objid[k]; !ok {
+			r.Values[k] = v
+			r.Sources[k] = SourceSysDescr
+		}
+	}
+	for k, v := range objid {
+		if _, ok := descr[k]; !ok {
+			r.Values[k] = v
+			r.Sources[k] = SourceSysObjectID
+		}
+	}
+
+	for k, dv := range descr {
+		ov, ok := objid[k]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case isMetadata(k):
+			r.Values[k] = dv
+			r.Sources[k] = SourceSysDescr
+		case strings.EqualFold(dv, ov):
+			r.Values[k] = dv
+			r.Sources[k] = SourceBoth
+		case refines(dv, ov):
+			r.Values[k] = dv
+			r.Sources[k] = SourceSysDescr
+		case refines(ov, dv):
+			r.Values[k] = ov
+			r.Sources[k] = SourceSysObjectID
+		default:
+			ns := namespace(k)
+			if r.Combined || namespaceFields(objid, ns) > namespaceFields(descr, ns) {
+				r.Values[k] = ov
+				r.Sources[k] = SourceSysObjectID
+			} else {
+				r.Values[k] = dv
+				r.Sources[k] = SourceSysDescr
+			}
+			r.Conflicts = append(r.Conflicts, Conflict{Key: k, SysDescr: dv, SysObjectID: ov, Chosen: r.Values[k]})
+		}
+	}
+	sort.Slice(r.Conflicts, func(i, j int) bool { return r.Conflicts[i].Key < r.Conflicts[j].Key })
+}
+
+// refines reports whether the words of general appear in order within specific
+func refines(specific string, general string) bool {
+	var words *conflict.Vocabulary
+	return words.Refines("", specific, general)
+}
+
+// isMetadata reports whether a value describes the match rather than the agent,
+// including namespace certainties such as os.certainty
+func isMetadata(key string) bool {
+	return key == "matched" || namespace(key) == "fp" || strings.HasSuffix(key, ".certainty")
+}
+
+// namespace returns the leading component of a fingerprint field name
+func namespace(key string) string {
+	if i := strings.IndexByte(key, '.'); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+// namespaceFields counts the values set in a namespace, ignoring metadata
+func namespaceFields(values map[string]string, ns string) int {
+	n := 0
+	for k := range values {
+		if namespace(k) == ns && !isMetadata(k) {
+			n++
+		}
+	}
+	return n
+}
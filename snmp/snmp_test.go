@@ -0,0 +1,119 @@
+package snmp
+
+import (
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+func TestParseObjectID(t *testing.T) {
+	cases := []struct {
+		in  []byte
+		out string
+	}{
+		{[]byte("1.3.6.1.4.1.8072.3.2.10"), "1.3.6.1.4.1.8072.3.2.10"},
+		{[]byte(".1.3.6.1.4.1.311.1.1.3.1.2"), "1.3.6.1.4.1.311.1.1.3.1.2"},
+		{[]byte{0x06, 0x0a, 0x2b, 0x06, 0x01, 0x04, 0x01, 0xbf, 0x08, 0x03, 0x02, 0x0a}, "1.3.6.1.4.1.8072.3.2.10"},
+		{[]byte{0x2b, 0x06, 0x01, 0x04, 0x01, 0x82, 0x37, 0x01, 0x01, 0x03, 0x01, 0x02}, "1.3.6.1.4.1.311.1.1.3.1.2"},
+		{[]byte{0x06, 0x81, 0x03, 0x2b, 0x06, 0x01}, "1.3.6.1"},
+		{[]byte{0x88, 0x37, 0x03}, "2.999.3"},
+	}
+
+	for _, c := range cases {
+		out, err := ParseObjectID(c.in)
+		if err != nil {
+			t.Errorf("ParseObjectID(%x) failed: %s", c.in, err)
+			continue
+		}
+		if out != c.out {
+			t.Errorf("ParseObjectID(%x) returned %s, expected %s", c.in, out, c.out)
+		}
+	}
+
+	for _, in := range [][]byte{{}, {0x2b, 0x06, 0x81}} {
+		if _, err := ParseObjectID(in); err == nil {
+			t.Errorf("ParseObjectID(%x) accepted invalid input", in)
+		}
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	fset, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	// The sysDescr alone cannot tell Windows 7 from Server 2008 R2, the OID can
+	res, err := Identify(fset, "Hardware: Intel64 Family 6 Model 44 Stepping 2 AT/AT COMPATIBLE - Software: Windows Version 6.1 (Build 7601 Multiprocessor Free)", []byte("1.3.6.1.4.1.311.1.1.3.1.2"))
+	if err != nil {
+		t.Fatalf("Identify() failed: %s", err)
+	}
+	if !res.Combined || res.Values["os.product"] != "Windows Server 2008 R2" || res.Sources["os.product"] != SourceSysObjectID {
+		t.Errorf("Identify() did not prefer the combined sysObjectID match: %v %v", res.Values, res.Sources)
+	}
+	if res.Sources["os.family"] != SourceBoth {
+		t.Errorf("Identify() did not record agreement on os.family: %v", res.Sources)
+	}
+	if len(res.Conflicts) == 0 || res.Conflicts[len(res.Conflicts)-1].Key != "os.product" {
+		t.Errorf("Identify() did not report the os.product conflict: %+v", res.Conflicts)
+	}
+
+	// Net-SNMP OIDs identify the agent, the sysDescr identifies the kernel
+	oid := []byte{0x06, 0x0a, 0x2b, 0x06, 0x01, 0x04, 0x01, 0xbf, 0x08, 0x03, 0x02, 0x0a}
+	res, err = Identify(fset, "Linux web01 3.10.0-1160.el7.x86_64 #1 SMP Mon Oct 19 16:18:59 UTC 2020 x86_64", oid)
+	if err != nil {
+		t.Fatalf("Identify() failed: %s", err)
+	}
+	if res.Combined || res.Values["service.vendor"] != "Net-SNMP" || res.Values["os.version"] != "3.10.0-1160.el7.x86_64" {
+		t.Errorf("Identify() did not merge both matches: %v", res.Values)
+	}
+	if len(res.Conflicts) != 0 {
+		t.Errorf("Identify() reported unexpected conflicts: %+v", res.Conflicts)
+	}
+
+	// An agent built for Linux reporting a Solaris sysDescr
+	res, err = Identify(fset, "SunOS sun1 5.10 Generic_147440-01 sun4v", []byte("1.3.6.1.4.1.8072.3.2.10"))
+	if err != nil {
+		t.Fatalf("Identify() failed: %s", err)
+	}
+	if res.Values["os.family"] != "Solaris" {
+		t.Errorf("Identify() did not prefer the more detailed sysDescr match: %v", res.Values)
+	}
+	found := false
+	for _, c := range res.Conflicts {
+		if c.Key == "os.family" && c.SysDescr == "Solaris" && c.SysObjectID == "Linux" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Identify() did not report the os.family conflict: %+v", res.Conflicts)
+	}
+
+	if _, err := Identify(fset, "", []byte{0x2b, 0x86}); err == nil {
+		t.Errorf("Identify() accepted a truncated sysObjectID")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	res := &Result{
+		SysDescrMatch: &recog.FingerprintMatch{Values: map[string]string{
+			"os.product": "Windows Server 2008 R2", "os.version": "12.1", "hw.certainty": "0.9",
+		}},
+		SysObjectIDMatch: &recog.FingerprintMatch{Values: map[string]string{
+			"os.product": "Windows", "os.version": "1", "os.certainty": "0.5", "hw.certainty": "0.4",
+		}},
+	}
+	res.reconcile()
+
+	// Certainties are metadata, so they neither conflict nor count towards the
+	// tiebreak, which sysDescr wins. The versions conflict rather than refine.
+	if len(res.Conflicts) != 1 || res.Conflicts[0].Key != "os.version" || res.Values["os.version"] != "12.1" {
+		t.Errorf("reconcile() reported unexpected conflicts: %+v %v", res.Conflicts, res.Values)
+	}
+	if res.Values["os.product"] != "Windows Server 2008 R2" || res.Sources["os.product"] != SourceSysDescr {
+		t.Errorf("reconcile() did not keep the more specific product: %v %v", res.Values, res.Sources)
+	}
+	if res.Values["hw.certainty"] != "0.9" {
+		t.Errorf("reconcile() did not take metadata from sysDescr: %v", res.Values)
+	}
+}
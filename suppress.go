@@ -0,0 +1,125 @@
+package recog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// SuppressionsFile is loaded alongside the XML databases by LoadFingerprintsFromFS
+// when it is present
+const SuppressionsFile = "suppressions.json"
+
+// Suppression marks the fingerprint with a pattern as a suppressor, or adds a new
+// suppressing fingerprint to the database if it has no such pattern
+type Suppression struct {
+	// Database is the file name of the database, such as http_servers.xml
+	Database string `json:"database"`
+	Pattern  string `json:"pattern"`
+	// Description explains what the input is, such as a honeypot
+	Description string `json:"description,omitempty"`
+}
+
+// Suppressions is a set of suppressions, stored as JSON:
+//
+//	{"suppressions": [
+//	  {"database": "http_servers.xml", "pattern": "^Apache$", "description": "Generic placeholder"},
+//	  {"database": "ssh_banners.xml", "pattern": "^SSH-2.0-Cowrie", "description": "Cowrie honeypot"}
+//	]}
+type Suppressions struct {
+	Suppressions []Suppression `json:"suppressions"`
+}
+
+// IsSuppressed reports whether matches are the result of a suppressing
+// fingerprint, which is always returned alone
+func IsSuppressed(matches []*FingerprintMatch) bool {
+	return len(matches) == 1 && matches[0].Suppressed
+}
+
+// ReadSuppressions parses suppressions from JSON
+func ReadSuppressions(r io.Reader) (*Suppressions, error) {
+	var res Suppressions
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to parse suppressions: %s", err)
+	}
+	for _, s := range res.Suppressions {
+		if s.Database == "" || s.Pattern == "" {
+			return nil, fmt.Errorf("suppression needs a database and a pattern")
+		}
+	}
+	return &res, nil
+}
+
+// AddSuppressions applies suppressions to the loaded databases. New fingerprints
+// are added after the existing ones, keeping their positions.
+func (fs *FingerprintSet) AddSuppressions(s *Suppressions) error {
+	defer fs.Cache.Purge()
+
+	for _, sup := range s.Suppressions {
+		fdbs := fs.databasesByName(sup.Database)
+		if len(fdbs) == 0 {
+			return fmt.Errorf("suppression for missing database %s", sup.Database)
+		}
+		for _, fdb := range fdbs {
+			if err := fdb.addSuppression(sup); err != nil {
+				return fmt.Errorf("failed to add suppression to %s: %s", sup.Database, err)
+			}
+		}
+	}
+	return nil
+}
+
+// addSuppression flags or adds the fingerprint of a suppression
+func (fdb *FingerprintDB) addSuppression(sup Suppression) error {
+	var fp *Fingerprint
+	for _, f := range fdb.Fingerprints {
+		if f.Pattern == sup.Pattern {
+			fp = f
+			break
+		}
+	}
+	if fp == nil {
+		fp = &Fingerprint{Pattern: sup.Pattern, DB: fdb, Index: len(fdb.Fingerprints)}
+		if err := fp.Normalize(); err != nil {
+			return err
+		}
+		fdb.Fingerprints = append(fdb.Fingerprints, fp)
+	}
+	fp.Suppress = true
+	if sup.Description != "" {
+		fp.Description = &FingerprintDescription{Text: sup.Description}
+	}
+	fdb.indexSuppressors()
+	return nil
+}
+
+// LoadSuppressions reads a suppressions file and applies it
+func (fs *FingerprintSet) LoadSuppressions(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open suppressions: %s", err)
+	}
+	defer f.Close()
+	s, err := ReadSuppressions(f)
+	if err != nil {
+		return err
+	}
+	return fs.AddSuppressions(s)
+}
+
+// loadSuppressionsFS applies the suppressions file of a database directory, if
+// there is one
+func (fs *FingerprintSet) loadSuppressionsFS(efs http.FileSystem) error {
+	f, err := efs.Open(SuppressionsFile)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	s, err := ReadSuppressions(f)
+	if err != nil {
+		return err
+	}
+	return fs.AddSuppressions(s)
+}
@@ -0,0 +1,139 @@
+package recog
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const suppressDB = `<?xml version='1.0' encoding='UTF-8'?>
+<fingerprints matches="test.banner" protocol="test" database_type="service" preference="0.90">
+  <fingerprint pattern="^Apache/(\S+)(?: (.*))?$">
+    <description>Apache</description>
+    <param pos="0" name="service.product" value="HTTPD"/>
+    <param pos="1" name="service.version"/>
+    <param pos="2" name="test.info"/>
+  </fingerprint>
+  <fingerprint pattern="^Apache/(\S+) \(Cowrie\)$">
+    <description>Cowrie</description>
+    <param pos="0" name="service.product" value="HTTPD"/>
+    <param pos="1" name="service.version"/>
+  </fingerprint>
+  <fingerprint pattern="^Apache/0\.0(?: (.*))?$" suppress="true">
+    <description>Placeholder banner</description>
+    <param pos="1" name="test.info"/>
+  </fingerprint>
+</fingerprints>
+`
+
+const suppressExtraDB = `<?xml version='1.0' encoding='UTF-8'?>
+<fingerprints matches="test.banner" protocol="test" database_type="service" preference="0.80">
+  <fingerprint pattern="^Apache">
+    <description>Apache again</description>
+  </fingerprint>
+</fingerprints>
+`
+
+const suppressInfoDB = `<?xml version='1.0' encoding='UTF-8'?>
+<fingerprints matches="test.inner" protocol="test" database_type="util.os" preference="0.90">
+  <fingerprint pattern="^(.+)$">
+    <description>Anything</description>
+    <param pos="1" name="os.product"/>
+  </fingerprint>
+</fingerprints>
+`
+
+// suppressions adds a new suppressor and flags an existing fingerprint
+const suppressions = `{"suppressions": [
+  {"database": "test_banner.xml", "pattern": "^Apache/honeypot$", "description": "Honeypot"},
+  {"database": "test_banner.xml", "pattern": "^Apache/(\\S+) \\(Cowrie\\)$"}
+]}`
+
+func TestSuppression(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"test_banner.xml": suppressDB,
+		"test_extra.xml":  suppressExtraDB,
+		"test_inner.xml":  suppressInfoDB,
+		SuppressionsFile:  suppressions,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %s", name, err)
+		}
+	}
+	fset, err := LoadFingerprintsDir(dir)
+	if err != nil {
+		t.Fatalf("LoadFingerprintsDir() failed: %s", err)
+	}
+	fset.AddRoutes(Route{Key: "test.info", MatchKeys: []string{"test.inner"}})
+
+	// Suppressors win over the fingerprints before them and the other databases
+	for _, input := range []string{"Apache/0.0", "Apache/honeypot", "Apache/2.4 (Cowrie)"} {
+		m, err := fset.MatchFirst("test.banner", input)
+		if err != nil || m == nil || !m.Suppressed {
+			t.Errorf("expected a suppressed match for %q, got %+v %v", input, m, err)
+		}
+		if m != nil && (m.Values["test.info"] != "" || m.Values["service.product"] != "") {
+			t.Errorf("expected a suppressed match to attribute nothing, got %v", m.Values)
+		}
+		ms, err := fset.MatchAll("test.banner", input)
+		if err != nil || !IsSuppressed(ms) {
+			t.Errorf("expected a single suppressed match for %q, got %d %v", input, len(ms), err)
+		}
+	}
+	ms, err := fset.MatchAll("test.banner", "Apache/2.4")
+	if err != nil || len(ms) != 2 || IsSuppressed(ms) {
+		t.Errorf("expected 2 matches, got %d %v", len(ms), err)
+	}
+	if m := fset.DatabasesByMatchKey["test_banner.xml"][0].MatchFirst("Apache/0.0"); m == nil || !m.Suppressed {
+		t.Errorf("expected the database to suppress, got %+v", m)
+	}
+
+	// Traversal stops at a suppressed match, which adds no facts
+	graph, err := TraverseMatchGraph(context.Background(), fset, "test.banner", "Apache/0.0 (Linux)", TraverseOptions{})
+	if err != nil {
+		t.Fatalf("TraverseMatchGraph() failed: %s", err)
+	}
+	if len(graph.Nodes) != 1 || len(graph.Suppressed()) != 1 || len(graph.Facts()) != 0 {
+		t.Errorf("expected a single suppressed node, got %d nodes %v", len(graph.Nodes), graph.Facts().Values())
+	}
+	var buf bytes.Buffer
+	if err := WriteGraphML(&buf, ExportGraph{Name: "Apache/0.0 (Linux)", Nodes: graph.Nodes, Edges: graph.Edges}); err != nil {
+		t.Fatalf("WriteGraphML() failed: %s", err)
+	}
+	if !strings.Contains(buf.String(), `<data key="suppressed">true</data>`) {
+		t.Errorf("expected the GraphML node to be marked suppressed:\n%s", buf.String())
+	}
+	graph, err = TraverseMatchGraph(context.Background(), fset, "test.banner", "Apache/2.4 (Linux)", TraverseOptions{})
+	if err != nil {
+		t.Fatalf("TraverseMatchGraph() failed: %s", err)
+	}
+	if len(graph.Suppressed()) != 0 || graph.Facts().Values()["os.product"] != "(Linux)" {
+		t.Errorf("unexpected traversal: %v", graph.Facts().Values())
+	}
+
+	// MatchFirst only uses the first database, but the suppressors of the others apply
+	err = fset.AddSuppressions(&Suppressions{Suppressions: []Suppression{{Database: "test_extra.xml", Pattern: "^Apache/9"}}})
+	if err != nil {
+		t.Fatalf("AddSuppressions() failed: %s", err)
+	}
+	if m, err := fset.MatchFirst("test.banner", "Apache/9.9"); err != nil || m == nil || !m.Suppressed || m.Fingerprint.DB.Name != "test_extra.xml" {
+		t.Errorf("expected the second database to suppress, got %+v %v", m, err)
+	}
+
+	err = fset.AddSuppressions(&Suppressions{Suppressions: []Suppression{{Database: "missing.xml", Pattern: "^x"}}})
+	if err == nil || !strings.Contains(err.Error(), "missing.xml") {
+		t.Errorf("expected an error for a missing database, got %v", err)
+	}
+	err = fset.AddSuppressions(&Suppressions{Suppressions: []Suppression{{Database: "test_banner.xml", Pattern: "(bad"}}})
+	if err == nil {
+		t.Errorf("expected an error for a bad pattern")
+	}
+	if _, err := ReadSuppressions(strings.NewReader(`{"suppressions": [{"database": "a.xml"}]}`)); err == nil {
+		t.Errorf("expected an error for a suppression without a pattern")
+	}
+}
@@ -0,0 +1,268 @@
+// Package telnet strips Telnet option negotiation from captured server output so
+// the remaining banner can be matched against the Recog telnet_banners.xml
+// database. Subnegotiation payloads such as the terminal type are decoded and
+// returned alongside the cleaned banner.
+package telnet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+// MatchKey is the Recog match key for the Telnet banner database, which is only
+// reachable by its file name since it does not declare a matches attribute
+const MatchKey = "telnet_banners.xml"
+
+// Telnet command codes (RFC 854)
+const (
+	SE   = 240
+	NOP  = 241
+	SB   = 250
+	WILL = 251
+	WONT = 252
+	DO   = 253
+	DONT = 254
+	IAC  = 255
+)
+
+// Telnet option codes with decoded subnegotiations
+const (
+	OptionBinary          = 0
+	OptionEcho            = 1
+	OptionSuppressGoAhead = 3
+	OptionStatus          = 5
+	OptionTimingMark      = 6
+	OptionTerminalType    = 24
+	OptionWindowSize      = 31
+	OptionTerminalSpeed   = 32
+	OptionFlowControl     = 33
+	OptionLinemode        = 34
+	OptionXDisplay        = 35
+	OptionEnviron         = 36
+	OptionNewEnviron      = 39
+)
+
+var commandNames = map[byte]string{
+	WILL: "WILL",
+	WONT: "WONT",
+	DO:   "DO",
+	DONT: "DONT",
+}
+
+var optionNames = map[byte]string{
+	OptionBinary:          "binary",
+	OptionEcho:            "echo",
+	OptionSuppressGoAhead: "suppress-go-ahead",
+	OptionStatus:          "status",
+	OptionTimingMark:      "timing-mark",
+	OptionTerminalType:    "terminal-type",
+	OptionWindowSize:      "window-size",
+	OptionTerminalSpeed:   "terminal-speed",
+	OptionFlowControl:     "remote-flow-control",
+	OptionLinemode:        "linemode",
+	OptionXDisplay:        "x-display-location",
+	OptionEnviron:         "environ",
+	OptionNewEnviron:      "new-environ",
+}
+
+// OptionName returns a human-readable name for a Telnet option code
+func OptionName(opt byte) string {
+	if name, ok := optionNames[opt]; ok {
+		return name
+	}
+	return fmt.Sprintf("option-%d", opt)
+}
+
+// Negotiation is a single WILL, WONT, DO or DONT request
+type Negotiation struct {
+	Command byte
+	Option  byte
+}
+
+// String returns the negotiation in the conventional "DO terminal-type" form
+func (n Negotiation) String() string {
+	return commandNames[n.Command] + " " + OptionName(n.Option)
+}
+
+// Subnegotiation is the raw payload of an IAC SB ... IAC SE sequence
+type Subnegotiation struct {
+	Option byte
+	Data   []byte
+}
+
+// Banner is a Telnet capture with the option negotiation removed
+type Banner struct {
+	Text            string
+	Negotiations    []Negotiation
+	Subnegotiations []Subnegotiation
+	// Values holds decoded subnegotiation data keyed by "telnet.<option>"
+	Values map[string]string
+}
+
+// Normalize removes Telnet commands and option negotiation from captured server
+// output. NVT CR NUL sequences become a bare CR, but CR LF line endings are kept
+// for the multi-line patterns of telnet_banners.xml, and surrounding CR, LF and
+// whitespace are trimmed.
+func Normalize(data []byte) *Banner {
+	b := &Banner{Values: make(map[string]string)}
+	text := make([]byte, 0, len(data))
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c != IAC {
+			// CR NUL is the NVT encoding of a bare carriage return
+			if c == 0 && i > 0 && data[i-1] == '\r' {
+				continue
+			}
+			text = append(text, c)
+			continue
+		}
+
+		if i+1 >= len(data) {
+			break
+		}
+		cmd := data[i+1]
+		switch {
+		case cmd == IAC:
+			// An escaped 0xFF data byte
+			text = append(text, IAC)
+			i++
+		case cmd >= WILL && cmd <= DONT:
+			if i+2 < len(data) {
+				b.Negotiations = append(b.Negotiations, Negotiation{Command: cmd, Option: data[i+2]})
+			}
+			i += 2
+		case cmd == SB:
+			n := b.readSubnegotiation(data[i+2:])
+			i += 1 + n
+		default:
+			// Two byte commands such as NOP, GA and AYT carry no data
+			i++
+		}
+	}
+
+	b.Text = strings.TrimSpace(string(text))
+	return b
+}
+
+// readSubnegotiation consumes the data following IAC SB and returns the number of
+// bytes used, including the closing IAC SE when present.
+func (b *Banner) readSubnegotiation(data []byte) int {
+	if len(data) == 0 {
+		return 0
+	}
+
+	opt := data[0]
+	var payload []byte
+	i := 1
+	for ; i < len(data); i++ {
+		if data[i] != IAC {
+			payload = append(payload, data[i])
+			continue
+		}
+		if i+1 < len(data) && data[i+1] == IAC {
+			payload = append(payload, IAC)
+			i++
+			continue
+		}
+		if i+1 < len(data) && data[i+1] == SE {
+			i += 2
+			break
+		}
+		// A malformed sequence ends the subnegotiation at the next command
+		break
+	}
+
+	b.Subnegotiations = append(b.Subnegotiations, Subnegotiation{Option: opt, Data: payload})
+	b.decodeSubnegotiation(opt, payload)
+	return i
+}
+
+// decodeSubnegotiation stores the readable contents of known subnegotiations
+func (b *Banner) decodeSubnegotiation(opt byte, data []byte) {
+	key := "telnet." + OptionName(opt)
+	switch opt {
+	case OptionTerminalType, OptionTerminalSpeed, OptionXDisplay:
+		// IS (0) is followed by the value, SEND (1) has no value
+		if len(data) > 1 && data[0] == 0 {
+			b.Values[key] = string(data[1:])
+		}
+	case OptionWindowSize:
+		if len(data) == 4 {
+			b.Values[key] = fmt.Sprintf("%dx%d", binary.BigEndian.Uint16(data[0:2]), binary.BigEndian.Uint16(data[2:4]))
+		}
+	case OptionEnviron, OptionNewEnviron:
+		// IS (0) or INFO (2) followed by VAR/USERVAR name VALUE value pairs
+		if len(data) > 1 && (data[0] == 0 || data[0] == 2) {
+			for name, value := range decodeEnviron(data[1:]) {
+				b.Values[key+"."+name] = value
+			}
+		}
+	}
+}
+
+// decodeEnviron parses the variable list of an ENVIRON or NEW-ENVIRON subnegotiation
+func decodeEnviron(data []byte) map[string]string {
+	const (
+		envVar     = 0
+		envValue   = 1
+		envEsc     = 2
+		envUserVar = 3
+	)
+
+	vars := make(map[string]string)
+	var name, value []byte
+	inValue := false
+	started := false
+	flush := func() {
+		if started && len(name) > 0 {
+			vars[string(name)] = string(value)
+		}
+		name, value, inValue = nil, nil, false
+	}
+
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case envVar, envUserVar:
+			flush()
+			started = true
+		case envValue:
+			inValue = true
+		case envEsc:
+			if i+1 < len(data) {
+				i++
+				if inValue {
+					value = append(value, data[i])
+				} else {
+					name = append(name, data[i])
+				}
+			}
+		default:
+			if inValue {
+				value = append(value, data[i])
+			} else {
+				name = append(name, data[i])
+			}
+		}
+	}
+	flush()
+	return vars
+}
+
+// Match normalizes a Telnet capture and matches the cleaned banner against the
+// telnet_banners database in the provided FingerprintSet.
+func Match(fs *recog.FingerprintSet, data []byte) (*Banner, []*recog.FingerprintMatch, error) {
+	b := Normalize(data)
+	if b.Text == "" {
+		return b, nil, nil
+	}
+
+	matches, err := fs.MatchAll(MatchKey, b.Text)
+	if err != nil {
+		return b, nil, err
+	}
+	return b, matches, nil
+}
@@ -0,0 +1,94 @@
+package telnet
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	recog "github.com/runZeroInc/recog-go"
+)
+
+func loadFixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile("testdata/" + name + ".hex")
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %s", name, err)
+	}
+	raw, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("bad fixture %s: %s", name, err)
+	}
+	return raw
+}
+
+func TestNormalize(t *testing.T) {
+	b := Normalize(loadFixture(t, "mikrotik_login"))
+	if b.Text != "MikroTik v6.42.3 (stable)\r\nLogin:" {
+		t.Errorf("Normalize() returned %q", b.Text)
+	}
+
+	var negotiations []string
+	for _, n := range b.Negotiations {
+		negotiations = append(negotiations, n.String())
+	}
+	if strings.Join(negotiations, ",") != "DO terminal-type,WILL echo,WILL suppress-go-ahead" {
+		t.Errorf("unexpected negotiations: %v", negotiations)
+	}
+	if len(b.Subnegotiations) != 1 || b.Subnegotiations[0].Option != OptionTerminalType {
+		t.Errorf("unexpected subnegotiations: %v", b.Subnegotiations)
+	}
+}
+
+func TestNormalizeSubnegotiation(t *testing.T) {
+	b := Normalize(loadFixture(t, "client_subnegotiation"))
+	if b.Text != "ls\r\xff" {
+		t.Errorf("Normalize() returned %q", b.Text)
+	}
+
+	expected := map[string]string{
+		"telnet.terminal-type":       "XTERM-256COLOR",
+		"telnet.window-size":         "80x24",
+		"telnet.new-environ.USER":    "root",
+		"telnet.new-environ.DISPLAY": "host:0",
+	}
+	for k, v := range expected {
+		if b.Values[k] != v {
+			t.Errorf("Values[%s] is %q, expected %q", k, b.Values[k], v)
+		}
+	}
+}
+
+func TestNormalizeTruncated(t *testing.T) {
+	b := Normalize([]byte{'o', 'k', IAC, SB, OptionTerminalType, 0, 'v', 't'})
+	if b.Text != "ok" || b.Values["telnet.terminal-type"] != "vt" {
+		t.Errorf("Normalize() returned %q with %v", b.Text, b.Values)
+	}
+
+	b = Normalize([]byte{'o', 'k', IAC})
+	if b.Text != "ok" {
+		t.Errorf("Normalize() returned %q", b.Text)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	fset, err := recog.LoadFingerprints()
+	if err != nil {
+		t.Fatalf("LoadFingerprints() failed: %s", err)
+	}
+
+	_, matches, err := Match(fset, loadFixture(t, "mikrotik_login"))
+	if err != nil {
+		t.Fatalf("Match() failed: %s", err)
+	}
+	if len(matches) == 0 || matches[0].Values["os.version"] != "6.42.3" {
+		t.Errorf("Match() did not identify MikroTik: %v", matches)
+	}
+
+	_, matches, err = Match(fset, loadFixture(t, "cisco_user_access"))
+	if err != nil {
+		t.Fatalf("Match() failed: %s", err)
+	}
+	if len(matches) == 0 || matches[0].Values["hw.vendor"] != "Cisco" {
+		t.Errorf("Match() did not identify Cisco: %v", matches)
+	}
+}